@@ -0,0 +1,114 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// MarketSubscriptionGroup is a set of WebSocket subscriptions made together, one per
+// channel and market, that can be released as a unit
+type MarketSubscriptionGroup struct {
+	ws        *WSClient
+	channels  []string
+	marketIDs []int
+}
+
+// MarketIDs returns the markets the group is subscribed to, in ascending order
+func (g *MarketSubscriptionGroup) MarketIDs() []int {
+	ids := make([]int, len(g.marketIDs))
+	copy(ids, g.marketIDs)
+	return ids
+}
+
+// Channels returns the channels subscribed for each market
+func (g *MarketSubscriptionGroup) Channels() []string {
+	channels := make([]string, len(g.channels))
+	copy(channels, g.channels)
+	return channels
+}
+
+// Unsubscribe removes every subscription in the group, returning the first error
+func (g *MarketSubscriptionGroup) Unsubscribe() error {
+	var firstErr error
+	for _, id := range g.marketIDs {
+		for _, channel := range g.channels {
+			if err := g.ws.UnsubscribeBinary(channel, id); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// SubscribeMyActiveMarkets subscribes ws to the given channels for every market in which
+// the user has an open order or a non-zero position. Subscriptions are binary, keyed by
+// market id; if any subscription fails, those already made are undone.
+func (c *Client) SubscribeMyActiveMarkets(ctx context.Context, ws *WSClient, channels []string) (*MarketSubscriptionGroup, error) {
+	if ws == nil {
+		return nil, &InvalidParamError{Message: "ws client is required"}
+	}
+	if len(channels) == 0 {
+		return nil, &InvalidParamError{Message: "at least one channel is required"}
+	}
+
+	marketIDs, err := c.activeMarketIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &MarketSubscriptionGroup{ws: ws, channels: append([]string(nil), channels...)}
+	for _, id := range marketIDs {
+		for _, channel := range channels {
+			if err := ws.SubscribeBinary(channel, id); err != nil {
+				// Release the partial group, including the market being subscribed
+				group.marketIDs = append(group.marketIDs, id)
+				group.Unsubscribe()
+				return nil, fmt.Errorf("failed to subscribe %s for market %d: %w", channel, id, err)
+			}
+		}
+		group.marketIDs = append(group.marketIDs, id)
+	}
+
+	return group, nil
+}
+
+// activeMarketIDs returns the distinct markets with open orders or non-zero positions
+func (c *Client) activeMarketIDs(ctx context.Context) ([]int, error) {
+	seen := make(map[int]bool)
+
+	orders := c.IterateMyOrders(0, OrderStatusFilterOpen)
+	for {
+		order, ok, err := orders.Next(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch open orders: %w", err)
+		}
+		if !ok {
+			break
+		}
+		seen[order.MarketID] = true
+	}
+
+	positions, err := c.allMyPositions(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+	for _, p := range positions {
+		shares, err := parseAPIAmount("sharesOwned", p.SharesOwned)
+		if err != nil {
+			return nil, err
+		}
+		if shares.Sign() != 0 {
+			seen[p.MarketID] = true
+		}
+	}
+
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		if id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
@@ -0,0 +1,52 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"testing"
+)
+
+func TestSubscribeMyActiveMarkets(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		if status := r.URL.Query().Get("status"); status != "1" {
+			t.Errorf("listed orders with status %q, want open orders", status)
+		}
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":3,"list":[{"marketId":5},{"marketId":3},{"marketId":5}]}}`)
+	})
+	// Market 9 has no shares left, so it is not active
+	f.handle("/positions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":3,"list":[{"marketId":3,"sharesOwned":"1"},{"marketId":8,"sharesOwned":"2.5"},{"marketId":9,"sharesOwned":"0"}]}}`)
+	})
+	c := newTestClient(t, f)
+
+	server := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: server.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+
+	group, err := c.SubscribeMyActiveMarkets(context.Background(), ws, []string{ChannelMarketDepthDiff, ChannelMarketLastPrice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := fmt.Sprint(group.MarketIDs()); ids != "[3 5 8]" {
+		t.Fatalf("subscribed markets %s, want [3 5 8]", ids)
+	}
+	subs := ws.GetSubscriptions()
+	sort.Strings(subs)
+	if len(subs) != 6 {
+		t.Fatalf("got %d subscriptions, want 6: %v", len(subs), subs)
+	}
+
+	if err := group.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+	if subs := ws.GetSubscriptions(); len(subs) != 0 {
+		t.Fatalf("subscriptions left after Unsubscribe: %v", subs)
+	}
+}
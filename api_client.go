@@ -2,23 +2,79 @@ package opinionclob
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// IdempotencyKeyHeader carries an order's client order ID on PlaceOrder requests
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RequestIDHeader carries the ID that correlates a request with the backend's logs
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a context whose requests are sent with id as their
+// request ID instead of a generated one. It applies to calls that send their requests with
+// the caller's context, such as Client.PlaceOrder and Client.Ping; requests made without
+// one get a generated ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set with ContextWithRequestID, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// newRequestID generates a random request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// requestID returns the request ID sent with the request that produced resp
+func requestID(resp *http.Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(RequestIDHeader)
+}
+
 // APIClient handles HTTP requests to the Opinion CLOB API
 type APIClient struct {
 	host    string
 	apiKey  string
 	chainID ChainID
 	client  *http.Client
+	headers http.Header // extra headers attached to every request
+	// overrideStandardHeaders lets headers replace Content-Type and apikey
+	overrideStandardHeaders bool
+	logger                  Logger
+	metrics                 MetricsCollector
+
+	ctx      context.Context // cancelled by Close to abort requests still running
+	cancel   context.CancelFunc
+	inflight sync.WaitGroup // requests whose response body is not yet closed
+	closeMu  sync.RWMutex
+	closed   bool
 }
 
 // NewAPIClient creates a new API client
 func NewAPIClient(host, apiKey string, chainID ChainID) *APIClient {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &APIClient{
 		host:    host,
 		apiKey:  apiKey,
@@ -26,11 +82,96 @@ func NewAPIClient(host, apiKey string, chainID ChainID) *APIClient {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		logger:  NopLogger{},
+		metrics: NopMetrics{},
+		ctx:     ctx,
+		cancel:  cancel,
 	}
 }
 
+// Close stops new requests, waits up to timeout for in-flight requests to finish (their
+// response bodies closed), then aborts any still running. It reports whether all
+// requests finished in time. Calling Close again only waits for stragglers.
+func (c *APIClient) Close(timeout time.Duration) bool {
+	c.closeMu.Lock()
+	c.closed = true
+	c.closeMu.Unlock()
+
+	drained := waitTimeout(&c.inflight, timeout)
+	c.cancel()
+	return drained
+}
+
+// beginRequest registers an in-flight request, failing once the client is closed
+func (c *APIClient) beginRequest() bool {
+	c.closeMu.RLock()
+	defer c.closeMu.RUnlock()
+	if c.closed {
+		return false
+	}
+	c.inflight.Add(1)
+	return true
+}
+
+// trackedBody marks its request finished when the response body is closed
+type trackedBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// SetLogger sets the logger for request events; nil restores the no-op logger
+func (c *APIClient) SetLogger(logger Logger) {
+	c.logger = loggerOrNop(logger)
+}
+
+// SetMetrics sets the collector for request and order metrics; nil restores the no-op collector
+func (c *APIClient) SetMetrics(metrics MetricsCollector) {
+	c.metrics = metricsOrNop(metrics)
+}
+
 // doRequest performs an HTTP request
 func (c *APIClient) doRequest(method, endpoint string, body interface{}) (*http.Response, error) {
+	return c.doRequestWithHeaders(method, endpoint, body, nil)
+}
+
+// doRequestWithHeaders performs an HTTP request with per-request headers, which are
+// applied after the standard and extra headers
+func (c *APIClient) doRequestWithHeaders(method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
+	return c.doRequestContext(c.ctx, method, endpoint, body, headers)
+}
+
+// requestContext returns a context for a request made on behalf of ctx that Close also
+// cancels. It carries ctx's values, such as the request ID.
+func (c *APIClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	reqCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.ctx, cancel)
+	return reqCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// doRequestContext performs an HTTP request bound to ctx, which must end no later than
+// the client's own context so that Close can abort it
+func (c *APIClient) doRequestContext(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
+	if !c.beginRequest() {
+		return nil, ErrClientClosed
+	}
+	// The request stays in flight until the caller closes the response body
+	handedOff := false
+	defer func() {
+		if !handedOff {
+			c.inflight.Done()
+		}
+	}()
+
 	var reqBody io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
@@ -41,22 +182,66 @@ func (c *APIClient) doRequest(method, endpoint string, body interface{}) (*http.
 	}
 
 	url := fmt.Sprintf("%s%s", c.host, endpoint)
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("apikey", c.apiKey)
+	if id, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set(RequestIDHeader, id)
+	} else if id := newRequestID(); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	c.applyHeaders(req)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	reqID := req.Header.Get(RequestIDHeader)
 
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	duration := time.Since(start)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		c.metrics.ObserveRequest(method, metricsRoute(endpoint), 0, duration)
+		c.logger.Warn("api request failed", "method", method, "endpoint", endpoint, "requestId", reqID, "duration", duration, "error", err)
+		return nil, fmt.Errorf("request failed (request ID: %s): %w", reqID, err)
 	}
+	c.metrics.ObserveRequest(method, metricsRoute(endpoint), resp.StatusCode, duration)
+	c.logger.Debug("api request", "method", method, "endpoint", endpoint, "requestId", reqID, "status", resp.StatusCode, "duration", duration)
 
+	resp.Body = &trackedBody{ReadCloser: resp.Body, done: c.inflight.Done}
+	handedOff = true
 	return resp, nil
 }
 
+// SetHeaders sets extra headers attached to every request. Content-Type and apikey are
+// only replaced if overrideStandard is true.
+func (c *APIClient) SetHeaders(headers map[string]string, overrideStandard bool) {
+	c.headers = make(http.Header, len(headers))
+	for name, value := range headers {
+		c.headers.Set(name, value)
+	}
+	c.overrideStandardHeaders = overrideStandard
+}
+
+// applyHeaders merges the extra headers into req after the standard headers
+func (c *APIClient) applyHeaders(req *http.Request) {
+	for name, values := range c.headers {
+		if !c.overrideStandardHeaders && isStandardHeader(name) {
+			continue
+		}
+		req.Header[name] = values
+	}
+}
+
+// isStandardHeader reports whether name is a header set by the SDK itself
+func isStandardHeader(name string) bool {
+	canonical := http.CanonicalHeaderKey(name)
+	return canonical == "Content-Type" || canonical == http.CanonicalHeaderKey("apikey")
+}
+
 // decodeJSONResponse reads the response body, checks HTTP status, and decodes JSON
 func (c *APIClient) decodeJSONResponse(resp *http.Response, result interface{}) error {
 	// Read body first to check status and handle errors
@@ -71,7 +256,7 @@ func (c *APIClient) decodeJSONResponse(resp *http.Response, result interface{})
 		if bodyStr == "" {
 			bodyStr = resp.Status
 		}
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, bodyStr)
+		return &APIError{StatusCode: resp.StatusCode, Message: bodyStr, RequestID: requestID(resp)}
 	}
 
 	// Decode JSON
@@ -87,7 +272,9 @@ func (c *APIClient) decodeJSONResponse(resp *http.Response, result interface{})
 	return nil
 }
 
-// decodeJSONResponseInterface reads the response body, checks HTTP status, and decodes JSON into interface{}
+// decodeJSONResponseInterface reads the response body, checks HTTP status, and decodes JSON into interface{}.
+// A 204 No Content or empty body is treated as success and yields a nil result, since
+// endpoints such as cancel may legitimately return no content.
 func (c *APIClient) decodeJSONResponseInterface(resp *http.Response) (interface{}, error) {
 	// Read body first to check status and handle errors
 	bodyBytes, err := io.ReadAll(resp.Body)
@@ -95,13 +282,17 @@ func (c *APIClient) decodeJSONResponseInterface(resp *http.Response) (interface{
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if isNoContent(resp, bodyBytes) {
+		return nil, nil
+	}
+
 	// Check HTTP status code before attempting to decode JSON
 	if resp.StatusCode != http.StatusOK {
 		bodyStr := string(bodyBytes)
 		if bodyStr == "" {
 			bodyStr = resp.Status
 		}
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, bodyStr)
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: bodyStr, RequestID: requestID(resp)}
 	}
 
 	// Decode JSON
@@ -118,6 +309,14 @@ func (c *APIClient) decodeJSONResponseInterface(resp *http.Response) (interface{
 	return result, nil
 }
 
+// isNoContent reports whether a response is a successful response without a body
+func isNoContent(resp *http.Response, body []byte) bool {
+	if resp.StatusCode == http.StatusNoContent {
+		return true
+	}
+	return resp.StatusCode == http.StatusOK && len(bytes.TrimSpace(body)) == 0
+}
+
 // GetQuoteTokens fetches the list of supported quote tokens
 func (c *APIClient) GetQuoteTokens() (*GetQuoteTokensResponse, error) {
 	// According to OpenAPI spec: /quoteToken with chainId as query parameter
@@ -134,7 +333,7 @@ func (c *APIClient) GetQuoteTokens() (*GetQuoteTokensResponse, error) {
 	}
 
 	if result.Code != 0 {
-		return nil, fmt.Errorf("API error: %s", result.Msg)
+		return nil, newAPIError(resp, result.Code, result.Msg)
 	}
 
 	return &result, nil
@@ -168,7 +367,7 @@ func (c *APIClient) GetMarkets(topicType TopicType, page, limit int, status *Top
 	}
 
 	if result.Code != 0 {
-		return nil, fmt.Errorf("API error: %s", result.Msg)
+		return nil, newAPIError(resp, result.Code, result.Msg)
 	}
 
 	return &result, nil
@@ -189,14 +388,14 @@ func (c *APIClient) GetMarket(marketID int) (*GetMarketResponse, error) {
 	}
 
 	if result.Code != 0 {
-		return nil, fmt.Errorf("API error: %s", result.Msg)
+		return nil, newAPIError(resp, result.Code, result.Msg)
 	}
 
 	return &result, nil
 }
 
 // GetCategoricalMarket fetches detailed information about a categorical market
-func (c *APIClient) GetCategoricalMarket(marketID int) (interface{}, error) {
+func (c *APIClient) GetCategoricalMarket(marketID int) (*GetCategoricalMarketResponse, error) {
 	endpoint := fmt.Sprintf("/market/categorical/%d", marketID)
 	resp, err := c.doRequest("GET", endpoint, nil)
 	if err != nil {
@@ -204,7 +403,16 @@ func (c *APIClient) GetCategoricalMarket(marketID int) (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result GetCategoricalMarketResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // GetPriceHistory fetches price history/candlestick data for a token
@@ -227,7 +435,7 @@ func (c *APIClient) GetPriceHistory(tokenID string, interval string, startAt, en
 }
 
 // GetOrderbook fetches the orderbook for a specific token
-func (c *APIClient) GetOrderbook(tokenID string) (interface{}, error) {
+func (c *APIClient) GetOrderbook(tokenID string) (*GetOrderbookResponse, error) {
 	endpoint := fmt.Sprintf("/token/orderbook?token_id=%s", tokenID)
 	resp, err := c.doRequest("GET", endpoint, nil)
 	if err != nil {
@@ -235,11 +443,20 @@ func (c *APIClient) GetOrderbook(tokenID string) (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result GetOrderbookResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // GetLatestPrice fetches the latest price for a token
-func (c *APIClient) GetLatestPrice(tokenID string) (interface{}, error) {
+func (c *APIClient) GetLatestPrice(tokenID string) (*GetLatestPriceResponse, error) {
 	endpoint := fmt.Sprintf("/token/latest-price?token_id=%s", tokenID)
 	resp, err := c.doRequest("GET", endpoint, nil)
 	if err != nil {
@@ -247,14 +464,29 @@ func (c *APIClient) GetLatestPrice(tokenID string) (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result GetLatestPriceResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // PlaceOrder places an order on the market
-func (c *APIClient) PlaceOrder(orderReq interface{}) (interface{}, error) {
+func (c *APIClient) PlaceOrder(orderReq interface{}) (*PlaceOrderResponse, error) {
+	return c.PlaceOrderContext(context.Background(), orderReq)
+}
+
+// PlaceOrderContext is like PlaceOrder but sends the request with ctx, which can cancel it
+// and carry its request ID. An order whose request was cancelled may still have been placed.
+func (c *APIClient) PlaceOrderContext(ctx context.Context, orderReq interface{}) (*PlaceOrderResponse, error) {
 	endpoint := "/order"
 	
-	// Log the request for debugging (remove sensitive fields in production)
+	// Log the request for debugging with signatures redacted
 	if reqMap, ok := orderReq.(map[string]interface{}); ok {
 		// Create a copy for logging (remove signature)
 		logReq := make(map[string]interface{})
@@ -265,17 +497,38 @@ func (c *APIClient) PlaceOrder(orderReq interface{}) (interface{}, error) {
 				logReq[k] = v
 			}
 		}
-		// This will help debug what's being sent
-		_ = logReq
+		c.logger.Debug("placing order", "request", logReq)
 	}
 	
-	resp, err := c.doRequest("POST", endpoint, orderReq)
+	// The idempotency key also goes in a header so the gateway can dedupe before parsing the body
+	var headers map[string]string
+	if reqMap, ok := orderReq.(map[string]interface{}); ok {
+		if id, ok := reqMap["client_order_id"].(string); ok && id != "" {
+			headers = map[string]string{IdempotencyKeyHeader: id}
+		}
+	}
+
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+	resp, err := c.doRequestContext(reqCtx, "POST", endpoint, orderReq, headers)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result PlaceOrderResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		c.metrics.IncOrder(OrderEventRejected)
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		c.metrics.IncOrder(OrderEventRejected)
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+	c.metrics.IncOrder(OrderEventPlaced)
+
+	return &result, nil
 }
 
 // CancelOrder cancels an existing order
@@ -288,11 +541,23 @@ func (c *APIClient) CancelOrder(orderID string) (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	result, err := c.decodeJSONResponseInterface(resp)
+	if err != nil {
+		return nil, err
+	}
+	c.metrics.IncOrder(OrderEventCancelled)
+
+	return result, nil
 }
 
 // GetMyOrders fetches user's orders with optional filters
-func (c *APIClient) GetMyOrders(marketID int, status string, limit, page int) (interface{}, error) {
+func (c *APIClient) GetMyOrders(marketID int, status string, limit, page int) (*MyOrdersResponse, error) {
+	return c.GetMyOrdersContext(context.Background(), marketID, status, limit, page)
+}
+
+// GetMyOrdersContext is like GetMyOrders but sends the request with ctx, which can cancel
+// it and carry its request ID
+func (c *APIClient) GetMyOrdersContext(ctx context.Context, marketID int, status string, limit, page int) (*MyOrdersResponse, error) {
 	endpoint := fmt.Sprintf("/order?chain_id=%d&limit=%d&page=%d", c.chainID, limit, page)
 	if marketID > 0 {
 		endpoint += fmt.Sprintf("&market_id=%d", marketID)
@@ -301,17 +566,28 @@ func (c *APIClient) GetMyOrders(marketID int, status string, limit, page int) (i
 		endpoint += fmt.Sprintf("&status=%s", status)
 	}
 
-	resp, err := c.doRequest("GET", endpoint, nil)
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+	resp, err := c.doRequestContext(reqCtx, "GET", endpoint, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result MyOrdersResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // GetOrderByID fetches detailed information about a specific order
-func (c *APIClient) GetOrderByID(orderID string) (interface{}, error) {
+func (c *APIClient) GetOrderByID(orderID string) (*GetOrderResponse, error) {
 	endpoint := fmt.Sprintf("/order/%s", orderID)
 	resp, err := c.doRequest("GET", endpoint, nil)
 	if err != nil {
@@ -319,11 +595,20 @@ func (c *APIClient) GetOrderByID(orderID string) (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result GetOrderResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // GetMyPositions fetches user's positions with optional filters
-func (c *APIClient) GetMyPositions(marketID int, page, limit int) (interface{}, error) {
+func (c *APIClient) GetMyPositions(marketID int, page, limit int) (*MyPositionsResponse, error) {
 	endpoint := fmt.Sprintf("/positions?chain_id=%d&page=%d&limit=%d", c.chainID, page, limit)
 	if marketID > 0 {
 		endpoint += fmt.Sprintf("&market_id=%d", marketID)
@@ -335,11 +620,20 @@ func (c *APIClient) GetMyPositions(marketID int, page, limit int) (interface{},
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result MyPositionsResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // GetMyBalances fetches user's balances
-func (c *APIClient) GetMyBalances() (interface{}, error) {
+func (c *APIClient) GetMyBalances() (*BalancesResponse, error) {
 	endpoint := fmt.Sprintf("/user/balance?chain_id=%d", c.chainID)
 	resp, err := c.doRequest("GET", endpoint, nil)
 	if err != nil {
@@ -347,11 +641,20 @@ func (c *APIClient) GetMyBalances() (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result BalancesResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
 
 // GetMyTrades fetches user's trade history
-func (c *APIClient) GetMyTrades(marketID *int, page, limit int) (interface{}, error) {
+func (c *APIClient) GetMyTrades(marketID *int, page, limit int) (*MyTradesResponse, error) {
 	endpoint := fmt.Sprintf("/trade?chain_id=%d&page=%d&limit=%d", c.chainID, page, limit)
 	if marketID != nil {
 		endpoint += fmt.Sprintf("&market_id=%d", *marketID)
@@ -363,11 +666,90 @@ func (c *APIClient) GetMyTrades(marketID *int, page, limit int) (interface{}, er
 	}
 	defer resp.Body.Close()
 
-	return c.decodeJSONResponseInterface(resp)
+	var result MyTradesResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
+}
+
+// GetMarketTrades fetches recent public trades for a market
+func (c *APIClient) GetMarketTrades(marketID int, page, limit int) (*MarketTradesResponse, error) {
+	endpoint := fmt.Sprintf("/market/%d/trade?chain_id=%d&page=%d&limit=%d", marketID, c.chainID, page, limit)
+
+	resp, err := c.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result MarketTradesResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
+}
+
+// authFailureCodes are the response codes with which the API rejects an API key. Like
+// other errors they come with HTTP 200, so they are not seen by checking the status.
+var authFailureCodes = map[int]bool{
+	http.StatusUnauthorized: true,
+	http.StatusForbidden:    true,
+}
+
+// Ping checks that the API can be reached and accepts the API key by fetching
+// /user/auth. A rejected key, by HTTP status or response code, yields ErrInvalidAPIKey
+// and a failed request ErrAPIUnreachable.
+func (c *APIClient) Ping(ctx context.Context) error {
+	// Abort on either the caller's cancellation or Close
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	resp, err := c.doRequestContext(reqCtx, "GET", "/user/auth", nil, nil)
+	if err != nil {
+		if errors.Is(err, ErrClientClosed) {
+			return err
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("ping cancelled: %w", ctx.Err())
+		}
+		return fmt.Errorf("%w: %v", ErrAPIUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: HTTP %d (request ID: %s)", ErrInvalidAPIKey, resp.StatusCode, requestID(resp))
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return err
+	}
+	if authFailureCodes[result.Code] {
+		return fmt.Errorf("%w: %w", ErrInvalidAPIKey, newAPIError(resp, result.Code, result.Msg))
+	}
+	if result.Code != 0 {
+		return newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return nil
 }
 
 // GetUserAuth fetches authenticated user information
-func (c *APIClient) GetUserAuth() (interface{}, error) {
+func (c *APIClient) GetUserAuth() (*UserAuthResponse, error) {
 	endpoint := "/user/auth"
 	resp, err := c.doRequest("GET", endpoint, nil)
 	if err != nil {
@@ -375,10 +757,14 @@ func (c *APIClient) GetUserAuth() (interface{}, error) {
 	}
 	defer resp.Body.Close()
 
-	var result interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	var result UserAuthResponse
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	if result.Code != 0 {
+		return nil, newAPIError(resp, result.Code, result.Msg)
+	}
+
+	return &result, nil
 }
@@ -0,0 +1,159 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCancelOrderAcceptsEmptyResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond http.HandlerFunc
+	}{
+		{"204 No Content", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }},
+		{"200 with empty body", func(w http.ResponseWriter, r *http.Request) {}},
+		{"200 with whitespace body", func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, " \n") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeAPI(t)
+			f.handle("/order/cancel", tt.respond)
+			api := NewAPIClient(f.srv.URL, "test-key", ChainIDBNBMainnet)
+
+			result, err := api.CancelOrder("ord-1")
+			if err != nil || result != nil {
+				t.Fatalf("CancelOrder = %v, %v; want nil, nil", result, err)
+			}
+		})
+	}
+}
+
+func TestTruncatedJSONReportsBody(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"o`)
+	})
+	api := NewAPIClient(f.srv.URL, "test-key", ChainIDBNBMainnet)
+
+	_, err := api.CancelOrder("ord-1")
+	if err == nil || !strings.Contains(err.Error(), `{"code":0,"msg":"o`) {
+		t.Fatalf("CancelOrder error = %v; want a decode error quoting the body", err)
+	}
+}
+
+func TestCustomHeaders(t *testing.T) {
+	f := newFakeAPI(t)
+	var got http.Header
+	f.handle("/quoteToken", func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"list":[]}}`)
+	})
+	api := NewAPIClient(f.srv.URL, "test-key", ChainIDBNBMainnet)
+
+	api.SetHeaders(map[string]string{"X-Tenant": "t1", "apikey": "other", "content-type": "text/plain"}, false)
+	if _, err := api.GetQuoteTokens(); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("X-Tenant") != "t1" || got.Get("apikey") != "test-key" || got.Get("Content-Type") != "application/json" {
+		t.Fatalf("headers = %v; want X-Tenant added and standard headers kept", got)
+	}
+
+	api.SetHeaders(map[string]string{"apikey": "other"}, true)
+	if _, err := api.GetQuoteTokens(); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("apikey") != "other" {
+		t.Fatalf("apikey = %q, want the explicit override", got.Get("apikey"))
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	f := newFakeAPI(t)
+	var mu sync.Mutex
+	var received []string
+	f.handle("/user/auth", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Header.Get(RequestIDHeader))
+		mu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+		io.WriteString(w, "boom")
+	})
+	f.handle("/user/balance", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":7,"msg":"nope"}`)
+	})
+	sent := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), received...)
+	}
+	api := NewAPIClient(f.srv.URL, "test-key", ChainIDBNBMainnet)
+
+	// Generated per call when the context has none
+	_, err := api.GetUserAuth()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("GetUserAuth error = %v, want an APIError for HTTP 500", err)
+	}
+	ids := sent()
+	if len(ids) != 1 || len(ids[0]) != 32 {
+		t.Fatalf("request IDs = %q, want one generated 32-character ID", ids)
+	}
+	if apiErr.RequestID != ids[0] || !strings.Contains(err.Error(), ids[0]) {
+		t.Errorf("error = %v (request ID %q), want the sent ID %q", err, apiErr.RequestID, ids[0])
+	}
+
+	// Taken from the context when set
+	err = api.Ping(ContextWithRequestID(context.Background(), "trace-1"))
+	ids = sent()
+	if len(ids) != 2 || ids[1] != "trace-1" {
+		t.Fatalf("request IDs = %q, want trace-1 sent", ids)
+	}
+	if err == nil || !strings.Contains(err.Error(), "request ID: trace-1") {
+		t.Errorf("Ping error = %v, want it to name trace-1", err)
+	}
+
+	// API-level errors carry the ID too
+	_, err = api.GetMyBalances()
+	if !errors.As(err, &apiErr) || apiErr.Code != 7 || apiErr.RequestID == "" {
+		t.Errorf("GetMyBalances error = %v, want an APIError with code 7 and a request ID", err)
+	}
+}
+
+func TestRequestIDFromCallerContext(t *testing.T) {
+	f := newFakeAPI(t)
+	var mu sync.Mutex
+	received := make(map[string]string)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.Method] = r.Header.Get(RequestIDHeader)
+		mu.Unlock()
+		if r.Method == http.MethodPost {
+			io.WriteString(w, `{"code":10403,"msg":"rejected"}`)
+			return
+		}
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":0,"list":[]}}`)
+	})
+	c := newTestClient(t, f)
+
+	_, err := c.PlaceOrder(ContextWithRequestID(context.Background(), "trace-order"), limitBuy("0.5", "10"), false)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "trace-order" {
+		t.Fatalf("PlaceOrder error = %v, want an APIError with request ID trace-order", err)
+	}
+
+	it := c.IterateMyOrders(0, OrderStatusFilterOpen)
+	if _, _, err := it.Next(ContextWithRequestID(context.Background(), "trace-list")); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if received[http.MethodPost] != "trace-order" || received[http.MethodGet] != "trace-list" {
+		t.Errorf("request IDs = %q, want trace-order for the order and trace-list for the listing", received)
+	}
+}
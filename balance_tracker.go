@@ -0,0 +1,339 @@
+package opinionclob
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TrackedBalance is the tracked balance of one quote token
+type TrackedBalance struct {
+	QuoteToken string
+	Available  *big.Rat
+	Locked     *big.Rat // reserved by open BUY orders
+}
+
+// BalanceChangeHandler is called with the new balance after every change
+type BalanceChangeHandler func(balance TrackedBalance)
+
+// BalanceTracker keeps quote token balances current from WebSocket order updates and
+// trade records, starting from a GetMyBalances snapshot. Only BUY order updates move
+// funds: the unfilled amount is locked and the filled amount is spent, so a fill is
+// counted once however its trade and order messages interleave. Trade records only
+// credit SELL proceeds and debit fees. Reconcile resets the view from the REST API.
+type BalanceTracker struct {
+	client   *Client
+	onChange BalanceChangeHandler
+
+	mu            sync.RWMutex
+	balances      map[string]*TrackedBalance // by lowercase quote token address
+	orders        map[string]*trackedOrder   // open BUY orders by order ID
+	trades        map[string]*list.Element   // recently applied trade numbers, kept across Reconcile
+	tradeOrder    *list.List                 // front = most recently applied
+	tradeCapacity int
+}
+
+// maxAppliedTrades is how many recently applied trade numbers a BalanceTracker remembers
+// to ignore redeliveries; older ones are forgotten so memory stays bounded
+const maxAppliedTrades = 10000
+
+// trackedOrder is what an open BUY order has locked and spent so far
+type trackedOrder struct {
+	quoteToken string
+	remaining  *big.Rat // unfilled amount, held in Locked
+	filled     *big.Rat // FilledAmount already spent
+}
+
+// NewBalanceTracker creates a tracker and seeds it with Reconcile. onChange may be nil.
+func (c *Client) NewBalanceTracker(ctx context.Context, onChange BalanceChangeHandler) (*BalanceTracker, error) {
+	t := &BalanceTracker{
+		client:        c,
+		onChange:      onChange,
+		balances:      make(map[string]*TrackedBalance),
+		orders:        make(map[string]*trackedOrder),
+		trades:        make(map[string]*list.Element),
+		tradeOrder:    list.New(),
+		tradeCapacity: maxAppliedTrades,
+	}
+
+	if err := t.Reconcile(ctx); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Balances returns a copy of all tracked balances
+func (t *BalanceTracker) Balances() []TrackedBalance {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]TrackedBalance, 0, len(t.balances))
+	for _, b := range t.balances {
+		result = append(result, copyBalance(b))
+	}
+	return result
+}
+
+// Balance returns a copy of the tracked balance of a quote token
+func (t *BalanceTracker) Balance(quoteToken string) (TrackedBalance, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	b, ok := t.balances[strings.ToLower(quoteToken)]
+	if !ok {
+		return TrackedBalance{}, false
+	}
+	return copyBalance(b), true
+}
+
+// Reconcile replaces the tracked balances with a fresh GetMyBalances snapshot and
+// re-reads the open orders so later order updates are applied as deltas
+func (t *BalanceTracker) Reconcile(ctx context.Context) error {
+	snapshot, err := t.client.GetMyBalances()
+	if err != nil {
+		return err
+	}
+
+	balances := make(map[string]*TrackedBalance, len(snapshot.Result.Balances))
+	for _, qb := range snapshot.Result.Balances {
+		available, err := parseAPIAmount("availableBalance", qb.Available)
+		if err != nil {
+			return err
+		}
+		locked, err := parseAPIAmount("frozenBalance", qb.Locked)
+		if err != nil {
+			return err
+		}
+		key := strings.ToLower(qb.QuoteTokenAddress)
+		balances[key] = &TrackedBalance{QuoteToken: key, Available: available, Locked: locked}
+	}
+
+	orders := make(map[string]*trackedOrder)
+	it := t.client.IterateMyOrders(0, OrderStatusFilterOpen)
+	for {
+		order, ok, err := it.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if order.Side != OrderSideBuy {
+			continue
+		}
+		tracked, err := newTrackedOrder(order.QuoteToken, order.Amount, order.FilledAmount, false)
+		if err != nil {
+			return err
+		}
+		orders[order.OrderID] = tracked
+	}
+
+	// Trades already applied stay recorded: the snapshot includes them, so a redelivery
+	// must still be ignored
+	t.mu.Lock()
+	t.balances = balances
+	t.orders = orders
+	changed := make([]TrackedBalance, 0, len(balances))
+	for _, b := range balances {
+		changed = append(changed, copyBalance(b))
+	}
+	t.mu.Unlock()
+
+	t.notify(changed...)
+	return nil
+}
+
+// Apply updates balances from a WebSocket message; other channels are ignored
+func (t *BalanceTracker) Apply(env WSMessageEnvelope) error {
+	switch data := env.Data.(type) {
+	case *OrderUpdate:
+		return t.ApplyOrderUpdate(data)
+	case *TradeRecord:
+		return t.ApplyTrade(data)
+	}
+	return nil
+}
+
+// ApplyOrderUpdate applies a BUY order's progress: changes in its unfilled amount move
+// funds between available and locked, and increases in its filled amount are spent
+func (t *BalanceTracker) ApplyOrderUpdate(update *OrderUpdate) error {
+	if OrderSide(update.Side) != OrderSideBuy {
+		return nil
+	}
+
+	final := OrderStatus(update.Status).IsFinal()
+	next, err := newTrackedOrder(update.QuoteToken, update.Amount, update.FilledAmount, final)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	previous, ok := t.orders[update.OrderID]
+	if !ok {
+		previous = &trackedOrder{remaining: new(big.Rat), filled: new(big.Rat)}
+	}
+	// An update older than one already applied is ignored, except that a final one
+	// still releases the lock; a fill is never un-spent
+	if next.filled.Cmp(previous.filled) < 0 {
+		if !final {
+			t.mu.Unlock()
+			return nil
+		}
+		next.filled = previous.filled
+	}
+	if next.remaining.Sign() > 0 {
+		t.orders[update.OrderID] = next
+	} else {
+		delete(t.orders, update.OrderID)
+	}
+
+	locked := new(big.Rat).Sub(next.remaining, previous.remaining)
+	spent := new(big.Rat).Sub(next.filled, previous.filled)
+	if locked.Sign() == 0 && spent.Sign() == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	b := t.balanceLocked(update.QuoteToken)
+	b.Locked.Add(b.Locked, locked)
+	b.Available.Sub(b.Available, locked)
+	b.Available.Sub(b.Available, spent)
+	changed := copyBalance(b)
+	t.mu.Unlock()
+
+	t.notify(changed)
+	return nil
+}
+
+// ApplyTrade applies a fill's fee and, for SELL fills, its proceeds. What a BUY fill
+// spends is applied by ApplyOrderUpdate from the order's filled amount.
+func (t *BalanceTracker) ApplyTrade(trade *TradeRecord) error {
+	amount, err := parseAPIAmount("amount", trade.Amount)
+	if err != nil {
+		return err
+	}
+	fee, err := parseAPIAmount("fee", trade.Fee)
+	if err != nil {
+		return err
+	}
+	side, err := trade.OrderSide()
+	if err != nil {
+		return &OpenAPIError{Message: fmt.Sprintf("invalid side in trade %s: %s", trade.TradeNo, trade.Side)}
+	}
+
+	t.mu.Lock()
+	if trade.TradeNo != "" && !t.recordTradeLocked(trade.TradeNo) {
+		t.mu.Unlock()
+		return nil
+	}
+
+	b := t.balanceLocked(trade.QuoteToken)
+	if side == OrderSideSell {
+		b.Available.Add(b.Available, amount)
+	}
+	b.Available.Sub(b.Available, fee)
+	changed := copyBalance(b)
+	t.mu.Unlock()
+
+	t.notify(changed)
+	return nil
+}
+
+// Run applies messages until ctx is done or messages is closed, calling Reconcile every
+// reconcileInterval (0 disables it). Apply and reconcile errors go to onError, which may be nil.
+func (t *BalanceTracker) Run(ctx context.Context, messages <-chan WSMessageEnvelope, reconcileInterval time.Duration, onError func(error)) {
+	var tick <-chan time.Time
+	if reconcileInterval > 0 {
+		ticker := time.NewTicker(reconcileInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	report := func(err error) {
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case env, ok := <-messages:
+			if !ok {
+				return
+			}
+			report(t.Apply(env))
+		case <-tick:
+			report(t.Reconcile(ctx))
+		}
+	}
+}
+
+// recordTradeLocked remembers a trade number and reports whether it is new, forgetting the
+// oldest one beyond tradeCapacity; callers must hold mu
+func (t *BalanceTracker) recordTradeLocked(tradeNo string) bool {
+	if _, ok := t.trades[tradeNo]; ok {
+		return false
+	}
+	t.trades[tradeNo] = t.tradeOrder.PushFront(tradeNo)
+
+	if t.tradeOrder.Len() > t.tradeCapacity {
+		oldest := t.tradeOrder.Back()
+		t.tradeOrder.Remove(oldest)
+		delete(t.trades, oldest.Value.(string))
+	}
+	return true
+}
+
+// balanceLocked returns the balance of a quote token, creating it if needed; callers must hold mu
+func (t *BalanceTracker) balanceLocked(quoteToken string) *TrackedBalance {
+	key := strings.ToLower(quoteToken)
+	b, ok := t.balances[key]
+	if !ok {
+		b = &TrackedBalance{QuoteToken: key, Available: new(big.Rat), Locked: new(big.Rat)}
+		t.balances[key] = b
+	}
+	return b
+}
+
+// notify calls the change handler outside the lock
+func (t *BalanceTracker) notify(balances ...TrackedBalance) {
+	if t.onChange == nil {
+		return
+	}
+	for _, b := range balances {
+		t.onChange(b)
+	}
+}
+
+// copyBalance returns a balance that shares no state with the tracker
+func copyBalance(b *TrackedBalance) TrackedBalance {
+	return TrackedBalance{
+		QuoteToken: b.QuoteToken,
+		Available:  new(big.Rat).Set(b.Available),
+		Locked:     new(big.Rat).Set(b.Locked),
+	}
+}
+
+// newTrackedOrder returns a BUY order's state from its amount and filled amount. A final
+// order has nothing left locked.
+func newTrackedOrder(quoteToken, amount, filledAmount string, final bool) (*trackedOrder, error) {
+	total, err := parseAPIAmount("amount", amount)
+	if err != nil {
+		return nil, err
+	}
+	filled, err := parseAPIAmount("filledAmount", filledAmount)
+	if err != nil {
+		return nil, err
+	}
+	remaining := new(big.Rat).Sub(total, filled)
+	if final || remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	return &trackedOrder{quoteToken: strings.ToLower(quoteToken), remaining: remaining, filled: filled}, nil
+}
@@ -0,0 +1,131 @@
+package opinionclob
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+)
+
+// newTestBalanceTracker returns a tracker seeded with 100 available quote tokens and no open orders
+func newTestBalanceTracker(t *testing.T) (*BalanceTracker, *int) {
+	t.Helper()
+	f := newFakeAPI(t)
+	f.handle("/user/balance", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"balances":[{"quoteToken":"`+testQuoteToken+`","tokenDecimals":18,"totalBalance":"100","availableBalance":"100","frozenBalance":"0"}]}}`)
+	})
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":0,"list":[]}}`)
+	})
+	c := newTestClient(t, f)
+
+	changes := new(int)
+	tracker, err := c.NewBalanceTracker(context.Background(), func(TrackedBalance) { *changes++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	*changes = 0
+	return tracker, changes
+}
+
+// checkBalance fails the test unless the tracked balance is available/locked
+func checkBalance(t *testing.T, tracker *BalanceTracker, available, locked string) {
+	t.Helper()
+	b, ok := tracker.Balance(testQuoteToken)
+	if !ok {
+		t.Fatal("quote token not tracked")
+	}
+	wantAvailable, _ := new(big.Rat).SetString(available)
+	wantLocked, _ := new(big.Rat).SetString(locked)
+	if b.Available.Cmp(wantAvailable) != 0 || b.Locked.Cmp(wantLocked) != 0 {
+		t.Fatalf("balance = %s available, %s locked; want %s, %s", b.Available.FloatString(4), b.Locked.FloatString(4), available, locked)
+	}
+}
+
+// buyUpdate is an update of BUY order id for 10 quote tokens
+func buyUpdate(id, filled string, status OrderStatus) WSMessageEnvelope {
+	return WSMessageEnvelope{Data: &OrderUpdate{OrderID: id, Side: int(OrderSideBuy), Amount: "10", FilledAmount: filled, Status: int(status), QuoteToken: testQuoteToken}}
+}
+
+// trade is a fill of order id
+func trade(id, tradeNo, side, amount, fee string) WSMessageEnvelope {
+	return WSMessageEnvelope{Data: &TradeRecord{OrderID: id, TradeNo: tradeNo, Side: side, Amount: amount, Fee: fee, QuoteToken: testQuoteToken}}
+}
+
+func TestBalanceTrackerAppliesFill(t *testing.T) {
+	tracker, changes := newTestBalanceTracker(t)
+	checkBalance(t, tracker, "100", "0")
+
+	tracker.Apply(buyUpdate("o1", "0", OrderStatusPending))
+	checkBalance(t, tracker, "90", "10")
+
+	// The fill is spent from the lock by the order update; its trade only pays the fee
+	tracker.Apply(trade("o1", "t1", "Buy", "4", "0.1"))
+	checkBalance(t, tracker, "89.9", "10")
+	tracker.Apply(buyUpdate("o1", "4", OrderStatusPending))
+	checkBalance(t, tracker, "89.9", "6")
+
+	tracker.Apply(trade("o2", "t2", "Sell", "5", "0"))
+	checkBalance(t, tracker, "94.9", "6")
+
+	// Cancelling releases what is still locked
+	tracker.Apply(buyUpdate("o1", "4", OrderStatusCancelled))
+	checkBalance(t, tracker, "100.9", "0")
+
+	if *changes != 5 {
+		t.Fatalf("%d change notifications, want 5", *changes)
+	}
+}
+
+func TestBalanceTrackerCountsFillOnceInAnyOrder(t *testing.T) {
+	orders := [][]WSMessageEnvelope{
+		{buyUpdate("o1", "0", OrderStatusPending), trade("o1", "t1", "Buy", "4", "0"), buyUpdate("o1", "4", OrderStatusPending)},
+		{buyUpdate("o1", "0", OrderStatusPending), buyUpdate("o1", "4", OrderStatusPending), trade("o1", "t1", "Buy", "4", "0")},
+		{trade("o1", "t1", "Buy", "4", "0"), buyUpdate("o1", "4", OrderStatusPending)},
+		// A stale update after a newer one changes nothing
+		{buyUpdate("o1", "4", OrderStatusPending), buyUpdate("o1", "0", OrderStatusPending), trade("o1", "t1", "Buy", "4", "0")},
+	}
+	for _, messages := range orders {
+		tracker, _ := newTestBalanceTracker(t)
+		for _, env := range messages {
+			if err := tracker.Apply(env); err != nil {
+				t.Fatal(err)
+			}
+		}
+		checkBalance(t, tracker, "90", "6")
+	}
+}
+
+func TestBalanceTrackerIgnoresRedeliveredTrades(t *testing.T) {
+	tracker, _ := newTestBalanceTracker(t)
+
+	tracker.Apply(trade("o2", "t2", "Sell", "5", "0.1"))
+	tracker.Apply(trade("o2", "t2", "Sell", "5", "0.1"))
+	checkBalance(t, tracker, "104.9", "0")
+
+	// The snapshot already includes the trade, so a redelivery after Reconcile is ignored too
+	if err := tracker.Reconcile(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkBalance(t, tracker, "100", "0")
+	tracker.Apply(trade("o2", "t2", "Sell", "5", "0.1"))
+	checkBalance(t, tracker, "100", "0")
+}
+
+func TestBalanceTrackerForgetsOldTrades(t *testing.T) {
+	tracker, _ := newTestBalanceTracker(t)
+	tracker.tradeCapacity = 2
+
+	tracker.Apply(trade("o2", "t1", "Sell", "1", "0"))
+	tracker.Apply(trade("o2", "t2", "Sell", "1", "0"))
+	tracker.Apply(trade("o2", "t3", "Sell", "1", "0"))
+	if n := len(tracker.trades); n != 2 {
+		t.Fatalf("remembered %d trades, want 2", n)
+	}
+	checkBalance(t, tracker, "103", "0")
+
+	// Recent trades are still deduplicated
+	tracker.Apply(trade("o2", "t3", "Sell", "1", "0"))
+	checkBalance(t, tracker, "103", "0")
+}
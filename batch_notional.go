@@ -0,0 +1,95 @@
+package opinionclob
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// OrderNotional is one order's quote token value within a batch
+type OrderNotional struct {
+	Index int
+	Side  OrderSide
+	// Notional is the quote token amount in wei that a BUY spends or a limit SELL receives
+	// when fully filled; nil for market SELL orders, whose proceeds depend on the book
+	Notional *big.Int
+}
+
+// BatchNotional is the aggregate quote token value of a batch of orders
+type BatchNotional struct {
+	Total    *big.Int // sum of the priced orders' notionals, in quote token wei
+	Decimals int      // quote token decimals, for converting Total with WeiToAmount
+	Orders   []OrderNotional
+	Unpriced int // market SELL orders left out of Total
+}
+
+// TotalAmount returns Total as a decimal quote token amount
+func (b *BatchNotional) TotalAmount() string {
+	return WeiToAmount(b.Total, b.Decimals)
+}
+
+// EstimateBatchNotional sums the quote token notional of a batch before it is placed,
+// using the same amount rounding as PlaceOrder. If market is given, every order must be
+// for it; otherwise each order's market is fetched (from the cache when possible). If
+// quoteToken is given it is used instead of looking the market's quote token up, so with
+// both set no network request is made. All orders must share one quote token.
+func (c *Client) EstimateBatchNotional(orders []PlaceOrderDataInput, market *Market, quoteToken *QuoteToken) (*BatchNotional, error) {
+	if len(orders) == 0 {
+		return nil, &InvalidParamError{Message: "orders list cannot be empty"}
+	}
+
+	result := &BatchNotional{Total: big.NewInt(0), Orders: make([]OrderNotional, 0, len(orders))}
+	var batchQuoteToken string
+	for i, order := range orders {
+		orderMarket := market
+		if orderMarket == nil {
+			var err error
+			orderMarket, err = c.GetMarket(order.MarketID, true)
+			if err != nil {
+				return nil, fmt.Errorf("order %d: %w", i, err)
+			}
+		} else if order.MarketID != market.MarketID {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("order %d is for market %d, not %d", i, order.MarketID, market.MarketID)}
+		}
+
+		if batchQuoteToken == "" {
+			batchQuoteToken = orderMarket.QuoteToken
+		} else if normalizeHexAddress(orderMarket.QuoteToken) != normalizeHexAddress(batchQuoteToken) {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("order %d uses quote token %s, not %s", i, orderMarket.QuoteToken, batchQuoteToken)}
+		}
+
+		token := quoteToken
+		if token == nil {
+			registry, err := c.GetQuoteTokenRegistry(true)
+			if err != nil {
+				return nil, err
+			}
+			var ok bool
+			token, ok = registry.Get(orderMarket.QuoteToken)
+			if !ok {
+				return nil, &OpenAPIError{Message: "Quote token not found for this market"}
+			}
+		}
+		result.Decimals = token.Decimal
+
+		amounts, err := c.computeOrderAmounts(orderMarket, order, token.Decimal)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+
+		entry := OrderNotional{Index: i, Side: order.Side}
+		switch {
+		case order.Side == OrderSideBuy:
+			entry.Notional = amounts.makerAmount
+		case order.OrderType == OrderTypeLimit:
+			entry.Notional = amounts.takerAmount
+		}
+		if entry.Notional == nil {
+			result.Unpriced++
+		} else {
+			result.Total.Add(result.Total, entry.Notional)
+		}
+		result.Orders = append(result.Orders, entry)
+	}
+
+	return result, nil
+}
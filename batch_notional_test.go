@@ -0,0 +1,56 @@
+package opinionclob
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEstimateBatchNotional(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+	orders := []PlaceOrderDataInput{
+		limitBuy("0.5", "10"),
+		{MarketID: 1, TokenID: "111", Side: OrderSideSell, OrderType: OrderTypeLimit, Price: "0.25", MakerAmountInBaseToken: strPtr("8")},
+		{MarketID: 1, TokenID: "111", Side: OrderSideSell, OrderType: OrderTypeMarket, MakerAmountInBaseToken: strPtr("3")},
+		{MarketID: 1, TokenID: "111", Side: OrderSideBuy, OrderType: OrderTypeMarket, MakerAmountInQuoteToken: strPtr("5")},
+	}
+
+	notional, err := c.EstimateBatchNotional(orders, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10 + 8*0.25 + 5; the market SELL has no price to value it at
+	if got := notional.TotalAmount(); got != "17" {
+		t.Errorf("total = %s, want 17", got)
+	}
+	if notional.Unpriced != 1 || notional.Orders[2].Notional != nil {
+		t.Errorf("unpriced = %d, market SELL notional = %v; want 1 and nil", notional.Unpriced, notional.Orders[2].Notional)
+	}
+	if got := WeiToAmount(notional.Orders[1].Notional, notional.Decimals); got != "2" {
+		t.Errorf("limit SELL notional = %s, want 2", got)
+	}
+
+	// With the market and quote token given, nothing is fetched
+	market, err := c.GetMarket(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := f.count("/market/1") + f.count("/quoteToken")
+	notional, err = c.EstimateBatchNotional(orders, market, &QuoteToken{Decimal: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := notional.TotalAmount(); got != "17" {
+		t.Errorf("total with 6 decimals = %s, want 17", got)
+	}
+	if after := f.count("/market/1") + f.count("/quoteToken"); after != before {
+		t.Errorf("made %d requests, want none", after-before)
+	}
+
+	mismatched := append([]PlaceOrderDataInput(nil), orders...)
+	mismatched[0].MarketID = 2
+	var invalid *InvalidParamError
+	if _, err := c.EstimateBatchNotional(mismatched, market, &QuoteToken{Decimal: 6}); !errors.As(err, &invalid) {
+		t.Errorf("order for another market: expected InvalidParamError, got %v", err)
+	}
+}
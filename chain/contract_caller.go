@@ -5,9 +5,13 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -22,9 +26,15 @@ type ContractCaller struct {
 	conditionalTokensAddr      common.Address
 	multisendAddr              common.Address
 	feeManagerAddr             common.Address
+	chainID                    *big.Int
 	enableTradingCheckInterval time.Duration
+	gasPriceMultiplier         float64
+	enableTradingMu            sync.Mutex // serializes EnableTrading and guards enableTradingLastTime
 	enableTradingLastTime      time.Time
+	decimalsMu                 sync.Mutex // guards tokenDecimalsCache
 	tokenDecimalsCache         map[string]int
+	logger                     Logger
+	metrics                    MetricsCollector
 }
 
 // NewContractCaller creates a new ContractCaller instance
@@ -35,8 +45,14 @@ func NewContractCaller(
 	conditionalTokensAddr string,
 	multisendAddr string,
 	feeManagerAddr string,
+	chainID int64,
 	enableTradingCheckInterval time.Duration,
+	gasPriceMultiplier float64,
 ) (*ContractCaller, error) {
+	if err := ValidateRPCURL(rpcURL); err != nil {
+		return nil, err
+	}
+
 	client, err := ethclient.Dial(rpcURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RPC: %w", err)
@@ -54,11 +70,52 @@ func NewContractCaller(
 		conditionalTokensAddr:      common.HexToAddress(conditionalTokensAddr),
 		multisendAddr:              common.HexToAddress(multisendAddr),
 		feeManagerAddr:             common.HexToAddress(feeManagerAddr),
+		chainID:                    big.NewInt(chainID),
 		enableTradingCheckInterval: enableTradingCheckInterval,
+		gasPriceMultiplier:         gasPriceMultiplier,
 		tokenDecimalsCache:         make(map[string]int),
+		logger:                     NopLogger{},
+		metrics:                    NopMetrics{},
 	}, nil
 }
 
+// SetLogger sets the logger for transaction events; nil restores the no-op logger
+func (cc *ContractCaller) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	cc.logger = logger
+}
+
+// SetMetrics sets the collector for transaction counts; nil restores the no-op collector
+func (cc *ContractCaller) SetMetrics(metrics MetricsCollector) {
+	if metrics == nil {
+		metrics = NopMetrics{}
+	}
+	cc.metrics = metrics
+}
+
+// ValidateRPCURL checks that rpcURL has an http, https, ws or wss scheme and a host
+func ValidateRPCURL(rpcURL string) error {
+	// "host:port" parses as scheme "host", so require the "://" separator explicitly
+	if !strings.Contains(rpcURL, "://") {
+		return fmt.Errorf("invalid RPC URL %q: missing scheme (expected http, https, ws or wss)", rpcURL)
+	}
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return fmt.Errorf("invalid RPC URL %q: %w", rpcURL, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf("invalid RPC URL %q: unsupported scheme %q (expected http, https, ws or wss)", rpcURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid RPC URL %q: missing host", rpcURL)
+	}
+	return nil
+}
+
 // GetSignerAddress returns the address of the signer
 func (cc *ContractCaller) GetSignerAddress() common.Address {
 	publicKey := cc.privateKey.Public()
@@ -84,9 +141,9 @@ func (cc *ContractCaller) CheckGasBalance(ctx context.Context, estimatedGas uint
 		return fmt.Errorf("failed to get balance: %w", err)
 	}
 
-	gasPrice, err := cc.client.SuggestGasPrice(ctx)
+	gasPrice, err := cc.gasPrice(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %w", err)
+		return err
 	}
 
 	// Add 20% safety margin
@@ -106,11 +163,29 @@ func (cc *ContractCaller) CheckGasBalance(ctx context.Context, estimatedGas uint
 	return nil
 }
 
+// gasPrice returns the suggested gas price scaled by the configured multiplier
+func (cc *ContractCaller) gasPrice(ctx context.Context) (*big.Int, error) {
+	suggested, err := cc.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	if cc.gasPriceMultiplier <= 0 || cc.gasPriceMultiplier == 1 {
+		return suggested, nil
+	}
+
+	scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(suggested), big.NewFloat(cc.gasPriceMultiplier)).Int(nil)
+	return scaled, nil
+}
+
 // GetTokenDecimals gets token decimals with caching
 func (cc *ContractCaller) GetTokenDecimals(ctx context.Context, tokenAddr common.Address) (int, error) {
 	tokenKey := tokenAddr.Hex()
 
-	if decimals, ok := cc.tokenDecimalsCache[tokenKey]; ok {
+	cc.decimalsMu.Lock()
+	decimals, ok := cc.tokenDecimalsCache[tokenKey]
+	cc.decimalsMu.Unlock()
+	if ok {
 		return decimals, nil
 	}
 
@@ -119,8 +194,7 @@ func (cc *ContractCaller) GetTokenDecimals(ctx context.Context, tokenAddr common
 	data, err := erc20ABI.Pack("decimals")
 	if err != nil {
 		// Fallback to 18 if we can't even pack the call
-		cc.tokenDecimalsCache[tokenKey] = 18
-		return 18, nil
+		return cc.cacheTokenDecimals(tokenKey, 18), nil
 	}
 
 	result, err := cc.client.CallContract(ctx, ethereum.CallMsg{
@@ -129,20 +203,26 @@ func (cc *ContractCaller) GetTokenDecimals(ctx context.Context, tokenAddr common
 	}, nil)
 	if err != nil {
 		// Default to 18 if call fails (standard for most tokens)
-		cc.tokenDecimalsCache[tokenKey] = 18
-		return 18, nil
+		return cc.cacheTokenDecimals(tokenKey, 18), nil
 	}
 
-	var decimals uint8
-	err = erc20ABI.UnpackIntoInterface(&decimals, "decimals", result)
+	var tokenDecimals uint8
+	err = erc20ABI.UnpackIntoInterface(&tokenDecimals, "decimals", result)
 	if err != nil {
 		// Default to 18 if unpacking fails
-		cc.tokenDecimalsCache[tokenKey] = 18
-		return 18, nil
+		return cc.cacheTokenDecimals(tokenKey, 18), nil
 	}
 
-	cc.tokenDecimalsCache[tokenKey] = int(decimals)
-	return int(decimals), nil
+	return cc.cacheTokenDecimals(tokenKey, int(tokenDecimals)), nil
+}
+
+// cacheTokenDecimals stores a token's decimals and returns them
+func (cc *ContractCaller) cacheTokenDecimals(tokenKey string, decimals int) int {
+	cc.decimalsMu.Lock()
+	defer cc.decimalsMu.Unlock()
+
+	cc.tokenDecimalsCache[tokenKey] = decimals
+	return decimals
 }
 
 // Split splits collateral into outcome tokens
@@ -290,82 +370,150 @@ func (cc *ContractCaller) Merge(ctx context.Context, collateralToken common.Addr
 
 // Redeem redeems winning outcome tokens for collateral
 func (cc *ContractCaller) Redeem(ctx context.Context, collateralToken common.Address, conditionID []byte) (*types.Transaction, error) {
-	if err := cc.CheckGasBalance(ctx, 300000); err != nil {
+	if err := cc.CheckGasBalance(ctx, redeemGasPerCondition); err != nil {
 		return nil, err
 	}
 
-	conditionalTokensABI := GetConditionalTokensABI()
-
 	// Convert conditionID to [32]byte
 	var conditionIDBytes32 [32]byte
 	copy(conditionIDBytes32[:], conditionID)
 
-	// parentCollectionId is NULL_HASH (all zeros)
-	var parentCollectionID [32]byte
+	// Check if user has any positions to redeem
+	hasPositions, err := cc.hasRedeemablePositions(ctx, collateralToken, conditionIDBytes32)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPositions {
+		return nil, fmt.Errorf("no positions to redeem")
+	}
 
-	// partition = [1, 2] for binary markets (YES and NO outcomes)
-	partition := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	redeemTx, err := redeemPositionsCall(cc.conditionalTokensAddr, collateralToken, conditionIDBytes32)
+	if err != nil {
+		return nil, err
+	}
 
-	// Check if user has any positions to redeem
-	hasPositions := false
-	for _, indexSet := range partition {
-		positionID, err := cc.getPositionID(ctx, conditionIDBytes32, indexSet, collateralToken, parentCollectionID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get position ID: %w", err)
-		}
+	// Execute via multisend
+	tx, err := cc.executeMultisend(ctx, []MultiSendTx{redeemTx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute redeemPositions: %w", err)
+	}
 
-		balance, err := cc.getConditionalTokenBalance(ctx, cc.multiSigAddr, positionID)
+	if err := cc.waitForRedeem(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// RedeemBatch redeems winning outcome tokens of several conditions sharing one collateral
+// token in a single multisend transaction. Conditions without positions are skipped.
+func (cc *ContractCaller) RedeemBatch(ctx context.Context, collateralToken common.Address, conditionIDs [][]byte) (*types.Transaction, error) {
+	var multiSendTxs []MultiSendTx
+	for _, conditionID := range conditionIDs {
+		var conditionIDBytes32 [32]byte
+		copy(conditionIDBytes32[:], conditionID)
+
+		hasPositions, err := cc.hasRedeemablePositions(ctx, collateralToken, conditionIDBytes32)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get position balance: %w", err)
+			return nil, err
+		}
+		if !hasPositions {
+			continue
 		}
 
-		if balance.Sign() > 0 {
-			hasPositions = true
-			break
+		redeemTx, err := redeemPositionsCall(cc.conditionalTokensAddr, collateralToken, conditionIDBytes32)
+		if err != nil {
+			return nil, err
 		}
+		multiSendTxs = append(multiSendTxs, redeemTx)
 	}
 
-	if !hasPositions {
+	if len(multiSendTxs) == 0 {
 		return nil, fmt.Errorf("no positions to redeem")
 	}
 
-	// Build redeemPositions call data
-	redeemData, err := conditionalTokensABI.Pack("redeemPositions",
-		collateralToken,
-		parentCollectionID,
-		conditionIDBytes32,
-		partition,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pack redeemPositions: %w", err)
+	gasLimit := redeemGasPerCondition * uint64(len(multiSendTxs))
+	if gasLimit < defaultMultisendGasLimit {
+		gasLimit = defaultMultisendGasLimit
 	}
-
-	// Build multisend transaction
-	multiSendTxs := []MultiSendTx{
-		{
-			Operation: MultiSendOperationCall,
-			To:        cc.conditionalTokensAddr,
-			Value:     big.NewInt(0),
-			Data:      redeemData,
-		},
+	if err := cc.CheckGasBalance(ctx, gasLimit); err != nil {
+		return nil, err
 	}
 
-	// Execute via multisend
-	tx, err := cc.executeMultisend(ctx, multiSendTxs)
+	tx, err := cc.executeMultisendWithGasLimit(ctx, multiSendTxs, gasLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute redeemPositions: %w", err)
 	}
 
-	// Wait for transaction receipt and validate
+	if err := cc.waitForRedeem(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// hasRedeemablePositions reports whether the multisig holds either outcome of a binary condition
+func (cc *ContractCaller) hasRedeemablePositions(ctx context.Context, collateralToken common.Address, conditionID [32]byte) (bool, error) {
+	// parentCollectionId is NULL_HASH (all zeros)
+	var parentCollectionID [32]byte
+
+	for _, indexSet := range binaryPartition() {
+		positionID, err := cc.getPositionID(ctx, conditionID, indexSet, collateralToken, parentCollectionID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get position ID: %w", err)
+		}
+
+		balance, err := cc.getConditionalTokenBalance(ctx, cc.multiSigAddr, positionID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get position balance: %w", err)
+		}
+
+		if balance.Sign() > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// waitForRedeem waits for a redeem transaction to be mined and checks it succeeded
+func (cc *ContractCaller) waitForRedeem(ctx context.Context, tx *types.Transaction) error {
 	receipt, err := cc.waitForReceipt(ctx, tx.Hash())
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for redeem transaction: %w", err)
+		return fmt.Errorf("failed to wait for redeem transaction: %w", err)
 	}
 	if receipt.Status != 1 {
-		return nil, fmt.Errorf("redeem transaction failed: tx hash %s", tx.Hash().Hex())
+		return fmt.Errorf("redeem transaction failed: tx hash %s", tx.Hash().Hex())
 	}
+	return nil
+}
 
-	return tx, nil
+// redeemPositionsCall builds the multisend call redeeming both outcomes of a binary condition
+func redeemPositionsCall(conditionalTokensAddr, collateralToken common.Address, conditionID [32]byte) (MultiSendTx, error) {
+	// parentCollectionId is NULL_HASH (all zeros)
+	var parentCollectionID [32]byte
+
+	redeemData, err := GetConditionalTokensABI().Pack("redeemPositions",
+		collateralToken,
+		parentCollectionID,
+		conditionID,
+		binaryPartition(),
+	)
+	if err != nil {
+		return MultiSendTx{}, fmt.Errorf("failed to pack redeemPositions: %w", err)
+	}
+
+	return MultiSendTx{
+		Operation: MultiSendOperationCall,
+		To:        conditionalTokensAddr,
+		Value:     big.NewInt(0),
+		Data:      redeemData,
+	}, nil
+}
+
+// binaryPartition returns the index sets [1, 2] of a binary market (YES and NO outcomes)
+func binaryPartition() []*big.Int {
+	return []*big.Int{big.NewInt(1), big.NewInt(2)}
 }
 
 // EnableTrading enables trading by approving necessary tokens.
@@ -374,6 +522,10 @@ func (cc *ContractCaller) Redeem(ctx context.Context, collateralToken common.Add
 // 2. ERC20 tokens -> ConditionalTokens (for splitting)
 // 3. ConditionalTokens -> CTF Exchange (setApprovalForAll)
 func (cc *ContractCaller) EnableTrading(ctx context.Context, supportedQuoteTokens map[string]string) (*types.Transaction, error) {
+	// Concurrent callers wait here and then see the updated last check time
+	cc.enableTradingMu.Lock()
+	defer cc.enableTradingMu.Unlock()
+
 	// Check if we should skip based on interval
 	if !cc.enableTradingLastTime.IsZero() {
 		elapsed := time.Since(cc.enableTradingLastTime)
@@ -393,98 +545,36 @@ func (cc *ContractCaller) EnableTrading(ctx context.Context, supportedQuoteToken
 	// ERC20 ABI for allowance and approve functions
 	erc20ABI := GetERC20ABI()
 
+	// Unlimited approval amount (max uint256)
+	maxUint256 := new(big.Int).Sub(new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil), big.NewInt(1))
+
 	for erc20Address, ctfExchangeAddress := range supportedQuoteTokens {
 		erc20Addr := common.HexToAddress(erc20Address)
 		ctfExchangeAddr := common.HexToAddress(ctfExchangeAddress)
 
-		// Get token decimals
-		decimals, err := cc.GetTokenDecimals(ctx, erc20Addr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get decimals for %s: %w", erc20Address, err)
-		}
-
-		// Calculate minimum threshold: 1 billion * 10^decimals
-		minThreshold := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
-		minThreshold = minThreshold.Mul(minThreshold, big.NewInt(1000000000))
-
-		// Unlimited approval amount (max uint256)
-		maxUint256 := new(big.Int).Sub(new(big.Int).Exp(big.NewInt(2), big.NewInt(256), nil), big.NewInt(1))
-
-		// Check allowance for CTF Exchange
-		allowance, err := cc.getERC20Allowance(ctx, erc20Addr, cc.multiSigAddr, ctfExchangeAddr)
+		// Only approve what is missing
+		status, err := cc.GetApprovalStatus(ctx, erc20Addr, ctfExchangeAddr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get allowance: %w", err)
+			return nil, err
 		}
 
-		if allowance.Cmp(minThreshold) < 0 {
-			// If there's existing allowance > 0, reset to 0 first (USDT-style protection)
-			if allowance.Sign() > 0 {
-				resetData, err := erc20ABI.Pack("approve", ctfExchangeAddr, big.NewInt(0))
-				if err != nil {
-					return nil, fmt.Errorf("failed to pack reset approve: %w", err)
-				}
-				multiSendTxs = append(multiSendTxs, MultiSendTx{
-					Operation: MultiSendOperationCall,
-					To:        erc20Addr,
-					Value:     big.NewInt(0),
-					Data:      resetData,
-				})
-			}
-
-			// Approve unlimited allowance
-			approveData, err := erc20ABI.Pack("approve", ctfExchangeAddr, maxUint256)
+		if !status.ExchangeAllowanceOK {
+			txs, err := approveTxs(erc20ABI, erc20Addr, ctfExchangeAddr, status.ExchangeAllowance, maxUint256)
 			if err != nil {
-				return nil, fmt.Errorf("failed to pack approve: %w", err)
+				return nil, err
 			}
-			multiSendTxs = append(multiSendTxs, MultiSendTx{
-				Operation: MultiSendOperationCall,
-				To:        erc20Addr,
-				Value:     big.NewInt(0),
-				Data:      approveData,
-			})
-		}
-
-		// Check allowance for ConditionalTokens (used for splitting)
-		allowanceForCT, err := cc.getERC20Allowance(ctx, erc20Addr, cc.multiSigAddr, cc.conditionalTokensAddr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get allowance for conditional tokens: %w", err)
+			multiSendTxs = append(multiSendTxs, txs...)
 		}
 
-		if allowanceForCT.Cmp(minThreshold) < 0 {
-			// Reset to 0 first if needed
-			if allowanceForCT.Sign() > 0 {
-				resetData, err := erc20ABI.Pack("approve", cc.conditionalTokensAddr, big.NewInt(0))
-				if err != nil {
-					return nil, fmt.Errorf("failed to pack reset approve for CT: %w", err)
-				}
-				multiSendTxs = append(multiSendTxs, MultiSendTx{
-					Operation: MultiSendOperationCall,
-					To:        erc20Addr,
-					Value:     big.NewInt(0),
-					Data:      resetData,
-				})
-			}
-
-			// Approve unlimited allowance
-			approveData, err := erc20ABI.Pack("approve", cc.conditionalTokensAddr, maxUint256)
+		if !status.ConditionalTokensAllowanceOK {
+			txs, err := approveTxs(erc20ABI, erc20Addr, cc.conditionalTokensAddr, status.ConditionalTokensAllowance, maxUint256)
 			if err != nil {
-				return nil, fmt.Errorf("failed to pack approve for CT: %w", err)
+				return nil, err
 			}
-			multiSendTxs = append(multiSendTxs, MultiSendTx{
-				Operation: MultiSendOperationCall,
-				To:        erc20Addr,
-				Value:     big.NewInt(0),
-				Data:      approveData,
-			})
+			multiSendTxs = append(multiSendTxs, txs...)
 		}
 
-		// Check if CTF Exchange is approved for all on ConditionalTokens
-		isApprovedForAll, err := cc.isApprovedForAll(ctx, cc.multiSigAddr, ctfExchangeAddr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check isApprovedForAll: %w", err)
-		}
-
-		if !isApprovedForAll {
+		if !status.ApprovedForAll {
 			conditionalTokensABI := GetConditionalTokensABI()
 			setApprovalData, err := conditionalTokensABI.Pack("setApprovalForAll", ctfExchangeAddr, true)
 			if err != nil {
@@ -513,6 +603,92 @@ func (cc *ContractCaller) EnableTrading(ctx context.Context, supportedQuoteToken
 	return tx, nil
 }
 
+// ApprovalStatus reports the trading approvals the multisig has granted for one quote token
+type ApprovalStatus struct {
+	QuoteToken                   common.Address
+	Exchange                     common.Address
+	ExchangeAllowance            *big.Int // ERC20 allowance to the CTF exchange
+	ConditionalTokensAllowance   *big.Int // ERC20 allowance to ConditionalTokens (for splitting)
+	ExchangeAllowanceOK          bool
+	ConditionalTokensAllowanceOK bool
+	ApprovedForAll               bool // ConditionalTokens setApprovalForAll to the CTF exchange
+}
+
+// Enabled reports whether every approval needed to trade the quote token is in place
+func (s *ApprovalStatus) Enabled() bool {
+	return s.ExchangeAllowanceOK && s.ConditionalTokensAllowanceOK && s.ApprovedForAll
+}
+
+// GetApprovalStatus checks the ERC20 allowances and the ERC1155 setApprovalForAll
+// the multisig has granted for trading quoteToken on exchange
+func (cc *ContractCaller) GetApprovalStatus(ctx context.Context, quoteToken, exchange common.Address) (*ApprovalStatus, error) {
+	decimals, err := cc.GetTokenDecimals(ctx, quoteToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decimals for %s: %w", quoteToken.Hex(), err)
+	}
+
+	// Minimum threshold: 1 billion * 10^decimals
+	minThreshold := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	minThreshold = minThreshold.Mul(minThreshold, big.NewInt(1000000000))
+
+	allowance, err := cc.getERC20Allowance(ctx, quoteToken, cc.multiSigAddr, exchange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowance: %w", err)
+	}
+
+	allowanceForCT, err := cc.getERC20Allowance(ctx, quoteToken, cc.multiSigAddr, cc.conditionalTokensAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowance for conditional tokens: %w", err)
+	}
+
+	approvedForAll, err := cc.isApprovedForAll(ctx, cc.multiSigAddr, exchange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check isApprovedForAll: %w", err)
+	}
+
+	return &ApprovalStatus{
+		QuoteToken:                   quoteToken,
+		Exchange:                     exchange,
+		ExchangeAllowance:            allowance,
+		ConditionalTokensAllowance:   allowanceForCT,
+		ExchangeAllowanceOK:          allowance.Cmp(minThreshold) >= 0,
+		ConditionalTokensAllowanceOK: allowanceForCT.Cmp(minThreshold) >= 0,
+		ApprovedForAll:               approvedForAll,
+	}, nil
+}
+
+// approveTxs builds the multisend calls that raise the allowance of token for spender to amount
+func approveTxs(erc20ABI abi.ABI, token, spender common.Address, current, amount *big.Int) ([]MultiSendTx, error) {
+	var txs []MultiSendTx
+
+	// If there's existing allowance > 0, reset to 0 first (USDT-style protection)
+	if current.Sign() > 0 {
+		resetData, err := erc20ABI.Pack("approve", spender, big.NewInt(0))
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack reset approve: %w", err)
+		}
+		txs = append(txs, MultiSendTx{
+			Operation: MultiSendOperationCall,
+			To:        token,
+			Value:     big.NewInt(0),
+			Data:      resetData,
+		})
+	}
+
+	approveData, err := erc20ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack approve: %w", err)
+	}
+	txs = append(txs, MultiSendTx{
+		Operation: MultiSendOperationCall,
+		To:        token,
+		Value:     big.NewInt(0),
+		Data:      approveData,
+	})
+
+	return txs, nil
+}
+
 // getERC20Allowance returns the ERC20 allowance for owner to spender
 func (cc *ContractCaller) getERC20Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error) {
 	erc20ABI := GetERC20ABI()
@@ -581,6 +757,7 @@ func (cc *ContractCaller) waitForReceipt(ctx context.Context, txHash common.Hash
 			return nil, fmt.Errorf("timeout waiting for transaction receipt: %s", txHash.Hex())
 		default:
 			// Wait a bit before retrying
+			cc.logger.Debug("transaction receipt not available yet, retrying", "txHash", txHash.Hex(), "error", err)
 			time.Sleep(2 * time.Second)
 		}
 	}
@@ -611,6 +788,40 @@ func (cc *ContractCaller) isApprovedForAll(ctx context.Context, owner, operator
 	return approved, nil
 }
 
+// IsExchangePaused reads the paused() state of a CTF exchange contract
+func (cc *ContractCaller) IsExchangePaused(ctx context.Context, exchangeAddr common.Address) (bool, error) {
+	exchangeABI := GetCTFExchangeABI()
+	data, err := exchangeABI.Pack("paused")
+	if err != nil {
+		return false, err
+	}
+
+	result, err := cc.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &exchangeAddr,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to read exchange paused state: %w", err)
+	}
+
+	var paused bool
+	err = exchangeABI.UnpackIntoInterface(&paused, "paused", result)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpack exchange paused state: %w", err)
+	}
+
+	return paused, nil
+}
+
+// HasCode reports whether a contract is deployed at addr
+func (cc *ContractCaller) HasCode(ctx context.Context, addr common.Address) (bool, error) {
+	code, err := cc.client.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code at %s: %w", addr.Hex(), err)
+	}
+	return len(code) > 0, nil
+}
+
 // getPositionID gets the position ID for conditional tokens
 func (cc *ContractCaller) getPositionID(ctx context.Context, conditionID [32]byte, indexSet *big.Int, collateralToken common.Address, parentCollectionID [32]byte) (*big.Int, error) {
 	conditionalTokensABI := GetConditionalTokensABI()
@@ -696,8 +907,19 @@ const (
 	MultiSendOperationDelegateCall uint8 = 1
 )
 
+// Gas limits for multisend transactions
+const (
+	defaultMultisendGasLimit uint64 = 500000
+	redeemGasPerCondition    uint64 = 300000
+)
+
 // executeMultisend executes multiple transactions via the multisend contract
 func (cc *ContractCaller) executeMultisend(ctx context.Context, txs []MultiSendTx) (*types.Transaction, error) {
+	return cc.executeMultisendWithGasLimit(ctx, txs, defaultMultisendGasLimit)
+}
+
+// encodeMultisend packs transactions into multiSend call data
+func encodeMultisend(txs []MultiSendTx) ([]byte, error) {
 	// Build encoded multisend data
 	var encodedTxs []byte
 	for _, tx := range txs {
@@ -717,39 +939,57 @@ func (cc *ContractCaller) executeMultisend(ctx context.Context, txs []MultiSendT
 		return nil, fmt.Errorf("failed to pack multisend: %w", err)
 	}
 
+	return callData, nil
+}
+
+// executeMultisendWithGasLimit executes transactions via the multisend contract with the given gas limit
+func (cc *ContractCaller) executeMultisendWithGasLimit(ctx context.Context, txs []MultiSendTx, gasLimit uint64) (*types.Transaction, error) {
+	callData, err := encodeMultisend(txs)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build and sign transaction
-	chainID, err := cc.client.ChainID(ctx)
+	// Refuse to sign for the configured chain through an RPC serving a different one
+	rpcChainID, err := cc.client.ChainID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
+	if rpcChainID.Cmp(cc.chainID) != 0 {
+		return nil, fmt.Errorf("RPC chain ID %s does not match configured chain ID %s", rpcChainID, cc.chainID)
+	}
 
 	nonce, err := cc.client.PendingNonceAt(ctx, cc.GetSignerAddress())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	gasPrice, err := cc.client.SuggestGasPrice(ctx)
+	gasPrice, err := cc.gasPrice(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
+		return nil, err
 	}
 
 	tx := types.NewTransaction(
 		nonce,
 		cc.multisendAddr,
 		big.NewInt(0),
-		uint64(500000),
+		gasLimit,
 		gasPrice,
 		callData,
 	)
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), cc.privateKey)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(cc.chainID), cc.privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
 	if err := cc.client.SendTransaction(ctx, signedTx); err != nil {
+		cc.metrics.IncTransaction(false)
+		cc.logger.Error("transaction broadcast failed", "txHash", signedTx.Hash().Hex(), "nonce", nonce, "error", err)
 		return nil, fmt.Errorf("failed to send transaction: %w", err)
 	}
+	cc.metrics.IncTransaction(true)
+	cc.logger.Info("transaction broadcast", "txHash", signedTx.Hash().Hex(), "to", cc.multisendAddr.Hex(), "nonce", nonce, "gasLimit", gasLimit, "gasPrice", gasPrice.String())
 
 	return signedTx, nil
 }
@@ -772,14 +1012,6 @@ func (cc *ContractCaller) GetFeeRateSettings(ctx context.Context, tokenID *big.I
 	}
 
 	// Unpack the result - returns (makerFeeRateBps, takerFeeRateBps, enabled, minFeeAmount)
-	var (
-		makerFeeRateBps *big.Int
-		takerFeeRateBps *big.Int
-		enabled         bool
-		minFeeAmount    *big.Int
-	)
-
-	// Create a struct to unpack into
 	type feeRateResult struct {
 		MakerFeeRateBps *big.Int
 		TakerFeeRateBps *big.Int
@@ -793,21 +1025,19 @@ func (cc *ContractCaller) GetFeeRateSettings(ctx context.Context, tokenID *big.I
 		return nil, fmt.Errorf("failed to unpack getFeeRateSettings: %w", err)
 	}
 
-	makerFeeRateBps = unpacked.MakerFeeRateBps
-	takerFeeRateBps = unpacked.TakerFeeRateBps
-	enabled = unpacked.Enabled
-	_ = minFeeAmount // Not used in the current FeeRateSettings struct
-
 	// Convert basis points to max fee rate percentage
 	// Formula: fee_rate_bps * 0.25 / 10000
 	// Example: 800 * 0.25 / 10000 = 0.02 (2%)
-	makerMaxFeeRate := float64(makerFeeRateBps.Int64()) * 0.25 / 10000
-	takerMaxFeeRate := float64(takerFeeRateBps.Int64()) * 0.25 / 10000
+	makerMaxFeeRate := float64(unpacked.MakerFeeRateBps.Int64()) * 0.25 / 10000
+	takerMaxFeeRate := float64(unpacked.TakerFeeRateBps.Int64()) * 0.25 / 10000
 
 	return &FeeRateSettings{
 		MakerMaxFeeRate: makerMaxFeeRate,
 		TakerMaxFeeRate: takerMaxFeeRate,
-		Enabled:         enabled,
+		Enabled:         unpacked.Enabled,
+		MakerFeeRateBps: unpacked.MakerFeeRateBps,
+		TakerFeeRateBps: unpacked.TakerFeeRateBps,
+		MinFeeAmount:    unpacked.MinFeeAmount,
 	}, nil
 }
 
@@ -0,0 +1,223 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// testKey is a well-known test key; never fund it
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+// fakeNode is a JSON-RPC endpoint on BNB mainnet suggesting a 1 gwei gas price. It
+// records the transactions sent to it.
+type fakeNode struct {
+	srv     *httptest.Server
+	balance *big.Int
+	mu      sync.Mutex
+	sent    []*types.Transaction
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	n := &fakeNode{balance: big.NewInt(0)}
+	n.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result := `"0x0"`
+		switch req.Method {
+		case "eth_chainId":
+			result = `"0x38"`
+		case "eth_gasPrice":
+			result = `"0x3b9aca00"`
+		case "eth_getBalance":
+			result = fmt.Sprintf("%q", hexutil.EncodeBig(n.balance))
+		case "eth_sendRawTransaction":
+			var raw hexutil.Bytes
+			json.Unmarshal(req.Params[0], &raw)
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalBinary(raw); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			n.mu.Lock()
+			n.sent = append(n.sent, tx)
+			n.mu.Unlock()
+			result = fmt.Sprintf("%q", tx.Hash().Hex())
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, result)
+	}))
+	t.Cleanup(n.srv.Close)
+	return n
+}
+
+// newTestCaller returns a ContractCaller for node with the given gas price multiplier
+func newTestCaller(t *testing.T, node *fakeNode, multiplier float64) *ContractCaller {
+	t.Helper()
+	cc, err := NewContractCaller(node.srv.URL, testKey,
+		"0x1111111111111111111111111111111111111111",
+		"0xAD1a38cEc043e70E83a3eC30443dB285ED10D774",
+		"0x998739BFdAAdde7C933B942a68053933098f9EDa",
+		"0xC9063Dc52dEEfb518E5b6634A6b8D624bc5d7c36",
+		56, time.Hour, multiplier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cc
+}
+
+// decodeMultisend splits multiSend call data back into its transactions
+func decodeMultisend(t *testing.T, callData []byte) []MultiSendTx {
+	t.Helper()
+	args, err := GetMultisendABI().Methods["multiSend"].Inputs.Unpack(callData[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed := args[0].([]byte)
+
+	var txs []MultiSendTx
+	for off := 0; off < len(packed); {
+		// operation (1) + to (20) + value (32) + data length (32) + data
+		length := int(common.BytesToHash(packed[off+53 : off+85]).Big().Int64())
+		txs = append(txs, MultiSendTx{
+			Operation: packed[off],
+			To:        common.BytesToAddress(packed[off+1 : off+21]),
+			Value:     common.BytesToHash(packed[off+21 : off+53]).Big(),
+			Data:      packed[off+85 : off+85+length],
+		})
+		off += 85 + length
+	}
+	return txs
+}
+
+func TestRedeemBatchEncodesOneCallPerCondition(t *testing.T) {
+	conditionalTokens := common.HexToAddress("0xAD1a38cEc043e70E83a3eC30443dB285ED10D774")
+	collateral := common.HexToAddress("0x55d398326f99059fF775485246999027B3197955")
+
+	var calls []MultiSendTx
+	for i := 0; i < 3; i++ {
+		var conditionID [32]byte
+		conditionID[0] = byte(i + 1)
+		call, err := redeemPositionsCall(conditionalTokens, collateral, conditionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, call)
+	}
+	callData, err := encodeMultisend(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txs := decodeMultisend(t, callData)
+	if len(txs) != 3 {
+		t.Fatalf("multisend holds %d calls, want 3", len(txs))
+	}
+	redeem := GetConditionalTokensABI().Methods["redeemPositions"]
+	for i, tx := range txs {
+		if tx.Operation != MultiSendOperationCall || tx.To != conditionalTokens || tx.Value.Sign() != 0 {
+			t.Fatalf("call %d = op %d to %s value %s", i, tx.Operation, tx.To, tx.Value)
+		}
+		if !bytes.Equal(tx.Data[:4], redeem.ID) {
+			t.Fatalf("call %d is not redeemPositions", i)
+		}
+		args, err := redeem.Inputs.Unpack(tx.Data[4:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if args[0].(common.Address) != collateral || args[2].([32]byte)[0] != byte(i+1) {
+			t.Fatalf("call %d redeems %v", i, args)
+		}
+	}
+}
+
+func TestGasPriceMultiplierIsApplied(t *testing.T) {
+	tests := []struct {
+		multiplier float64
+		want       int64
+	}{
+		{0, 1000000000}, // unset
+		{1, 1000000000},
+		{1.1, 1100000000},
+		{2.5, 2500000000},
+	}
+	for _, tt := range tests {
+		node := newFakeNode(t)
+		cc := newTestCaller(t, node, tt.multiplier)
+
+		call := MultiSendTx{Operation: MultiSendOperationCall, To: cc.conditionalTokensAddr, Value: big.NewInt(0)}
+		if _, err := cc.executeMultisend(context.Background(), []MultiSendTx{call}); err != nil {
+			t.Fatal(err)
+		}
+		if len(node.sent) != 1 {
+			t.Fatalf("multiplier %g: sent %d transactions, want 1", tt.multiplier, len(node.sent))
+		}
+		if got := node.sent[0].GasPrice(); got.Int64() != tt.want {
+			t.Errorf("multiplier %g: gas price = %s, want %d", tt.multiplier, got, tt.want)
+		}
+	}
+}
+
+func TestCheckGasBalanceUsesScaledGasPrice(t *testing.T) {
+	node := newFakeNode(t)
+	// Enough for 100000 gas plus the 20% margin at 1 gwei, but not at 2 gwei
+	node.balance = big.NewInt(120000 * 1000000000)
+
+	if err := newTestCaller(t, node, 1).CheckGasBalance(context.Background(), 100000); err != nil {
+		t.Fatalf("unscaled: %v", err)
+	}
+	err := newTestCaller(t, node, 2).CheckGasBalance(context.Background(), 100000)
+	if err == nil || !strings.Contains(err.Error(), "insufficient gas balance") {
+		t.Fatalf("scaled error = %v, want insufficient gas balance", err)
+	}
+}
+
+func TestValidateRPCURL(t *testing.T) {
+	tests := map[string]string{
+		"bsc-dataseed.binance.org": "missing scheme",
+		"ftp://example.org":        "unsupported scheme",
+		"http://":                  "missing host",
+		"wss://example.org/ws":     "",
+		"HTTPS://example.org":      "",
+	}
+	for rpcURL, want := range tests {
+		err := ValidateRPCURL(rpcURL)
+		if want == "" && err != nil {
+			t.Errorf("ValidateRPCURL(%q) = %v, want nil", rpcURL, err)
+		}
+		if want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+			t.Errorf("ValidateRPCURL(%q) = %v, want an error mentioning %q", rpcURL, err, want)
+		}
+	}
+}
+
+func TestNewContractCallerRejectsSchemelessURL(t *testing.T) {
+	_, err := NewContractCaller("localhost:8545", testKey,
+		"0x1111111111111111111111111111111111111111",
+		"0xAD1a38cEc043e70E83a3eC30443dB285ED10D774",
+		"0x998739BFdAAdde7C933B942a68053933098f9EDa",
+		"0xC9063Dc52dEEfb518E5b6634A6b8D624bc5d7c36",
+		56, time.Hour, 1)
+	if err == nil || !strings.Contains(err.Error(), "missing scheme") {
+		t.Fatalf("expected a missing scheme error, got %v", err)
+	}
+}
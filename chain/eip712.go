@@ -36,6 +36,48 @@ var (
 	))
 )
 
+// ABI argument lists for the domain separator and order struct encodings, built once
+// since constructing abi types allocates
+var (
+	bytes32Type = mustNewType("bytes32")
+	uint256Type = mustNewType("uint256")
+	addressType = mustNewType("address")
+	uint8Type   = mustNewType("uint8")
+
+	domainArguments = abi.Arguments{
+		{Type: bytes32Type}, // typeHash
+		{Type: bytes32Type}, // nameHash
+		{Type: bytes32Type}, // versionHash
+		{Type: uint256Type}, // chainId
+		{Type: addressType}, // verifyingContract
+	}
+
+	orderArguments = abi.Arguments{
+		{Type: bytes32Type}, // typeHash
+		{Type: uint256Type}, // salt
+		{Type: addressType}, // maker
+		{Type: addressType}, // signer
+		{Type: addressType}, // taker
+		{Type: uint256Type}, // tokenId
+		{Type: uint256Type}, // makerAmount
+		{Type: uint256Type}, // takerAmount
+		{Type: uint256Type}, // expiration
+		{Type: uint256Type}, // nonce
+		{Type: uint256Type}, // feeRateBps
+		{Type: uint8Type},   // side
+		{Type: uint8Type},   // signatureType
+	}
+)
+
+// mustNewType builds an elementary abi type, panicking on an invalid name
+func mustNewType(name string) abi.Type {
+	t, err := abi.NewType(name, "", nil)
+	if err != nil {
+		panic("invalid abi type " + name + ": " + err.Error())
+	}
+	return t
+}
+
 // EIP712Domain represents the EIP712 domain separator data
 type EIP712Domain struct {
 	Name              string
@@ -62,19 +104,7 @@ func (d *EIP712Domain) Hash() common.Hash {
 
 	// ABI encode the domain separator data
 	// The encoding is: typeHash ++ keccak256(name) ++ keccak256(version) ++ chainId ++ verifyingContract
-	bytes32Type, _ := abi.NewType("bytes32", "", nil)
-	uint256Type, _ := abi.NewType("uint256", "", nil)
-	addressType, _ := abi.NewType("address", "", nil)
-
-	arguments := abi.Arguments{
-		{Type: bytes32Type}, // typeHash
-		{Type: bytes32Type}, // nameHash
-		{Type: bytes32Type}, // versionHash
-		{Type: uint256Type}, // chainId
-		{Type: addressType}, // verifyingContract
-	}
-
-	encoded, err := arguments.Pack(
+	encoded, err := domainArguments.Pack(
 		EIP712DomainTypeHash,
 		nameHash,
 		versionHash,
@@ -107,28 +137,7 @@ type OrderTypedData struct {
 // Hash computes the struct hash for the order
 func (o *OrderTypedData) Hash() common.Hash {
 	// ABI encode the order data
-	bytes32Type, _ := abi.NewType("bytes32", "", nil)
-	addressType, _ := abi.NewType("address", "", nil)
-	uint256Type, _ := abi.NewType("uint256", "", nil)
-	uint8Type, _ := abi.NewType("uint8", "", nil)
-
-	arguments := abi.Arguments{
-		{Type: bytes32Type}, // typeHash
-		{Type: uint256Type}, // salt
-		{Type: addressType}, // maker
-		{Type: addressType}, // signer
-		{Type: addressType}, // taker
-		{Type: uint256Type}, // tokenId
-		{Type: uint256Type}, // makerAmount
-		{Type: uint256Type}, // takerAmount
-		{Type: uint256Type}, // expiration
-		{Type: uint256Type}, // nonce
-		{Type: uint256Type}, // feeRateBps
-		{Type: uint8Type},   // side
-		{Type: uint8Type},   // signatureType
-	}
-
-	encoded, err := arguments.Pack(
+	encoded, err := orderArguments.Pack(
 		OrderTypeHash,
 		o.Salt,
 		o.Maker,
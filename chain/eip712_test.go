@@ -0,0 +1,54 @@
+package chain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testOrder returns an order with every field set
+func testOrder() *OrderTypedData {
+	return &OrderTypedData{
+		Salt:          big.NewInt(12345),
+		Maker:         common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Signer:        common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		TokenID:       big.NewInt(777),
+		MakerAmount:   big.NewInt(1000),
+		TakerAmount:   big.NewInt(2000),
+		Expiration:    big.NewInt(0),
+		Nonce:         big.NewInt(0),
+		FeeRateBps:    big.NewInt(5),
+		Side:          1,
+		SignatureType: 2,
+	}
+}
+
+func TestOrderHashIsUnchanged(t *testing.T) {
+	domain := NewEIP712Domain(big.NewInt(56), common.HexToAddress("0x3333333333333333333333333333333333333333"))
+
+	// Computed with the ABI types built on every call
+	tests := []struct {
+		name string
+		got  common.Hash
+		want string
+	}{
+		{"domain", domain.Hash(), "0x80fb290dc6c2e98e2740643c372dbb4944c0e368a0ddddba231d721d5c707718"},
+		{"order", testOrder().Hash(), "0x9b32c7de1eb9a6cb2694757c0832297d98741892fb6fd2b4ca1877fa4ccec293"},
+		{"sign hash", CreateOrderSignHash(domain, testOrder()), "0xbd3ca6b5f7f3120741f94f441a2997667113886cfbfa32e89f8ee1f2b7dd2605"},
+	}
+	for _, tt := range tests {
+		if tt.got.Hex() != tt.want {
+			t.Errorf("%s hash = %s, want %s", tt.name, tt.got.Hex(), tt.want)
+		}
+	}
+}
+
+func BenchmarkCreateOrderSignHash(b *testing.B) {
+	domain := NewEIP712Domain(big.NewInt(56), common.HexToAddress("0x3333333333333333333333333333333333333333"))
+	order := testOrder()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		CreateOrderSignHash(domain, order)
+	}
+}
@@ -0,0 +1,25 @@
+package chain
+
+// Logger receives structured log events. keysAndValues are alternating field names and
+// values, so a *slog.Logger can be used directly.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// NopLogger discards all log events
+type NopLogger struct{}
+
+// Debug discards the event
+func (NopLogger) Debug(string, ...interface{}) {}
+
+// Info discards the event
+func (NopLogger) Info(string, ...interface{}) {}
+
+// Warn discards the event
+func (NopLogger) Warn(string, ...interface{}) {}
+
+// Error discards the event
+func (NopLogger) Error(string, ...interface{}) {}
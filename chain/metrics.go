@@ -0,0 +1,34 @@
+package chain
+
+import (
+	"time"
+)
+
+// MetricsCollector receives counters and timings from the SDK. Implementations are called
+// on the request path and should not block.
+type MetricsCollector interface {
+	// ObserveRequest records an API request; status is the HTTP status code, or 0 if no
+	// response was received. route has IDs and query strings removed.
+	ObserveRequest(method, route string, status int, duration time.Duration)
+	// IncOrder counts an order event such as "placed", "rejected" or "cancelled"
+	IncOrder(event string)
+	// IncReconnect counts a WebSocket reconnect attempt
+	IncReconnect(success bool)
+	// IncTransaction counts a transaction broadcast
+	IncTransaction(success bool)
+}
+
+// NopMetrics discards all metrics
+type NopMetrics struct{}
+
+// ObserveRequest discards the observation
+func (NopMetrics) ObserveRequest(string, string, int, time.Duration) {}
+
+// IncOrder discards the event
+func (NopMetrics) IncOrder(string) {}
+
+// IncReconnect discards the event
+func (NopMetrics) IncReconnect(bool) {}
+
+// IncTransaction discards the event
+func (NopMetrics) IncTransaction(bool) {}
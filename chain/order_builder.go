@@ -12,19 +12,82 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// OrderParamsProvider supplies the salt, nonce and default expiration of new orders.
+// Integrators running several processes can share one provider to avoid salt collisions.
+type OrderParamsProvider interface {
+	NextSalt() (string, error)
+	NextNonce() (string, error)
+	DefaultExpiration() string
+}
+
+// LocalOrderParamsProvider generates order params in-process.
+// Salts are random, nonce is "0" and orders never expire.
+type LocalOrderParamsProvider struct{}
+
+// NextSalt returns a new random salt
+func (LocalOrderParamsProvider) NextSalt() (string, error) {
+	now := time.Now().Unix()
+	random := rand.Int63()
+	return strconv.FormatInt(now*random, 10), nil
+}
+
+// NextNonce returns the default nonce
+func (LocalOrderParamsProvider) NextNonce() (string, error) {
+	return "0", nil
+}
+
+// DefaultExpiration returns "0" (good till cancelled)
+func (LocalOrderParamsProvider) DefaultExpiration() string {
+	return "0"
+}
+
+// FixedOrderParamsProvider returns the same salt, nonce and expiration for every order.
+// It makes signed orders reproducible, e.g. for golden tests.
+type FixedOrderParamsProvider struct {
+	Salt       string
+	Nonce      string
+	Expiration string
+}
+
+// NextSalt returns the fixed salt
+func (p FixedOrderParamsProvider) NextSalt() (string, error) {
+	return p.Salt, nil
+}
+
+// NextNonce returns the fixed nonce
+func (p FixedOrderParamsProvider) NextNonce() (string, error) {
+	return p.Nonce, nil
+}
+
+// DefaultExpiration returns the fixed expiration
+func (p FixedOrderParamsProvider) DefaultExpiration() string {
+	return p.Expiration
+}
+
 // OrderBuilder builds and signs orders
 type OrderBuilder struct {
 	exchangeAddr common.Address
 	chainID      *big.Int
 	signer       *ecdsa.PrivateKey
+	params       OrderParamsProvider
 }
 
-// NewOrderBuilder creates a new OrderBuilder
+// NewOrderBuilder creates a new OrderBuilder using locally generated order params
 func NewOrderBuilder(exchangeAddr string, chainID int64, signer *ecdsa.PrivateKey) (*OrderBuilder, error) {
+	return NewOrderBuilderWithParams(exchangeAddr, chainID, signer, nil)
+}
+
+// NewOrderBuilderWithParams creates a new OrderBuilder that takes salt, nonce and
+// default expiration from params. A nil params falls back to LocalOrderParamsProvider.
+func NewOrderBuilderWithParams(exchangeAddr string, chainID int64, signer *ecdsa.PrivateKey, params OrderParamsProvider) (*OrderBuilder, error) {
+	if params == nil {
+		params = LocalOrderParamsProvider{}
+	}
 	return &OrderBuilder{
 		exchangeAddr: common.HexToAddress(exchangeAddr),
 		chainID:      big.NewInt(chainID),
 		signer:       signer,
+		params:       params,
 	}, nil
 }
 
@@ -34,16 +97,25 @@ func (ob *OrderBuilder) BuildOrder(data *OrderData) (*Order, error) {
 		return nil, err
 	}
 
-	// Generate salt if not provided
-	salt := ob.generateSalt()
+	salt, err := ob.params.NextSalt()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get salt: %w", err)
+	}
 
 	// Set defaults
 	if data.Signer == "" {
 		data.Signer = data.Maker
 	}
 
+	if data.Nonce == "" {
+		data.Nonce, err = ob.params.NextNonce()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+	}
+
 	if data.Expiration == "" {
-		data.Expiration = "0"
+		data.Expiration = ob.params.DefaultExpiration()
 	}
 
 	// Convert side to string
@@ -143,12 +215,6 @@ func (ob *OrderBuilder) validateInputs(data *OrderData) error {
 	return nil
 }
 
-func (ob *OrderBuilder) generateSalt() string {
-	now := time.Now().Unix()
-	random := rand.Int63()
-	return strconv.FormatInt(now*random, 10)
-}
-
 func normalizeAddress(addr string) string {
 	return common.HexToAddress(addr).Hex()
 }
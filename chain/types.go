@@ -1,9 +1,12 @@
 package chain
 
 import (
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/kaifufi/opinion-labs-sdk-go/internal/enumjson"
 )
 
 // OrderSide represents the side of an order
@@ -14,6 +17,44 @@ const (
 	OrderSideSell
 )
 
+// String returns "Buy" or "Sell"
+func (s OrderSide) String() string {
+	if name, ok := s.name(); ok {
+		return name
+	}
+	return "unknown"
+}
+
+// name returns the side's name, or false if it has none
+func (s OrderSide) name() (string, bool) {
+	switch s {
+	case OrderSideBuy:
+		return "Buy", true
+	case OrderSideSell:
+		return "Sell", true
+	default:
+		return "", false
+	}
+}
+
+// MarshalJSON encodes the side by name; unknown values are encoded as numbers
+func (s OrderSide) MarshalJSON() ([]byte, error) {
+	name, ok := s.name()
+	return enumjson.Encode(name, ok, int(s)), nil
+}
+
+// UnmarshalJSON decodes a side sent as a number, a numeric string or "buy"/"sell"
+func (s *OrderSide) UnmarshalJSON(data []byte) error {
+	n, ok, err := enumjson.Decode(data, "order side", map[string]int{
+		"buy":  int(OrderSideBuy),
+		"sell": int(OrderSideSell),
+	})
+	if ok {
+		*s = OrderSide(n)
+	}
+	return err
+}
+
 // SignatureType represents the signature type for orders
 type SignatureType int
 
@@ -23,6 +64,47 @@ const (
 	SignatureTypePolyProxy
 )
 
+// String returns the name of the signature type
+func (t SignatureType) String() string {
+	if name, ok := t.name(); ok {
+		return name
+	}
+	return "unknown"
+}
+
+// name returns the signature type's name, or false if it has none
+func (t SignatureType) name() (string, bool) {
+	switch t {
+	case SignatureTypeEOA:
+		return "EOA", true
+	case SignatureTypePolyGnosisSafe:
+		return "PolyGnosisSafe", true
+	case SignatureTypePolyProxy:
+		return "PolyProxy", true
+	default:
+		return "", false
+	}
+}
+
+// MarshalJSON encodes the signature type by name; unknown values are encoded as numbers
+func (t SignatureType) MarshalJSON() ([]byte, error) {
+	name, ok := t.name()
+	return enumjson.Encode(name, ok, int(t)), nil
+}
+
+// UnmarshalJSON decodes a signature type sent as a number, a numeric string or its name
+func (t *SignatureType) UnmarshalJSON(data []byte) error {
+	n, ok, err := enumjson.Decode(data, "signature type", map[string]int{
+		"eoa":            int(SignatureTypeEOA),
+		"polygnosissafe": int(SignatureTypePolyGnosisSafe),
+		"polyproxy":      int(SignatureTypePolyProxy),
+	})
+	if ok {
+		*t = SignatureType(n)
+	}
+	return err
+}
+
 // OrderData represents the data for building an order
 type OrderData struct {
 	Maker         string
@@ -65,6 +147,9 @@ type FeeRateSettings struct {
 	MakerMaxFeeRate float64
 	TakerMaxFeeRate float64
 	Enabled         bool
+	MakerFeeRateBps *big.Int // raw maker fee rate, as signed into orders
+	TakerFeeRateBps *big.Int // raw taker fee rate, as signed into orders
+	MinFeeAmount    *big.Int
 }
 
 // ERC20 ABI JSON for allowance, approve, balanceOf, and decimals functions
@@ -229,6 +314,17 @@ const feeManagerABIJSON = `[
 	}
 ]`
 
+// CTF exchange ABI JSON for the paused function
+const ctfExchangeABIJSON = `[
+	{
+		"constant": true,
+		"inputs": [],
+		"name": "paused",
+		"outputs": [{"name": "", "type": "bool"}],
+		"type": "function"
+	}
+]`
+
 // GetERC20ABI returns the parsed ERC20 ABI
 func GetERC20ABI() abi.ABI {
 	parsed, err := abi.JSON(strings.NewReader(erc20ABIJSON))
@@ -264,3 +360,12 @@ func GetFeeManagerABI() abi.ABI {
 	}
 	return parsed
 }
+
+// GetCTFExchangeABI returns the parsed CTF exchange ABI
+func GetCTFExchangeABI() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(ctfExchangeABIJSON))
+	if err != nil {
+		panic("failed to parse CTF exchange ABI: " + err.Error())
+	}
+	return parsed
+}
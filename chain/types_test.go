@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderSideJSON(t *testing.T) {
+	for _, tt := range []struct {
+		side OrderSide
+		want string
+	}{
+		{OrderSideBuy, "Buy"},
+		{OrderSideSell, "Sell"},
+	} {
+		if got := tt.side.String(); got != tt.want {
+			t.Errorf("OrderSide(%d).String() = %q, want %q", int(tt.side), got, tt.want)
+		}
+		data, err := json.Marshal(tt.side)
+		if err != nil || string(data) != `"`+tt.want+`"` {
+			t.Errorf("json.Marshal(%v) = %s, %v", tt.side, data, err)
+		}
+		var decoded OrderSide
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != tt.side {
+			t.Errorf("round trip of %v = %v, %v", tt.side, decoded, err)
+		}
+	}
+
+	// The backend sends sides as numbers; names match case-insensitively
+	for input, want := range map[string]OrderSide{`1`: OrderSideSell, `"0"`: OrderSideBuy, `"SELL"`: OrderSideSell} {
+		var side OrderSide
+		if err := json.Unmarshal([]byte(input), &side); err != nil || side != want {
+			t.Errorf("json.Unmarshal(%s) = %v, %v; want %v", input, side, err, want)
+		}
+	}
+	if data, _ := json.Marshal(OrderSide(7)); string(data) != "7" {
+		t.Errorf("unknown side marshalled as %s, want 7", data)
+	}
+	if OrderSide(7).String() != "unknown" {
+		t.Errorf("OrderSide(7).String() = %q, want unknown", OrderSide(7).String())
+	}
+	var side OrderSide
+	if err := json.Unmarshal([]byte(`"hold"`), &side); err == nil {
+		t.Error("json.Unmarshal accepted an unknown side name")
+	}
+}
+
+func TestSignatureTypeJSON(t *testing.T) {
+	for _, tt := range []struct {
+		sigType SignatureType
+		want    string
+	}{
+		{SignatureTypeEOA, "EOA"},
+		{SignatureTypePolyGnosisSafe, "PolyGnosisSafe"},
+		{SignatureTypePolyProxy, "PolyProxy"},
+	} {
+		if got := tt.sigType.String(); got != tt.want {
+			t.Errorf("SignatureType(%d).String() = %q, want %q", int(tt.sigType), got, tt.want)
+		}
+		data, err := json.Marshal(tt.sigType)
+		if err != nil || string(data) != `"`+tt.want+`"` {
+			t.Errorf("json.Marshal(%v) = %s, %v", tt.sigType, data, err)
+		}
+		var decoded SignatureType
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != tt.sigType {
+			t.Errorf("round trip of %v = %v, %v", tt.sigType, decoded, err)
+		}
+	}
+
+	// null leaves the value unchanged
+	sigType := SignatureTypePolyProxy
+	if err := json.Unmarshal([]byte(`null`), &sigType); err != nil || sigType != SignatureTypePolyProxy {
+		t.Errorf("json.Unmarshal(null) = %v, %v; want it unchanged", sigType, err)
+	}
+}
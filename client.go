@@ -2,16 +2,21 @@ package opinionclob
 
 import (
 	"context"
-	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/kaifufi/opinion-labs-sdk-go/chain"
+	"golang.org/x/sync/singleflight"
 )
 
 // Client is the main SDK client
@@ -19,12 +24,42 @@ type Client struct {
 	apiClient            *APIClient
 	contractCaller       *chain.ContractCaller
 	chainID              ChainID
-	quoteTokensCache     interface{}
+	quoteTokensCache     *GetQuoteTokensResponse
 	quoteTokensCacheTime time.Time
 	quoteTokensCacheTTL  time.Duration
-	marketCache          map[int]cacheEntry
-	marketCacheTTL       time.Duration
+	quoteTokenRegistry   *QuoteTokenRegistry
+	registrySource       *GetQuoteTokensResponse
+	marketCache          *marketCache
+	pausedCache          map[string]cacheEntry
+	pausedCacheTTL       time.Duration
+	verifyExchangeCode   bool
+	autoInvalidate       bool // drop cached markets after trading actions
+	validateTokenID      bool // reject orders whose token is not one of the market's outcomes
+	checkCutoff          bool // reject orders on markets past their trading cutoff
+	marketOrderPrice     MarketOrderPriceMode
+	timestampPrecision   TimestampPrecision
+	verifiedExchanges    map[string]bool // exchanges known to have contract code
+	feeRateCache         map[string]cacheEntry
+	feeRatesCacheTTL     time.Duration
 	cacheMutex           sync.RWMutex
+	fetchGroup           singleflight.Group // coalesces concurrent market and quote token fetches
+	orderParams          OrderParamsProvider
+	clock                func() time.Time
+	submittedOrders      *submittedOrderCache
+	logger               Logger
+	priceTick            *big.Rat
+	minOrderAmount       *big.Rat // minimum order value for markets that do not report one
+	tickMode             TickMode
+
+	ctx             context.Context // cancelled by Close to stop background work
+	cancel          context.CancelFunc
+	background      sync.WaitGroup // goroutines started by watchers and streams
+	closeMu         sync.Mutex
+	closed          bool
+	wsClients       []*WSClient // WebSocket clients created with NewWSClient
+	shutdownTimeout time.Duration
+	cancelWorkers   int // concurrent cancels in CancelOrdersBatch
+	orderWorkers    int // concurrent placements in PlaceOrdersBatch
 }
 
 type cacheEntry struct {
@@ -46,26 +81,134 @@ type ClientConfig struct {
 	EnableTradingCheckInterval time.Duration
 	QuoteTokensCacheTTL        time.Duration
 	MarketCacheTTL             time.Duration
+	MarketCacheMaxEntries      int                  // Optional: markets kept in the cache, least recently used evicted first (default: 1000)
+	FeeRatesCacheTTL           time.Duration        // Optional: cache TTL for FeeManager fee rates (default: 5 minutes)
+	OrderParamsProvider        OrderParamsProvider  // Optional: defaults to local salt/nonce/expiration generation
+	Clock                      func() time.Time     // Optional: source of order timestamps, defaults to time.Now
+	SubmittedOrdersCacheSize   int                  // Optional: number of submitted orders kept for GetSubmittedOrder (default: 1000)
+	HTTPClient                 *http.Client         // Optional: HTTP client for API requests (default: 30s timeout)
+	Headers                    map[string]string    // Optional: extra headers sent with every API request
+	OverrideStandardHeaders    bool                 // Allow Headers to replace Content-Type and apikey
+	GasPriceMultiplier         float64              // Optional: factor applied to the suggested gas price (default: 1.0)
+	PriceTickSize              string               // Optional: tick for markets that do not report one (default: "0.001")
+	PriceTickMode              TickMode             // Optional: reject (default) or snap prices not aligned to the tick
+	MinOrderAmount             string               // Optional: minimum order value in quote token for markets that do not report one (default: "1")
+	Logger                     Logger               // Optional: receives API, order and transaction events (default: no-op)
+	DisableCache               bool                 // Fetch quote tokens, markets, fee rates and exchange state on every call
+	Metrics                    MetricsCollector     // Optional: receives request, order and transaction metrics (default: no-op)
+	VerifyExchangeCode         bool                 // Check that the API's exchange address is a deployed contract before signing orders for it
+	VerifyAuthAddress          bool                 // Have NewClient check that the API key belongs to the configured signer and multi-sig (one extra request)
+	DisableCacheInvalidation   bool                 // Keep serving cached markets after trading actions until their TTL expires
+	DisableTokenValidation     bool                 // Place orders without checking that TokenID is one of the market's outcome tokens
+	DisableCutoffCheck         bool                 // Place orders without checking that the market's trading cutoff has not passed
+	MarketOrderPrice           MarketOrderPriceMode // Optional: how market orders send their price (default: "0")
+	ShutdownTimeout            time.Duration        // Optional: how long Close waits for in-flight requests and background work (default: 5s)
+	OrderTimestampPrecision    TimestampPrecision   // Optional: unit of the order request timestamp (default: seconds)
+	CancelConcurrency          int                  // Optional: cancel requests CancelOrdersBatch runs at once (default: 8)
+	OrderConcurrency           int                  // Optional: orders PlaceOrdersBatch places at once (default: 1)
 }
 
-// NewClient creates a new Opinion CLOB SDK client
-func NewClient(config ClientConfig) (*Client, error) {
-	// Validate chain ID
-	isSupported := false
-	for _, supportedID := range SupportedChainIDs {
-		if config.ChainID == supportedID {
-			isSupported = true
-			break
-		}
+// Validate checks the config and returns an InvalidParamError listing every problem found
+func (config ClientConfig) Validate() error {
+	var problems []string
+
+	if config.Host == "" {
+		problems = append(problems, "host is required")
+	} else if u, err := url.Parse(config.Host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("host must be an http(s) URL, got: %q", config.Host))
+	}
+
+	if config.APIKey == "" {
+		problems = append(problems, "api_key is required")
 	}
+
+	defaults, isSupported := lookupChain(config.ChainID)
 	if !isSupported {
-		return nil, &InvalidParamError{
-			Message: fmt.Sprintf("chain_id must be one of %v", SupportedChainIDs),
+		problems = append(problems, fmt.Sprintf("chain_id must be one of %v", supportedChainIDs()))
+	}
+
+	// Without a private key and RPC URL the client is read-only and needs no chain settings
+	if !config.isReadOnly() {
+		if config.RPCURL == "" {
+			problems = append(problems, "rpc_url is required")
+		} else if err := chain.ValidateRPCURL(config.RPCURL); err != nil {
+			problems = append(problems, fmt.Sprintf("rpc_url must be an http, https, ws or wss URL with a host, got: %q", config.RPCURL))
+		}
+
+		if config.PrivateKey == "" {
+			problems = append(problems, "private_key is required")
+		} else if _, err := crypto.HexToECDSA(strings.TrimPrefix(config.PrivateKey, "0x")); err != nil {
+			// Never echo the key itself
+			problems = append(problems, "private_key must be a 32-byte hex string")
+		}
+
+		if !common.IsHexAddress(config.MultiSigAddr) {
+			problems = append(problems, fmt.Sprintf("multi_sig_addr must be a hex address, got: %q", config.MultiSigAddr))
+		}
+	}
+
+	optionalAddrs := []struct {
+		name     string
+		value    string
+		fallback string
+	}{
+		{"conditional_tokens_addr", config.ConditionalTokensAddr, defaults.ConditionalTokens},
+		{"multisend_addr", config.MultisendAddr, defaults.Multisend},
+		{"fee_manager_addr", config.FeeManagerAddr, defaults.FeeManager},
+	}
+	for _, addr := range optionalAddrs {
+		if addr.value != "" && !common.IsHexAddress(addr.value) {
+			problems = append(problems, fmt.Sprintf("%s must be a hex address, got: %q", addr.name, addr.value))
+		}
+		// Chains without default contracts need them set explicitly to sign transactions
+		if addr.value == "" && addr.fallback == "" && isSupported && !config.isReadOnly() {
+			problems = append(problems, fmt.Sprintf("%s is required for chain_id %d (no default address)", addr.name, config.ChainID))
+		}
+	}
+
+	if config.PriceTickSize != "" {
+		if _, err := ParseTickSize(config.PriceTickSize); err != nil {
+			problems = append(problems, fmt.Sprintf("price_tick_size: %v", err))
+		}
+	}
+
+	if config.MinOrderAmount != "" {
+		if _, err := ParseMinOrderAmount(config.MinOrderAmount); err != nil {
+			problems = append(problems, fmt.Sprintf("min_order_amount: %v", err))
 		}
 	}
 
+	if config.GasPriceMultiplier < 0 {
+		problems = append(problems, fmt.Sprintf("gas_price_multiplier must not be negative, got: %g", config.GasPriceMultiplier))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return &InvalidParamError{Message: "invalid client config: " + strings.Join(problems, "; ")}
+}
+
+// isReadOnly reports whether the config describes a read-only (API data only) client
+func (config ClientConfig) isReadOnly() bool {
+	return config.PrivateKey == "" && config.RPCURL == ""
+}
+
+// NewClient creates a new Opinion CLOB SDK client
+func NewClient(config ClientConfig) (*Client, error) {
+	return NewClientWithOptions(WithConfig(config))
+}
+
+// newClient creates a client from a complete config
+func newClient(config ClientConfig) (*Client, error) {
+	// Report every config problem before connecting anywhere
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.PrivateKey = strings.TrimPrefix(config.PrivateKey, "0x")
+
 	// Use default contract addresses if not provided
-	contracts := DefaultContractAddresses[config.ChainID]
+	contracts, _ := lookupChain(config.ChainID)
 	if config.ConditionalTokensAddr == "" {
 		config.ConditionalTokensAddr = contracts.ConditionalTokens
 	}
@@ -83,61 +226,280 @@ func NewClient(config ClientConfig) (*Client, error) {
 	if config.MarketCacheTTL == 0 {
 		config.MarketCacheTTL = 5 * time.Minute
 	}
+	if config.MarketCacheMaxEntries <= 0 {
+		config.MarketCacheMaxEntries = 1000
+	}
+	if config.FeeRatesCacheTTL == 0 {
+		config.FeeRatesCacheTTL = 5 * time.Minute
+	}
 	if config.EnableTradingCheckInterval == 0 {
 		config.EnableTradingCheckInterval = 1 * time.Hour
 	}
+	if config.OrderParamsProvider == nil {
+		config.OrderParamsProvider = chain.LocalOrderParamsProvider{}
+	}
+	if config.Clock == nil {
+		config.Clock = time.Now
+	}
+	if config.SubmittedOrdersCacheSize <= 0 {
+		config.SubmittedOrdersCacheSize = 1000
+	}
+	if config.GasPriceMultiplier == 0 {
+		config.GasPriceMultiplier = 1.0
+	}
+	if config.PriceTickSize == "" {
+		config.PriceTickSize = DefaultPriceTickSize
+	}
+	if config.MinOrderAmount == "" {
+		config.MinOrderAmount = DefaultMinOrderAmount
+	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if config.CancelConcurrency <= 0 {
+		config.CancelConcurrency = DefaultCancelConcurrency
+	}
+	if config.OrderConcurrency <= 0 {
+		config.OrderConcurrency = 1
+	}
+	pausedCacheTTL := exchangePausedCacheTTL
+	if config.DisableCache {
+		// A zero TTL makes every cache lookup miss and every store a no-op
+		config.QuoteTokensCacheTTL = 0
+		config.MarketCacheTTL = 0
+		config.FeeRatesCacheTTL = 0
+		pausedCacheTTL = 0
+	}
+	priceTickSize, err := ParseTickSize(config.PriceTickSize)
+	if err != nil {
+		return nil, err
+	}
+	minOrderAmount, err := ParseMinOrderAmount(config.MinOrderAmount)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create API client
 	apiClient := NewAPIClient(config.Host, config.APIKey, config.ChainID)
-
-	// Create contract caller
-	contractCaller, err := chain.NewContractCaller(
-		config.RPCURL,
-		config.PrivateKey,
-		config.MultiSigAddr,
-		config.ConditionalTokensAddr,
-		config.MultisendAddr,
-		config.FeeManagerAddr,
-		config.EnableTradingCheckInterval,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create contract caller: %w", err)
+	if config.HTTPClient != nil {
+		apiClient.client = config.HTTPClient
+	}
+	if len(config.Headers) > 0 {
+		apiClient.SetHeaders(config.Headers, config.OverrideStandardHeaders)
+	}
+	logger := loggerOrNop(config.Logger)
+	apiClient.SetLogger(logger)
+	apiClient.SetMetrics(config.Metrics)
+
+	// Create contract caller (read-only clients have none)
+	var contractCaller *chain.ContractCaller
+	if !config.isReadOnly() {
+		var err error
+		contractCaller, err = chain.NewContractCaller(
+			config.RPCURL,
+			config.PrivateKey,
+			config.MultiSigAddr,
+			config.ConditionalTokensAddr,
+			config.MultisendAddr,
+			config.FeeManagerAddr,
+			int64(config.ChainID),
+			config.EnableTradingCheckInterval,
+			config.GasPriceMultiplier,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create contract caller: %w", err)
+		}
+		contractCaller.SetLogger(logger)
+		contractCaller.SetMetrics(config.Metrics)
 	}
 
-	return &Client{
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &Client{
 		apiClient:           apiClient,
 		contractCaller:      contractCaller,
 		chainID:             config.ChainID,
 		quoteTokensCacheTTL: config.QuoteTokensCacheTTL,
-		marketCacheTTL:      config.MarketCacheTTL,
-		marketCache:         make(map[int]cacheEntry),
-	}, nil
+		marketCache:         newMarketCache(config.MarketCacheMaxEntries, config.MarketCacheTTL),
+		pausedCache:         make(map[string]cacheEntry),
+		pausedCacheTTL:      pausedCacheTTL,
+		verifyExchangeCode:  config.VerifyExchangeCode,
+		autoInvalidate:      !config.DisableCacheInvalidation,
+		validateTokenID:     !config.DisableTokenValidation,
+		checkCutoff:         !config.DisableCutoffCheck,
+		marketOrderPrice:    config.MarketOrderPrice,
+		timestampPrecision:  config.OrderTimestampPrecision,
+		verifiedExchanges:   make(map[string]bool),
+		feeRateCache:        make(map[string]cacheEntry),
+		feeRatesCacheTTL:    config.FeeRatesCacheTTL,
+		orderParams:         config.OrderParamsProvider,
+		clock:               config.Clock,
+		priceTick:           priceTickSize,
+		minOrderAmount:      minOrderAmount,
+		tickMode:            config.PriceTickMode,
+		submittedOrders:     newSubmittedOrderCache(config.SubmittedOrdersCacheSize),
+		logger:              logger,
+		ctx:                 ctx,
+		cancel:              cancel,
+		shutdownTimeout:     config.ShutdownTimeout,
+		cancelWorkers:       config.CancelConcurrency,
+		orderWorkers:        config.OrderConcurrency,
+	}
+
+	if config.VerifyAuthAddress {
+		if err := client.CheckAuthAddress(); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// IsReadOnly reports whether the client was created without a private key and RPC URL.
+// A read-only client serves API data but cannot sign orders or send transactions.
+func (c *Client) IsReadOnly() bool {
+	return c.contractCaller == nil
+}
+
+// requireSigner returns ErrReadOnly for clients that cannot sign or reach the chain
+func (c *Client) requireSigner() error {
+	if c.IsReadOnly() {
+		return ErrReadOnly
+	}
+	return nil
 }
 
-// Close closes the client and cleans up resources
+// DefaultShutdownTimeout is how long Close waits for in-flight work by default
+const DefaultShutdownTimeout = 5 * time.Second
+
+// Close shuts the client down in order: it stops background watchers and streams,
+// closes WebSocket clients created with NewWSClient (waiting for their callbacks to
+// return), waits for in-flight API requests and background goroutines, aborts whatever
+// is left, and finally closes the RPC connection. All waiting is bounded by
+// ShutdownTimeout. Calls after the first do nothing.
 func (c *Client) Close() {
+	c.closeMu.Lock()
+	if c.closed {
+		c.closeMu.Unlock()
+		return
+	}
+	c.closed = true
+	wsClients := c.wsClients
+	c.wsClients = nil
+	c.closeMu.Unlock()
+
+	deadline := time.Now().Add(c.shutdownTimeout)
+	c.cancel()
+
+	// WebSocket clients go first so their callbacks stop before the rest shuts down
+	wsCtx, cancelWS := context.WithDeadline(context.Background(), deadline)
+	for _, ws := range wsClients {
+		if err := ws.Close(wsCtx); err != nil {
+			c.logger.Warn("websocket close failed", "error", err)
+		}
+	}
+	cancelWS()
+
+	if !c.apiClient.Close(time.Until(deadline)) {
+		c.logger.Warn("aborted in-flight api requests on close")
+	}
+	if !waitTimeout(&c.background, time.Until(deadline)) {
+		c.logger.Warn("background work still running on close")
+	}
+
 	if c.contractCaller != nil {
 		c.contractCaller.Close()
 	}
 }
 
+// NewWSClient creates a WebSocket client that Close disconnects. The client's chain ID,
+// API key, logger and metrics are used where config leaves them unset.
+func (c *Client) NewWSClient(config WSConfig) (*WSClient, error) {
+	if config.ChainID == 0 {
+		config.ChainID = c.chainID
+	}
+	if config.APIKey == "" {
+		config.APIKey = c.apiClient.apiKey
+	}
+	if config.Logger == nil {
+		config.Logger = c.logger
+	}
+	if config.Metrics == nil {
+		config.Metrics = c.apiClient.metrics
+	}
+	ws := NewWSClient(config)
+
+	c.closeMu.Lock()
+	defer c.closeMu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+	c.wsClients = append(c.wsClients, ws)
+	return ws, nil
+}
+
+// goBackground runs fn in a goroutine that Close waits for, with a context that is
+// cancelled when either ctx is done or the client is closed
+func (c *Client) goBackground(ctx context.Context, fn func(ctx context.Context)) error {
+	c.closeMu.Lock()
+	defer c.closeMu.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+
+	ctx, cancel := c.bindContext(ctx)
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+		defer cancel()
+		fn(ctx)
+	}()
+	return nil
+}
+
+// bindContext derives a context from ctx that is also cancelled when the client is closed
+func (c *Client) bindContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// waitTimeout waits for wg for at most timeout and reports whether it finished
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
 // EnableTrading enables trading by approving necessary tokens
 func (c *Client) EnableTrading(ctx context.Context) (*TransactionResult, error) {
-	quoteTokenListResponse, err := c.GetQuoteTokens(true)
-	if err != nil {
+	if err := c.requireSigner(); err != nil {
 		return nil, err
 	}
 
-	// Parse quote token response to extract quote_token_address -> ctf_exchange_address mapping
-	supportedQuoteTokens := make(map[string]string)
-
-	for _, quoteToken := range quoteTokenListResponse.Result.List {
-		quoteTokenAddress := common.HexToAddress(quoteToken.QuoteTokenAddress).Hex()
-		ctfExchangeAddress := common.HexToAddress(quoteToken.CTFExchangeAddress).Hex()
-		supportedQuoteTokens[quoteTokenAddress] = ctfExchangeAddress
+	registry, err := c.GetQuoteTokenRegistry(true)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Supported quote tokens: %v\n", supportedQuoteTokens)
+	// quote_token_address -> ctf_exchange_address mapping
+	supportedQuoteTokens := registry.ExchangeMapping()
+
+	c.logger.Debug("supported quote tokens", "quoteTokens", supportedQuoteTokens)
 
 	if len(supportedQuoteTokens) == 0 {
 		return nil, &OpenAPIError{Message: "No supported quote tokens found"}
@@ -164,8 +526,123 @@ func (c *Client) EnableTrading(ctx context.Context) (*TransactionResult, error)
 	}, nil
 }
 
+// GetTradingStatus reports, for each supported quote token, whether the multisig has
+// granted the ERC20 allowances and ERC1155 approval needed to trade it
+func (c *Client) GetTradingStatus(ctx context.Context) ([]TradingStatus, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
+	registry, err := c.GetQuoteTokenRegistry(true)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]TradingStatus, 0, registry.Len())
+	for quoteToken, exchange := range registry.ExchangeMapping() {
+		approval, err := c.contractCaller.GetApprovalStatus(ctx, common.HexToAddress(quoteToken), common.HexToAddress(exchange))
+		if err != nil {
+			return nil, err
+		}
+
+		status := TradingStatus{
+			QuoteToken:      quoteToken,
+			Exchange:        exchange,
+			ERC20Approved:   approval.ExchangeAllowanceOK && approval.ConditionalTokensAllowanceOK,
+			ERC1155Approved: approval.ApprovedForAll,
+		}
+		if qt, ok := registry.Get(quoteToken); ok {
+			status.Symbol = qt.Symbol
+		}
+		statuses = append(statuses, status)
+	}
+
+	// Map iteration order is random; keep the result stable
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].QuoteToken < statuses[j].QuoteToken
+	})
+
+	return statuses, nil
+}
+
+// exchangePausedCacheTTL is how long an exchange's paused state is cached unless caching is disabled
+const exchangePausedCacheTTL = 30 * time.Second
+
+// IsTradingPaused reports whether a CTF exchange contract is paused. The state is cached briefly.
+func (c *Client) IsTradingPaused(ctx context.Context, exchangeAddr string) (bool, error) {
+	if err := c.requireSigner(); err != nil {
+		return false, err
+	}
+	if !common.IsHexAddress(exchangeAddr) {
+		return false, &InvalidParamError{Message: fmt.Sprintf("invalid exchange address: %s", exchangeAddr)}
+	}
+	key := strings.ToLower(exchangeAddr)
+
+	c.cacheMutex.RLock()
+	entry, ok := c.pausedCache[key]
+	c.cacheMutex.RUnlock()
+	if ok && time.Since(entry.timestamp) < c.pausedCacheTTL {
+		if paused, ok := entry.data.(bool); ok {
+			return paused, nil
+		}
+	}
+
+	paused, err := c.contractCaller.IsExchangePaused(ctx, common.HexToAddress(exchangeAddr))
+	if err != nil {
+		return false, err
+	}
+
+	if c.pausedCacheTTL > 0 {
+		c.cacheMutex.Lock()
+		c.pausedCache[key] = cacheEntry{
+			data:      paused,
+			timestamp: time.Now(),
+		}
+		c.cacheMutex.Unlock()
+	}
+
+	return paused, nil
+}
+
+// VerifyExchangeAddress checks on chain that exchangeAddr is a deployed contract and returns
+// ErrExchangeNotContract if it has no code. Verified addresses are remembered.
+func (c *Client) VerifyExchangeAddress(ctx context.Context, exchangeAddr string) error {
+	if err := c.requireSigner(); err != nil {
+		return err
+	}
+	if !common.IsHexAddress(exchangeAddr) {
+		return &InvalidParamError{Message: fmt.Sprintf("invalid exchange address: %s", exchangeAddr)}
+	}
+	key := strings.ToLower(exchangeAddr)
+
+	c.cacheMutex.RLock()
+	verified := c.verifiedExchanges[key]
+	c.cacheMutex.RUnlock()
+	if verified {
+		return nil
+	}
+
+	hasCode, err := c.contractCaller.HasCode(ctx, common.HexToAddress(exchangeAddr))
+	if err != nil {
+		return err
+	}
+	if !hasCode {
+		return fmt.Errorf("%w: %s", ErrExchangeNotContract, exchangeAddr)
+	}
+
+	c.cacheMutex.Lock()
+	c.verifiedExchanges[key] = true
+	c.cacheMutex.Unlock()
+
+	return nil
+}
+
 // Split splits collateral into outcome tokens
 func (c *Client) Split(ctx context.Context, marketID int, amount *big.Int, checkApproval bool) (*TransactionResult, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
 	if marketID <= 0 {
 		return nil, &InvalidParamError{Message: "market_id must be a positive integer"}
 	}
@@ -201,7 +678,7 @@ func (c *Client) Split(ctx context.Context, marketID int, amount *big.Int, check
 
 	// Extract collateral (quote_token) and condition_id from market data
 	collateral := common.HexToAddress(market.QuoteToken)
-	conditionID, err := hex.DecodeString(market.ConditionID)
+	conditionID, err := decodeHexBytes(market.ConditionID)
 	if err != nil {
 		return nil, &OpenAPIError{Message: fmt.Sprintf("invalid condition_id: %s", market.ConditionID)}
 	}
@@ -210,6 +687,7 @@ func (c *Client) Split(ctx context.Context, marketID int, amount *big.Int, check
 	if err != nil {
 		return nil, &OpenAPIError{Message: fmt.Sprintf("Failed to split collateral: %v", err)}
 	}
+	c.invalidateAfterTrade(marketID)
 
 	return &TransactionResult{
 		TxHash:      tx.Hash().Hex(),
@@ -220,6 +698,10 @@ func (c *Client) Split(ctx context.Context, marketID int, amount *big.Int, check
 
 // Merge merges outcome tokens back into collateral
 func (c *Client) Merge(ctx context.Context, marketID int, amount *big.Int, checkApproval bool) (*TransactionResult, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
 	if marketID <= 0 {
 		return nil, &InvalidParamError{Message: "market_id must be a positive integer"}
 	}
@@ -255,7 +737,7 @@ func (c *Client) Merge(ctx context.Context, marketID int, amount *big.Int, check
 
 	// Extract collateral (quote_token) and condition_id from market data
 	collateral := common.HexToAddress(market.QuoteToken)
-	conditionID, err := hex.DecodeString(market.ConditionID)
+	conditionID, err := decodeHexBytes(market.ConditionID)
 	if err != nil {
 		return nil, &OpenAPIError{Message: fmt.Sprintf("invalid condition_id: %s", market.ConditionID)}
 	}
@@ -264,6 +746,7 @@ func (c *Client) Merge(ctx context.Context, marketID int, amount *big.Int, check
 	if err != nil {
 		return nil, &OpenAPIError{Message: fmt.Sprintf("Failed to merge tokens: %v", err)}
 	}
+	c.invalidateAfterTrade(marketID)
 
 	return &TransactionResult{
 		TxHash:      tx.Hash().Hex(),
@@ -274,6 +757,10 @@ func (c *Client) Merge(ctx context.Context, marketID int, amount *big.Int, check
 
 // Redeem redeems winning outcome tokens for collateral
 func (c *Client) Redeem(ctx context.Context, marketID int, checkApproval bool) (*TransactionResult, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
 	if marketID <= 0 {
 		return nil, &InvalidParamError{Message: "market_id must be a positive integer"}
 	}
@@ -284,74 +771,163 @@ func (c *Client) Redeem(ctx context.Context, marketID int, checkApproval bool) (
 		}
 	}
 
+	collateral, conditionID, err := c.redeemTarget(marketID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.contractCaller.Redeem(ctx, collateral, conditionID)
+	if err != nil {
+		return nil, &OpenAPIError{Message: fmt.Sprintf("Failed to redeem tokens: %v", err)}
+	}
+	c.invalidateAfterTrade(marketID)
+
+	return &TransactionResult{
+		TxHash:      tx.Hash().Hex(),
+		SafeTxHash:  "",
+		ReturnValue: "",
+	}, nil
+}
+
+// RedeemBatch redeems several resolved markets in one multisend transaction. All markets
+// must share the same collateral token; markets without positions are skipped.
+func (c *Client) RedeemBatch(ctx context.Context, marketIDs []int, checkApproval bool) (*TransactionResult, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
+	if len(marketIDs) == 0 {
+		return nil, &InvalidParamError{Message: "marketIDs list cannot be empty"}
+	}
+
+	if checkApproval {
+		if _, err := c.EnableTrading(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var collateral common.Address
+	conditionIDs := make([][]byte, 0, len(marketIDs))
+	for i, marketID := range marketIDs {
+		marketCollateral, conditionID, err := c.redeemTarget(marketID)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && marketCollateral != collateral {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("market %d uses collateral %s, expected %s", marketID, marketCollateral.Hex(), collateral.Hex())}
+		}
+		collateral = marketCollateral
+		conditionIDs = append(conditionIDs, conditionID)
+	}
+
+	tx, err := c.contractCaller.RedeemBatch(ctx, collateral, conditionIDs)
+	if err != nil {
+		return nil, &OpenAPIError{Message: fmt.Sprintf("Failed to redeem tokens: %v", err)}
+	}
+	c.invalidateAfterTrade(marketIDs...)
+
+	return &TransactionResult{
+		TxHash:      tx.Hash().Hex(),
+		SafeTxHash:  "",
+		ReturnValue: "",
+	}, nil
+}
+
+// redeemTarget validates that a market can be redeemed and returns its collateral and condition ID
+func (c *Client) redeemTarget(marketID int) (common.Address, []byte, error) {
+	if marketID <= 0 {
+		return common.Address{}, nil, &InvalidParamError{Message: "market_id must be a positive integer"}
+	}
+
 	market, err := c.GetMarket(marketID, true)
 	if err != nil {
-		return nil, &OpenAPIError{Message: fmt.Sprintf("get market for redeem: %v", err)}
+		return common.Address{}, nil, &OpenAPIError{Message: fmt.Sprintf("get market for redeem: %v", err)}
 	}
 
 	// Validate chain_id matches
 	marketChainID, err := strconv.Atoi(market.ChainID)
 	if err != nil {
-		return nil, &OpenAPIError{Message: fmt.Sprintf("invalid market chain_id: %s", market.ChainID)}
+		return common.Address{}, nil, &OpenAPIError{Message: fmt.Sprintf("invalid market chain_id: %s", market.ChainID)}
 	}
 	if ChainID(marketChainID) != c.chainID {
-		return nil, &OpenAPIError{Message: "Cannot redeem on different chain"}
+		return common.Address{}, nil, &OpenAPIError{Message: "Cannot redeem on different chain"}
 	}
 
 	// Validate market status (must be RESOLVED for redemption)
 	status := TopicStatus(market.Status)
 	if status != TopicStatusResolved {
-		return nil, &OpenAPIError{Message: "Cannot redeem on non-resolved market"}
+		return common.Address{}, nil, &OpenAPIError{Message: "Cannot redeem on non-resolved market"}
 	}
 
 	// Extract collateral (quote_token) and condition_id from market data
 	collateral := common.HexToAddress(market.QuoteToken)
-	conditionID, err := hex.DecodeString(market.ConditionID)
+	conditionID, err := decodeHexBytes(market.ConditionID)
 	if err != nil {
-		return nil, &OpenAPIError{Message: fmt.Sprintf("invalid condition_id: %s", market.ConditionID)}
+		return common.Address{}, nil, &OpenAPIError{Message: fmt.Sprintf("invalid condition_id: %s", market.ConditionID)}
 	}
 
-	tx, err := c.contractCaller.Redeem(ctx, collateral, conditionID)
+	return collateral, conditionID, nil
+}
+
+// GetQuoteTokens fetches the list of supported quote tokens
+func (c *Client) GetQuoteTokens(useCache bool) (*GetQuoteTokensResponse, error) {
+	if useCache {
+		if cached, ok := c.cachedQuoteTokens(); ok {
+			return cached, nil
+		}
+	}
+
+	// Concurrent misses share one request
+	v, err, _ := c.fetchGroup.Do("quoteTokens", func() (interface{}, error) {
+		result, err := c.apiClient.GetQuoteTokens()
+		if err != nil {
+			return nil, err
+		}
+
+		c.cacheMutex.Lock()
+		if c.quoteTokensCacheTTL > 0 {
+			c.quoteTokensCache = result
+			c.quoteTokensCacheTime = time.Now()
+		}
+		c.cacheMutex.Unlock()
+
+		return result, nil
+	})
 	if err != nil {
-		return nil, &OpenAPIError{Message: fmt.Sprintf("Failed to redeem tokens: %v", err)}
+		return nil, err
 	}
 
-	return &TransactionResult{
-		TxHash:      tx.Hash().Hex(),
-		SafeTxHash:  "",
-		ReturnValue: "",
-	}, nil
+	return v.(*GetQuoteTokensResponse), nil
 }
 
-// GetQuoteTokens fetches the list of supported quote tokens
-func (c *Client) GetQuoteTokens(useCache bool) (*GetQuoteTokensResponse, error) {
+// cachedQuoteTokens returns the cached quote token list if it is still fresh
+func (c *Client) cachedQuoteTokens() (*GetQuoteTokensResponse, bool) {
 	c.cacheMutex.RLock()
-	if useCache && c.quoteTokensCacheTTL > 0 {
-		if c.quoteTokensCache != nil {
-			cacheAge := time.Since(c.quoteTokensCacheTime)
-			if cacheAge < c.quoteTokensCacheTTL {
-				c.cacheMutex.RUnlock()
-				if cached, ok := c.quoteTokensCache.(*GetQuoteTokensResponse); ok {
-					return cached, nil
-				}
-			}
-		}
+	defer c.cacheMutex.RUnlock()
+
+	if c.quoteTokensCache == nil || time.Since(c.quoteTokensCacheTime) >= c.quoteTokensCacheTTL {
+		return nil, false
 	}
-	c.cacheMutex.RUnlock()
+	return c.quoteTokensCache, true
+}
 
-	result, err := c.apiClient.GetQuoteTokens()
+// GetQuoteTokenRegistry returns a registry of the supported quote tokens.
+// The registry is rebuilt only when the underlying quote token list changes.
+func (c *Client) GetQuoteTokenRegistry(useCache bool) (*QuoteTokenRegistry, error) {
+	quoteTokens, err := c.GetQuoteTokens(useCache)
 	if err != nil {
 		return nil, err
 	}
 
 	c.cacheMutex.Lock()
-	if c.quoteTokensCacheTTL > 0 {
-		c.quoteTokensCache = result
-		c.quoteTokensCacheTime = time.Now()
+	defer c.cacheMutex.Unlock()
+
+	if c.quoteTokenRegistry == nil || c.registrySource != quoteTokens {
+		c.quoteTokenRegistry = NewQuoteTokenRegistry(quoteTokens.Result.List)
+		c.registrySource = quoteTokens
 	}
-	c.cacheMutex.Unlock()
 
-	return result, nil
+	return c.quoteTokenRegistry, nil
 }
 
 // GetMarkets fetches markets with pagination and filters
@@ -372,46 +948,42 @@ func (c *Client) GetMarket(marketID int, useCache bool) (*Market, error) {
 		return nil, &InvalidParamError{Message: "market_id is required"}
 	}
 
-	c.cacheMutex.RLock()
-	if useCache && c.marketCacheTTL > 0 {
-		if entry, ok := c.marketCache[marketID]; ok {
-			cacheAge := time.Since(entry.timestamp)
-			if cacheAge < c.marketCacheTTL {
-				c.cacheMutex.RUnlock()
-				if market, ok := entry.data.(*Market); ok {
-					return market, nil
-				}
-			}
+	if useCache {
+		if market, ok := c.marketCache.get(marketID); ok {
+			return market, nil
 		}
 	}
-	c.cacheMutex.RUnlock()
 
-	result, err := c.apiClient.GetMarket(marketID)
+	// Concurrent misses for the same market share one request
+	v, err, _ := c.fetchGroup.Do("market:"+strconv.Itoa(marketID), func() (interface{}, error) {
+		result, err := c.apiClient.GetMarket(marketID)
+		if err != nil {
+			return nil, err
+		}
+
+		market := &result.Result.Data
+		c.marketCache.add(marketID, market)
+
+		return market, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	market := &result.Result.Data
-
-	c.cacheMutex.Lock()
-	if c.marketCacheTTL > 0 {
-		c.marketCache[marketID] = cacheEntry{
-			data:      market,
-			timestamp: time.Now(),
-		}
-	}
-	c.cacheMutex.Unlock()
-
-	return market, nil
-}
+	return v.(*Market), nil
+}
 
 // GetCategoricalMarket fetches detailed information about a categorical market
-func (c *Client) GetCategoricalMarket(marketID int) (interface{}, error) {
+func (c *Client) GetCategoricalMarket(marketID int) (*CategoricalMarket, error) {
 	if marketID <= 0 {
 		return nil, &InvalidParamError{Message: "market_id is required"}
 	}
 
-	return c.apiClient.GetCategoricalMarket(marketID)
+	result, err := c.apiClient.GetCategoricalMarket(marketID)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Result.Data, nil
 }
 
 // GetPriceHistory fetches price history for a token
@@ -427,16 +999,21 @@ func (c *Client) GetPriceHistory(tokenID string, interval string, startAt, endAt
 }
 
 // GetOrderbook fetches the orderbook for a token
-func (c *Client) GetOrderbook(tokenID string) (interface{}, error) {
+func (c *Client) GetOrderbook(tokenID string) (*OrderBook, error) {
 	if tokenID == "" {
 		return nil, &InvalidParamError{Message: "token_id is required"}
 	}
 
-	return c.apiClient.GetOrderbook(tokenID)
+	result, err := c.apiClient.GetOrderbook(tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result.Result, nil
 }
 
 // GetLatestPrice fetches the latest price for a token
-func (c *Client) GetLatestPrice(tokenID string) (interface{}, error) {
+func (c *Client) GetLatestPrice(tokenID string) (*GetLatestPriceResponse, error) {
 	if tokenID == "" {
 		return nil, &InvalidParamError{Message: "token_id is required"}
 	}
@@ -444,13 +1021,37 @@ func (c *Client) GetLatestPrice(tokenID string) (interface{}, error) {
 	return c.apiClient.GetLatestPrice(tokenID)
 }
 
-// GetFeeRates fetches fee rates from FeeManager contract
+// GetFeeRates fetches fee rates from FeeManager contract. Results are cached per token
+// for FeeRatesCacheTTL; ctx bounds the contract call on a cache miss.
 func (c *Client) GetFeeRates(ctx context.Context, tokenID int) (*FeeRateSettings, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
 	if tokenID <= 0 {
 		return nil, &InvalidParamError{Message: "token_id is required"}
 	}
 
-	result, err := c.contractCaller.GetFeeRateSettings(ctx, big.NewInt(int64(tokenID)))
+	return c.feeRateSettings(ctx, strconv.Itoa(tokenID))
+}
+
+// feeRateSettings fetches the fee rates of a token, cached for feeRatesCacheTTL
+func (c *Client) feeRateSettings(ctx context.Context, tokenID string) (*FeeRateSettings, error) {
+	c.cacheMutex.RLock()
+	entry, ok := c.feeRateCache[tokenID]
+	c.cacheMutex.RUnlock()
+	if ok && time.Since(entry.timestamp) < c.feeRatesCacheTTL {
+		if settings, ok := entry.data.(*FeeRateSettings); ok {
+			return settings, nil
+		}
+	}
+
+	id, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid token_id: %s", tokenID)}
+	}
+
+	result, err := c.contractCaller.GetFeeRateSettings(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -459,16 +1060,54 @@ func (c *Client) GetFeeRates(ctx context.Context, tokenID int) (*FeeRateSettings
 		return nil, fmt.Errorf("fee rate settings not available")
 	}
 
-	// Convert chain.FeeRateSettings to FeeRateSettings
-	return &FeeRateSettings{
-		MakerMaxFeeRate: result.MakerMaxFeeRate,
-		TakerMaxFeeRate: result.TakerMaxFeeRate,
-		Enabled:         result.Enabled,
-	}, nil
+	if c.feeRatesCacheTTL > 0 {
+		c.cacheMutex.Lock()
+		c.feeRateCache[tokenID] = cacheEntry{
+			data:      result,
+			timestamp: time.Now(),
+		}
+		c.cacheMutex.Unlock()
+	}
+
+	return result, nil
+}
+
+// ClearFeeCache drops all cached fee rates so the next GetFeeRates calls read the contract
+func (c *Client) ClearFeeCache() {
+	c.cacheMutex.Lock()
+	c.feeRateCache = make(map[string]cacheEntry)
+	c.cacheMutex.Unlock()
+}
+
+// orderFeeRateBps returns the fee rate to sign into an order: the explicit override if set,
+// otherwise the FeeManager taker rate for market orders and maker rate for limit orders
+func (c *Client) orderFeeRateBps(ctx context.Context, data PlaceOrderDataInput) (string, error) {
+	if data.FeeRateBps != nil {
+		bps, ok := new(big.Int).SetString(*data.FeeRateBps, 10)
+		if !ok || bps.Sign() < 0 {
+			return "", &InvalidParamError{Message: fmt.Sprintf("feeRateBps must be a non-negative integer, got: %q", *data.FeeRateBps)}
+		}
+		return bps.String(), nil
+	}
+
+	settings, err := c.feeRateSettings(ctx, data.TokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get fee rates: %w", err)
+	}
+
+	bps := settings.MakerFeeRateBps
+	if data.OrderType == OrderTypeMarket {
+		bps = settings.TakerFeeRateBps
+	}
+	if !settings.Enabled || bps == nil {
+		return "0", nil
+	}
+
+	return bps.String(), nil
 }
 
 // PlaceOrder places an order on the market
-func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, checkApproval bool) (interface{}, error) {
+func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, checkApproval bool) (*PlaceOrderResponse, error) {
 	// Enable trading first if requested
 	if checkApproval {
 		if _, err := c.EnableTrading(ctx); err != nil {
@@ -476,8 +1115,104 @@ func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, check
 		}
 	}
 
+	order, err := c.buildOrderRequest(ctx, data, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.apiClient.PlaceOrderContext(ctx, order.request)
+	if err != nil {
+		c.logger.Warn("order rejected", "marketId", data.MarketID, "tokenId", data.TokenID, "side", data.Side, "clientOrderId", order.clientOrderID, "error", err)
+		return nil, err
+	}
+	result.ClientOrderID = order.clientOrderID
+	c.invalidateAfterTrade(data.MarketID)
+	c.logger.Info("order placed", "orderId", result.Result.OrderData.OrderID, "marketId", data.MarketID, "clientOrderId", order.clientOrderID)
+
+	// Keep the exact payload for audit; see GetSubmittedOrder
+	if orderID := result.Result.OrderData.OrderID; orderID != "" {
+		c.submittedOrders.add(&SubmittedOrder{
+			OrderID:     orderID,
+			SignedOrder: *order.signed,
+			Request:     order.request,
+			SubmittedAt: c.clock(),
+		})
+	}
+
+	return result, nil
+}
+
+// PlaceOrderVerified places an order and reads it back by ID to confirm the gateway stored it.
+// It returns ErrOrderNotAccepted if the order cannot be found or is already cancelled, expired or failed.
+func (c *Client) PlaceOrderVerified(ctx context.Context, data PlaceOrderDataInput, checkApproval bool) (*OrderRecord, error) {
+	result, err := c.PlaceOrder(ctx, data, checkApproval)
+	if err != nil {
+		return nil, err
+	}
+
+	orderID := result.Result.OrderData.OrderID
+	if orderID == "" {
+		return nil, fmt.Errorf("%w: no order id in response", ErrOrderNotAccepted)
+	}
+
+	order, err := c.GetOrderByID(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: order %s could not be read back: %v", ErrOrderNotAccepted, orderID, err)
+	}
+
+	// A filled order was accepted too; anything else final was dropped
+	if order.Status != OrderStatusPending && order.Status != OrderStatusFilled {
+		return order, fmt.Errorf("%w: order %s has status %d", ErrOrderNotAccepted, orderID, order.Status)
+	}
+
+	return order, nil
+}
+
+// BuildSignedOrder runs all of PlaceOrder's validation, amount math, fee lookup and
+// signing and returns the signed order with the request payload PlaceOrder would POST,
+// without sending it. Unlike PlaceOrder it does not check whether the exchange is paused.
+func (c *Client) BuildSignedOrder(ctx context.Context, data PlaceOrderDataInput) (*SignedOrder, map[string]interface{}, error) {
+	order, err := c.buildOrderRequest(ctx, data, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return order.signed, order.request, nil
+}
+
+// PlaceOrderDryRun builds and signs an order exactly as PlaceOrder would and returns
+// the JSON request body without sending it. With a fixed OrderParamsProvider and
+// Clock the output is deterministic.
+func (c *Client) PlaceOrderDryRun(data PlaceOrderDataInput) ([]byte, error) {
+	_, request, err := c.BuildSignedOrder(context.Background(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal order request: %w", err)
+	}
+
+	return body, nil
+}
+
+// builtOrder is a signed order together with the API request body that submits it
+type builtOrder struct {
+	request       map[string]interface{}
+	signed        *chain.SignedOrder
+	clientOrderID string
+}
+
+// buildOrderRequest validates an order, signs it and builds the API request body. With
+// checkPaused it returns ErrExchangePaused before fetching fee rates or signing if the
+// market's exchange is paused.
+func (c *Client) buildOrderRequest(ctx context.Context, data PlaceOrderDataInput, checkPaused bool) (*builtOrder, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
 	// Get quote tokens
-	quoteTokenListResponse, err := c.GetQuoteTokens(true)
+	registry, err := c.GetQuoteTokenRegistry(true)
 	if err != nil {
 		return nil, &OpenAPIError{Message: fmt.Sprintf("failed to get quote tokens: %v", err)}
 	}
@@ -497,152 +1232,107 @@ func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, check
 		return nil, &OpenAPIError{Message: "Cannot place order on different chain"}
 	}
 
-	// Find matching quote token
-	quoteTokenAddr := market.QuoteToken
-	var matchedQuoteToken *QuoteToken
-	for i := range quoteTokenListResponse.Result.List {
-		qt := &quoteTokenListResponse.Result.List[i]
-		if strings.EqualFold(qt.QuoteTokenAddress, quoteTokenAddr) {
-			matchedQuoteToken = qt
-			break
+	if c.validateTokenID {
+		if err := validateOrderTokenID(market, data.TokenID); err != nil {
+			return nil, err
+		}
+	}
+
+	// CutoffAt is in Unix seconds; 0 means no cutoff
+	if c.checkCutoff && market.CutoffAt > 0 {
+		cutoff := time.Unix(market.CutoffAt, 0)
+		if !c.clock().Before(cutoff) {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("market %d stopped trading at %s", market.MarketID, cutoff.UTC().Format(time.RFC3339))}
 		}
 	}
-	if matchedQuoteToken == nil {
+
+	// Find matching quote token
+	quoteTokenAddr := market.QuoteToken
+	matchedQuoteToken, ok := registry.Get(quoteTokenAddr)
+	if !ok {
 		return nil, &OpenAPIError{Message: "Quote token not found for this market"}
 	}
 
 	exchangeAddr := matchedQuoteToken.CTFExchangeAddress
 	currencyDecimal := matchedQuoteToken.Decimal
 
-	// Validate based on order type and side
-	// Reject if market buy and makerAmountInBaseToken is provided
-	if data.Side == OrderSideBuy && data.OrderType == OrderTypeMarket && data.MakerAmountInBaseToken != nil {
-		return nil, &InvalidParamError{Message: "makerAmountInBaseToken is not allowed for market buy"}
-	}
-	// Reject if market sell and makerAmountInQuoteToken is provided
-	if data.Side == OrderSideSell && data.OrderType == OrderTypeMarket && data.MakerAmountInQuoteToken != nil {
-		return nil, &InvalidParamError{Message: "makerAmountInQuoteToken is not allowed for market sell"}
+	if c.verifyExchangeCode {
+		if err := c.VerifyExchangeAddress(ctx, exchangeAddr); err != nil {
+			return nil, err
+		}
 	}
 
-	// Validate price for limit orders
-	if data.OrderType == OrderTypeLimit {
-		priceFloat, err := strconv.ParseFloat(data.Price, 64)
-		if err != nil || priceFloat <= 0 {
-			return nil, &InvalidParamError{Message: fmt.Sprintf("Price must be positive for limit orders, got: %s", data.Price)}
+	if checkPaused {
+		paused, err := c.IsTradingPaused(ctx, exchangeAddr)
+		if err != nil {
+			return nil, err
+		}
+		if paused {
+			return nil, ErrExchangePaused
 		}
 	}
 
-	// Calculate makerAmount based on side
-	var makerAmount float64
-	const minimalMakerAmount = 1.0
+	amounts, err := c.computeOrderAmounts(market, data, currencyDecimal)
+	if err != nil {
+		return nil, err
+	}
+	price := amounts.price
+	recalculatedMakerAmount, takerAmount := amounts.makerAmount, amounts.takerAmount
 
-	if data.Side == OrderSideBuy {
-		if data.MakerAmountInBaseToken != nil {
-			// BUY with base token amount: makerAmount = baseAmount * price
-			baseAmount, err := strconv.ParseFloat(*data.MakerAmountInBaseToken, 64)
-			if err != nil {
-				return nil, &InvalidParamError{Message: fmt.Sprintf("invalid makerAmountInBaseToken: %s", *data.MakerAmountInBaseToken)}
-			}
-			if baseAmount < minimalMakerAmount {
-				return nil, &InvalidParamError{Message: "makerAmountInBaseToken must be at least 1"}
-			}
-			priceFloat, _ := strconv.ParseFloat(data.Price, 64)
-			makerAmount = baseAmount * priceFloat
-		} else if data.MakerAmountInQuoteToken != nil {
-			// BUY with quote token amount: use as-is
-			quoteAmount, err := strconv.ParseFloat(*data.MakerAmountInQuoteToken, 64)
-			if err != nil {
-				return nil, &InvalidParamError{Message: fmt.Sprintf("invalid makerAmountInQuoteToken: %s", *data.MakerAmountInQuoteToken)}
-			}
-			if quoteAmount < minimalMakerAmount {
-				return nil, &InvalidParamError{Message: "makerAmountInQuoteToken must be at least 1"}
-			}
-			makerAmount = quoteAmount
-		} else {
-			return nil, &InvalidParamError{Message: "Either makerAmountInBaseToken or makerAmountInQuoteToken must be provided for BUY orders"}
-		}
-	} else { // SELL
-		if data.MakerAmountInBaseToken != nil {
-			// SELL with base token amount: use as-is
-			baseAmount, err := strconv.ParseFloat(*data.MakerAmountInBaseToken, 64)
-			if err != nil {
-				return nil, &InvalidParamError{Message: fmt.Sprintf("invalid makerAmountInBaseToken: %s", *data.MakerAmountInBaseToken)}
-			}
-			if baseAmount < minimalMakerAmount {
-				return nil, &InvalidParamError{Message: "makerAmountInBaseToken must be at least 1"}
-			}
-			makerAmount = baseAmount
-		} else if data.MakerAmountInQuoteToken != nil {
-			// SELL with quote token amount: makerAmount = quoteAmount / price
-			quoteAmount, err := strconv.ParseFloat(*data.MakerAmountInQuoteToken, 64)
-			if err != nil {
-				return nil, &InvalidParamError{Message: fmt.Sprintf("invalid makerAmountInQuoteToken: %s", *data.MakerAmountInQuoteToken)}
-			}
-			if quoteAmount < minimalMakerAmount {
-				return nil, &InvalidParamError{Message: "makerAmountInQuoteToken must be at least 1"}
-			}
-			priceFloat, _ := strconv.ParseFloat(data.Price, 64)
-			if priceFloat == 0 {
-				return nil, &InvalidParamError{Message: "Price cannot be zero for SELL orders with makerAmountInQuoteToken"}
-			}
-			makerAmount = quoteAmount / priceFloat
-		} else {
-			return nil, &InvalidParamError{Message: "Either makerAmountInBaseToken or makerAmountInQuoteToken must be provided for SELL orders"}
-		}
+	maker, signatureType, err := c.resolveOrderMaker(data)
+	if err != nil {
+		return nil, err
 	}
 
-	// Final validation: ensure makerAmount was properly calculated
-	if makerAmount <= 0 {
-		return nil, &InvalidParamError{Message: fmt.Sprintf("Calculated makerAmount must be positive, got: %f", makerAmount)}
+	// safe_rate is sent alongside the order but is not covered by its signature
+	safeRate := "0"
+	if data.SafeRate != nil {
+		rate, err := parseAmount("safeRate", *data.SafeRate)
+		if err != nil {
+			return nil, err
+		}
+		if rate.Sign() < 0 || rate.Cmp(big.NewRat(1, 1)) > 0 {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("safeRate must be between 0 and 1, got: %s", *data.SafeRate)}
+		}
+		safeRate = *data.SafeRate
 	}
 
-	// Handle market orders: set price to 0 and takerAmount to 0
-	price := data.Price
-	if data.OrderType == OrderTypeMarket {
-		price = "0"
+	if data.ClientOrderID != nil {
+		if err := validateClientOrderID(*data.ClientOrderID); err != nil {
+			return nil, err
+		}
 	}
 
-	// Convert makerAmount to wei
-	makerAmountWei, err := SafeAmountToWei(makerAmount, currencyDecimal)
+	feeRateBps, err := c.orderFeeRateBps(ctx, data)
 	if err != nil {
-		return nil, &InvalidParamError{Message: fmt.Sprintf("failed to convert makerAmount to wei: %v", err)}
+		return nil, err
 	}
 
-	// Calculate order amounts for limit orders
-	var recalculatedMakerAmount, takerAmount *big.Int
-	if data.OrderType == OrderTypeLimit {
-		priceFloat, _ := strconv.ParseFloat(price, 64)
-		recalculatedMakerAmount, takerAmount, err = CalculateOrderAmounts(
-			priceFloat,
-			makerAmountWei,
-			data.Side,
-			currencyDecimal,
-		)
-		if err != nil {
-			return nil, err
+	// An empty expiration falls back to the order params provider (good-till-cancelled by default)
+	expiration := ""
+	if data.ExpiresAt != nil {
+		if !data.ExpiresAt.After(c.clock()) {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("expiresAt must be in the future, got: %s", data.ExpiresAt.Format(time.RFC3339))}
 		}
-	} else {
-		recalculatedMakerAmount = makerAmountWei
-		takerAmount = big.NewInt(0)
+		expiration = strconv.FormatInt(data.ExpiresAt.Unix(), 10)
 	}
 
-	// Build order data
+	// Build order data (nonce, and expiration unless set, come from the order params provider)
 	orderData := &chain.OrderData{
-		Maker:         c.contractCaller.GetMultiSigAddress().Hex(),
+		Maker:         maker,
 		Taker:         ZeroAddress,
 		TokenID:       data.TokenID,
 		MakerAmount:   recalculatedMakerAmount.String(),
 		TakerAmount:   takerAmount.String(),
-		FeeRateBps:    "0",
-		Side:          convertOrderSide(data.Side),
-		SignatureType: chain.SignatureTypePolyGnosisSafe,
-		Nonce:         "0",
+		FeeRateBps:    feeRateBps,
+		Side:          data.Side,
+		SignatureType: signatureType,
 		Signer:        c.contractCaller.GetSignerAddress().Hex(),
-		Expiration:    "0",
+		Expiration:    expiration,
 	}
 
 	// Build and sign order
-	orderBuilder, err := chain.NewOrderBuilder(exchangeAddr, int64(c.chainID), c.contractCaller.GetPrivateKey())
+	orderBuilder, err := chain.NewOrderBuilderWithParams(exchangeAddr, int64(c.chainID), c.contractCaller.GetPrivateKey(), c.orderParams)
 	if err != nil {
 		return nil, &OpenAPIError{Message: fmt.Sprintf("failed to create order builder: %v", err)}
 	}
@@ -652,6 +1342,12 @@ func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, check
 		return nil, &OpenAPIError{Message: fmt.Sprintf("failed to build signed order: %v", err)}
 	}
 
+	// The salt is unique per order, so it doubles as the default idempotency key
+	clientOrderID := signedOrder.Order.Salt
+	if data.ClientOrderID != nil {
+		clientOrderID = *data.ClientOrderID
+	}
+
 	// Create order request
 	// Note: contract_address should not be empty string - use exchange address if needed
 	contractAddr := exchangeAddr
@@ -706,9 +1402,10 @@ func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, check
 		"currency_address": quoteTokenAddr,
 		"price":            price,
 		"trading_method":   int(data.OrderType),
-		"timestamp":        time.Now().Unix(),
-		"safe_rate":        "0", // Keep as string "0" to match Python SDK
-		"order_exp_time":   "0", // Keep as string "0" to match Python SDK
+		"timestamp":        c.timestampPrecision.unix(c.clock()),
+		"safe_rate":        safeRate,
+		"order_exp_time":   signedOrder.Order.Expiration, // "0" means good-till-cancelled
+		"client_order_id":  clientOrderID,
 	}
 
 	// Final validation: ensure no empty strings in numeric fields
@@ -724,7 +1421,175 @@ func (c *Client) PlaceOrder(ctx context.Context, data PlaceOrderDataInput, check
 		}
 	}
 
-	return c.apiClient.PlaceOrder(orderReq)
+	// Market orders have no limit price; send it the way the gateway expects
+	if data.OrderType == OrderTypeMarket {
+		switch c.marketOrderPrice {
+		case MarketOrderPriceEmpty:
+			orderReq["price"] = ""
+		case MarketOrderPriceOmit:
+			delete(orderReq, "price")
+		}
+	}
+
+	return &builtOrder{request: orderReq, signed: signedOrder, clientOrderID: clientOrderID}, nil
+}
+
+// maxClientOrderIDLength bounds client order IDs so they fit the gateway's idempotency key
+const maxClientOrderIDLength = 64
+
+// orderAmounts are the price and wei amounts an order is signed and sent with
+type orderAmounts struct {
+	price       string   // request price, snapped to the tick if allowed; "0" for market orders
+	makerAmount *big.Int // wei
+	takerAmount *big.Int // wei; 0 for market orders
+}
+
+// validateOrderTokenID checks that tokenID is one of the market's outcome tokens. Markets
+// that report no token IDs are not checked.
+func validateOrderTokenID(market *Market, tokenID string) error {
+	tokenIDs := market.TokenIDs()
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+	for _, id := range tokenIDs {
+		if id == tokenID {
+			return nil
+		}
+	}
+	return &InvalidParamError{Message: fmt.Sprintf("token_id %s is not an outcome of market %d", tokenID, market.MarketID)}
+}
+
+// computeOrderAmounts validates an order's price and size against its market and
+// converts them to the exact wei amounts the order is signed with
+func (c *Client) computeOrderAmounts(market *Market, data PlaceOrderDataInput, currencyDecimal int) (*orderAmounts, error) {
+	// Validate based on order type and side
+	// Reject if market buy and makerAmountInBaseToken is provided
+	if data.Side == OrderSideBuy && data.OrderType == OrderTypeMarket && data.MakerAmountInBaseToken != nil {
+		return nil, &InvalidParamError{Message: "makerAmountInBaseToken is not allowed for market buy"}
+	}
+	// Reject if market sell and makerAmountInQuoteToken is provided
+	if data.Side == OrderSideSell && data.OrderType == OrderTypeMarket && data.MakerAmountInQuoteToken != nil {
+		return nil, &InvalidParamError{Message: "makerAmountInQuoteToken is not allowed for market sell"}
+	}
+
+	// Parse and validate price for limit orders (market orders ignore the price)
+	var limitPrice *big.Rat
+	if data.OrderType == OrderTypeLimit {
+		var err error
+		limitPrice, err = ParsePrice(data.Price)
+		if err != nil {
+			return nil, err
+		}
+		aligned, err := c.alignPriceToTick(market, limitPrice, data.Side)
+		if err != nil {
+			return nil, err
+		}
+		if aligned.Cmp(limitPrice) != 0 {
+			// Send the snapped price instead of the requested one
+			data.Price = aligned.FloatString(3)
+		}
+		limitPrice = aligned
+	}
+
+	// Calculate makerAmount based on side
+	// Amounts stay exact decimals until they are converted to wei
+	var makerAmount *big.Rat
+
+	if data.Side == OrderSideBuy {
+		if data.MakerAmountInBaseToken != nil {
+			// BUY with base token amount: makerAmount = baseAmount * price
+			baseAmount, err := parseAmount("makerAmountInBaseToken", *data.MakerAmountInBaseToken)
+			if err != nil {
+				return nil, err
+			}
+			if limitPrice == nil {
+				return nil, &InvalidParamError{Message: "makerAmountInBaseToken requires a limit price for BUY orders"}
+			}
+			makerAmount = new(big.Rat).Mul(baseAmount, limitPrice)
+		} else if data.MakerAmountInQuoteToken != nil {
+			// BUY with quote token amount: use as-is
+			quoteAmount, err := parseAmount("makerAmountInQuoteToken", *data.MakerAmountInQuoteToken)
+			if err != nil {
+				return nil, err
+			}
+			makerAmount = quoteAmount
+		} else {
+			return nil, &InvalidParamError{Message: "Either makerAmountInBaseToken or makerAmountInQuoteToken must be provided for BUY orders"}
+		}
+	} else { // SELL
+		if data.MakerAmountInBaseToken != nil {
+			// SELL with base token amount: use as-is
+			baseAmount, err := parseAmount("makerAmountInBaseToken", *data.MakerAmountInBaseToken)
+			if err != nil {
+				return nil, err
+			}
+			makerAmount = baseAmount
+		} else if data.MakerAmountInQuoteToken != nil {
+			// SELL with quote token amount: makerAmount = quoteAmount / price
+			quoteAmount, err := parseAmount("makerAmountInQuoteToken", *data.MakerAmountInQuoteToken)
+			if err != nil {
+				return nil, err
+			}
+			if limitPrice == nil {
+				return nil, &InvalidParamError{Message: "makerAmountInQuoteToken requires a limit price for SELL orders"}
+			}
+			makerAmount = new(big.Rat).Quo(quoteAmount, limitPrice)
+		} else {
+			return nil, &InvalidParamError{Message: "Either makerAmountInBaseToken or makerAmountInQuoteToken must be provided for SELL orders"}
+		}
+	}
+
+	// Final validation: ensure makerAmount was properly calculated
+	if makerAmount.Sign() <= 0 {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("Calculated makerAmount must be positive, got: %s", makerAmount.FloatString(6))}
+	}
+	if err := c.checkMinOrderAmount(market, data, makerAmount, limitPrice, currencyDecimal); err != nil {
+		return nil, err
+	}
+
+	// Handle market orders: set price to 0 and takerAmount to 0
+	price := data.Price
+	if data.OrderType == OrderTypeMarket {
+		price = "0"
+	}
+
+	// Convert makerAmount to wei
+	makerAmountWei, err := ratToWei(makerAmount, currencyDecimal)
+	if err != nil {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("failed to convert makerAmount to wei: %v", err)}
+	}
+
+	// Calculate order amounts for limit orders
+	var recalculatedMakerAmount, takerAmount *big.Int
+	if data.OrderType == OrderTypeLimit {
+		recalculatedMakerAmount, takerAmount, err = CalculateOrderAmounts(
+			limitPrice,
+			makerAmountWei,
+			data.Side,
+			currencyDecimal,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		recalculatedMakerAmount = makerAmountWei
+		takerAmount = big.NewInt(0)
+	}
+
+	return &orderAmounts{price: price, makerAmount: recalculatedMakerAmount, takerAmount: takerAmount}, nil
+}
+
+// validateClientOrderID checks a caller-supplied idempotency key
+func validateClientOrderID(id string) error {
+	if id == "" || len(id) > maxClientOrderIDLength {
+		return &InvalidParamError{Message: fmt.Sprintf("clientOrderID must be 1-%d characters, got %d", maxClientOrderIDLength, len(id))}
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return &InvalidParamError{Message: fmt.Sprintf("clientOrderID must be printable ASCII without spaces, got: %q", id)}
+		}
+	}
+	return nil
 }
 
 func getMakerAmount(data PlaceOrderDataInput) string {
@@ -737,58 +1602,310 @@ func getMakerAmount(data PlaceOrderDataInput) string {
 	return "0"
 }
 
-func convertOrderSide(side OrderSide) chain.OrderSide {
-	if side == OrderSideBuy {
-		return chain.OrderSideBuy
+// resolveOrderMaker returns the maker address and signature type for an order,
+// checking that an explicit maker is consistent with the signature type
+func (c *Client) resolveOrderMaker(data PlaceOrderDataInput) (string, chain.SignatureType, error) {
+	signatureType := chain.SignatureTypePolyGnosisSafe
+	if data.SignatureType != nil {
+		signatureType = *data.SignatureType
+	}
+
+	signer := c.contractCaller.GetSignerAddress()
+	safe := c.contractCaller.GetMultiSigAddress()
+
+	var maker common.Address
+	if data.Maker != nil {
+		if !common.IsHexAddress(*data.Maker) {
+			return "", 0, &InvalidParamError{Message: fmt.Sprintf("invalid maker address: %s", *data.Maker)}
+		}
+		maker = common.HexToAddress(*data.Maker)
+		if maker == (common.Address{}) {
+			return "", 0, &InvalidParamError{Message: "maker cannot be the zero address"}
+		}
+	}
+
+	switch signatureType {
+	case chain.SignatureTypeEOA:
+		if data.Maker == nil {
+			maker = signer
+		} else if maker != signer {
+			return "", 0, &InvalidParamError{Message: fmt.Sprintf("EOA orders require maker to be the signer %s, got: %s", signer.Hex(), maker.Hex())}
+		}
+	case chain.SignatureTypePolyGnosisSafe:
+		if data.Maker == nil {
+			maker = safe
+		} else if maker != safe {
+			return "", 0, &InvalidParamError{Message: fmt.Sprintf("Safe orders require maker to be the multisig %s, got: %s", safe.Hex(), maker.Hex())}
+		}
+	case chain.SignatureTypePolyProxy:
+		if data.Maker == nil {
+			return "", 0, &InvalidParamError{Message: "maker is required for proxy orders"}
+		}
+	default:
+		return "", 0, &InvalidParamError{Message: fmt.Sprintf("invalid signature type: %d", signatureType)}
 	}
-	return chain.OrderSideSell
+
+	return maker.Hex(), signatureType, nil
 }
 
-// CancelOrder cancels an existing order
+// CancelOrder cancels an existing order. If this client submitted the order, its market is
+// dropped from the market cache; orders placed elsewhere leave the cache to its TTL.
 func (c *Client) CancelOrder(orderID string) (interface{}, error) {
+	result, err := c.cancelOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if marketID, ok := c.submittedOrderMarket(orderID); ok {
+		c.invalidateAfterTrade(marketID)
+	}
+
+	return result, nil
+}
+
+// cancelOrder cancels an order without touching the market cache
+func (c *Client) cancelOrder(orderID string) (interface{}, error) {
 	if orderID == "" {
 		return nil, &InvalidParamError{Message: "order_id must be a non-empty string"}
 	}
-
 	return c.apiClient.CancelOrder(orderID)
 }
 
+// submittedOrderMarket returns the market of an order this client submitted
+func (c *Client) submittedOrderMarket(orderID string) (int, bool) {
+	submitted, ok := c.submittedOrders.get(orderID)
+	if !ok {
+		return 0, false
+	}
+	marketID, ok := submitted.Request["topic_id"].(int)
+	return marketID, ok
+}
+
 // GetMyOrders fetches user's orders with optional filters
-func (c *Client) GetMyOrders(marketID int, status string, limit, page int) (interface{}, error) {
+func (c *Client) GetMyOrders(marketID int, status string, limit, page int) (*MyOrdersResponse, error) {
 	return c.apiClient.GetMyOrders(marketID, status, limit, page)
 }
 
 // GetOrderByID fetches detailed information about a specific order
-func (c *Client) GetOrderByID(orderID string) (interface{}, error) {
+func (c *Client) GetOrderByID(orderID string) (*OrderRecord, error) {
 	if orderID == "" {
 		return nil, &InvalidParamError{Message: "order_id must be a non-empty string"}
 	}
 
-	return c.apiClient.GetOrderByID(orderID)
+	result, err := c.apiClient.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result.Result.OrderData, nil
+}
+
+// maxOrderPollInterval caps the backoff between WaitForOrderFill polls
+const maxOrderPollInterval = 30 * time.Second
+
+// WaitForOrderFill polls an order until it is filled, cancelled or otherwise final,
+// or ctx is done. The interval between polls starts at pollInterval and doubles
+// up to maxOrderPollInterval. If ctx ends first, the last seen state is returned with ctx's error.
+func (c *Client) WaitForOrderFill(ctx context.Context, orderID string, pollInterval time.Duration) (*OrderRecord, error) {
+	if orderID == "" {
+		return nil, &InvalidParamError{Message: "order_id must be a non-empty string"}
+	}
+	if pollInterval <= 0 {
+		return nil, &InvalidParamError{Message: "pollInterval must be positive"}
+	}
+
+	var last *OrderRecord
+	interval := pollInterval
+	for {
+		order, err := c.GetOrderByID(orderID)
+		if err != nil {
+			return last, err
+		}
+		last = order
+		if order.Status.IsFinal() {
+			return order, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxOrderPollInterval {
+			interval = maxOrderPollInterval
+		}
+		if interval < pollInterval {
+			interval = pollInterval
+		}
+	}
 }
 
 // GetMyPositions fetches user's positions
-func (c *Client) GetMyPositions(marketID int, page, limit int) (interface{}, error) {
+func (c *Client) GetMyPositions(marketID int, page, limit int) (*MyPositionsResponse, error) {
 	return c.apiClient.GetMyPositions(marketID, page, limit)
 }
 
-// GetMyBalances fetches user's balances
-func (c *Client) GetMyBalances() (interface{}, error) {
-	return c.apiClient.GetMyBalances()
+// GetMyBalances fetches user's balances. Symbols the API omits are filled in from the
+// quote token list when it can be fetched.
+func (c *Client) GetMyBalances() (*BalancesResponse, error) {
+	result, err := c.apiClient.GetMyBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	c.fillBalanceSymbols(result.Result.Balances)
+	return result, nil
+}
+
+// fillBalanceSymbols sets the symbol of balances that lack one from the quote token list
+func (c *Client) fillBalanceSymbols(balances []Balance) {
+	missing := false
+	for _, b := range balances {
+		if b.Symbol == "" {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return
+	}
+
+	registry, err := c.GetQuoteTokenRegistry(true)
+	if err != nil {
+		c.logger.Debug("balance symbols unknown", "error", err)
+		return
+	}
+
+	for i := range balances {
+		if balances[i].Symbol != "" {
+			continue
+		}
+		if token, ok := registry.Get(balances[i].QuoteTokenAddress); ok {
+			balances[i].Symbol = token.Symbol
+		}
+	}
+}
+
+// GetBalanceFor returns the user's balance of one quote token. A supported quote token
+// the API reports no balance for yields a zero balance.
+func (c *Client) GetBalanceFor(quoteToken string) (*Balance, error) {
+	if !common.IsHexAddress(quoteToken) {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid quote token address: %s", quoteToken)}
+	}
+
+	result, err := c.GetMyBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range result.Result.Balances {
+		if strings.EqualFold(result.Result.Balances[i].QuoteTokenAddress, quoteToken) {
+			return &result.Result.Balances[i], nil
+		}
+	}
+
+	registry, err := c.GetQuoteTokenRegistry(true)
+	if err != nil {
+		return nil, err
+	}
+	token, ok := registry.Get(quoteToken)
+	if !ok {
+		return nil, &OpenAPIError{Message: fmt.Sprintf("quote token not supported: %s", quoteToken)}
+	}
+
+	return &Balance{
+		QuoteTokenAddress: quoteToken,
+		Symbol:            token.Symbol,
+		Decimals:          token.Decimal,
+		Total:             "0",
+		Available:         "0",
+		Locked:            "0",
+	}, nil
 }
 
 // GetMyTrades fetches user's trade history
-func (c *Client) GetMyTrades(marketID *int, page, limit int) (interface{}, error) {
+func (c *Client) GetMyTrades(marketID *int, page, limit int) (*MyTradesResponse, error) {
 	return c.apiClient.GetMyTrades(marketID, page, limit)
 }
 
-// GetUserAuth fetches authenticated user information
-func (c *Client) GetUserAuth() (interface{}, error) {
-	return c.apiClient.GetUserAuth()
+// GetMarketTrades fetches one page of recent public trades for a market
+func (c *Client) GetMarketTrades(marketID int, page, limit int) ([]Trade, error) {
+	if marketID <= 0 {
+		return nil, &InvalidParamError{Message: "market_id is required"}
+	}
+	if page < 1 {
+		return nil, &InvalidParamError{Message: "page must be >= 1"}
+	}
+	if limit < 1 || limit > 20 {
+		return nil, &InvalidParamError{Message: "limit must be between 1 and 20"}
+	}
+
+	result, err := c.apiClient.GetMarketTrades(marketID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result.Result.List, nil
+}
+
+// GetUserAuth fetches the user the API key authenticates as
+func (c *Client) GetUserAuth() (*UserAuth, error) {
+	result, err := c.apiClient.GetUserAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &result.Result, nil
 }
 
-// PlaceOrdersBatch places multiple orders in batch to reduce API calls.
-// If checkApproval is true, trading is enabled once for all orders.
+// CheckAuthAddress confirms that the API key belongs to the multi-sig wallet the client
+// signs for and, if the API reports a wallet address, to the signer, returning an error
+// wrapping ErrAuthAddressMismatch otherwise
+func (c *Client) CheckAuthAddress() error {
+	if err := c.requireSigner(); err != nil {
+		return err
+	}
+
+	auth, err := c.GetUserAuth()
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(auth.MultiSignAddress) {
+		return &OpenAPIError{Message: fmt.Sprintf("invalid multiSignAddress in auth response: %q", auth.MultiSignAddress)}
+	}
+
+	configured := c.contractCaller.GetMultiSigAddress()
+	if common.HexToAddress(auth.MultiSignAddress) != configured {
+		return fmt.Errorf("%w: the key belongs to multi-sig %s, the client uses %s", ErrAuthAddressMismatch, normalizeHexAddress(auth.MultiSignAddress), configured.Hex())
+	}
+
+	if auth.WalletAddress != "" {
+		if !common.IsHexAddress(auth.WalletAddress) {
+			return &OpenAPIError{Message: fmt.Sprintf("invalid walletAddress in auth response: %q", auth.WalletAddress)}
+		}
+		signer := c.contractCaller.GetSignerAddress()
+		if common.HexToAddress(auth.WalletAddress) != signer {
+			return fmt.Errorf("%w: the key belongs to wallet %s, the client signs with %s", ErrAuthAddressMismatch, normalizeHexAddress(auth.WalletAddress), signer.Hex())
+		}
+	}
+	return nil
+}
+
+// Ping checks API connectivity and the API key with a cheap authenticated request, for
+// use as a startup check. It returns an error wrapping ErrInvalidAPIKey if the key is
+// rejected or ErrAPIUnreachable if the API cannot be reached.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.apiClient.Ping(ctx)
+}
+
+// PlaceOrdersBatch places multiple orders in batch to reduce API calls, running up to
+// ClientConfig.OrderConcurrency placements at once (one at a time by default). Results
+// are in the order of orders, and a failed order does not stop the others. If
+// checkApproval is true, trading is enabled once for all orders before any is placed.
+// If ctx is done before every order has started, the remaining orders are reported as
+// failed with the context error, which is also returned alongside the results.
 func (c *Client) PlaceOrdersBatch(ctx context.Context, orders []PlaceOrderDataInput, checkApproval bool) ([]BatchOrderResult, error) {
 	if len(orders) == 0 {
 		return nil, &InvalidParamError{Message: "orders list cannot be empty"}
@@ -801,127 +1918,234 @@ func (c *Client) PlaceOrdersBatch(ctx context.Context, orders []PlaceOrderDataIn
 		}
 	}
 
-	results := make([]BatchOrderResult, 0, len(orders))
-
-	for i, order := range orders {
-		orderCopy := order                             // Create a copy for the pointer
-		result, err := c.PlaceOrder(ctx, order, false) // Don't check approval again
+	results := make([]BatchOrderResult, len(orders))
+	started := forEachConcurrently(ctx, len(orders), c.orderWorkers, func(i int) {
+		orderCopy := orders[i]                             // Create a copy for the pointer
+		result, err := c.PlaceOrder(ctx, orders[i], false) // Don't check approval again
 		if err != nil {
-			results = append(results, BatchOrderResult{
+			results[i] = BatchOrderResult{
 				Index:   i,
 				Success: false,
 				Error:   err.Error(),
 				Order:   &orderCopy,
-			})
+			}
 		} else {
-			results = append(results, BatchOrderResult{
+			results[i] = BatchOrderResult{
 				Index:   i,
 				Success: true,
 				Result:  result,
 				Order:   &orderCopy,
-			})
+			}
+		}
+	})
+
+	if started < len(orders) {
+		for i := started; i < len(orders); i++ {
+			orderCopy := orders[i]
+			results[i] = BatchOrderResult{
+				Index:   i,
+				Success: false,
+				Error:   ctx.Err().Error(),
+				Order:   &orderCopy,
+			}
 		}
+		return results, ctx.Err()
 	}
 
 	return results, nil
 }
 
-// CancelOrdersBatch cancels multiple orders in batch.
-func (c *Client) CancelOrdersBatch(orderIDs []string) ([]BatchCancelResult, error) {
+// DefaultCancelConcurrency is how many cancel requests CancelOrdersBatch runs at once by default
+const DefaultCancelConcurrency = 8
+
+// CancelOrdersBatch cancels multiple orders, running up to ClientConfig.CancelConcurrency
+// cancels at once. Results are in the order of orderIDs. If ctx is done before every
+// cancel has started, the remaining orders are reported as failed with the context error,
+// which is also returned alongside the results; cancels already sent are not interrupted.
+func (c *Client) CancelOrdersBatch(ctx context.Context, orderIDs []string) ([]BatchCancelResult, error) {
+	return c.cancelOrdersBatch(ctx, orderIDs, nil)
+}
+
+// cancelOrdersBatch implements CancelOrdersBatch. Once the batch is done, the markets of the
+// cancelled orders are invalidated once each, taken from markets or, for orders missing
+// there, from the orders this client submitted.
+func (c *Client) cancelOrdersBatch(ctx context.Context, orderIDs []string, markets map[string]int) ([]BatchCancelResult, error) {
 	if len(orderIDs) == 0 {
 		return nil, &InvalidParamError{Message: "orderIDs list cannot be empty"}
 	}
 
-	results := make([]BatchCancelResult, 0, len(orderIDs))
+	results := make([]BatchCancelResult, len(orderIDs))
+	started := forEachConcurrently(ctx, len(orderIDs), c.cancelWorkers, func(i int) {
+		results[i] = c.cancelBatchOrder(i, orderIDs[i])
+	})
+	c.invalidateCancelledMarkets(results[:started], markets)
 
-	for i, orderID := range orderIDs {
-		result, err := c.CancelOrder(orderID)
-		if err != nil {
-			results = append(results, BatchCancelResult{
+	if started < len(orderIDs) {
+		for i := started; i < len(orderIDs); i++ {
+			results[i] = BatchCancelResult{
 				Index:   i,
 				Success: false,
-				Error:   err.Error(),
-				OrderID: orderID,
-			})
-		} else {
-			results = append(results, BatchCancelResult{
-				Index:   i,
-				Success: true,
-				Result:  result,
-				OrderID: orderID,
-			})
+				Error:   ctx.Err().Error(),
+				OrderID: orderIDs[i],
+			}
 		}
+		return results, ctx.Err()
 	}
 
 	return results, nil
 }
 
-// CancelAllOrders cancels all open orders, optionally filtered by market and/or side.
-// Uses pagination to fetch all orders (max 20 per page).
-func (c *Client) CancelAllOrders(marketID *int, side *OrderSide) (*CancelAllOrdersResult, error) {
-	const (
-		maxPageLimit = 20
-		maxPages     = 100 // Safety limit to prevent infinite loops
-		openStatus   = "1" // 1 = pending/open orders
-	)
-
-	// Collect all open orders using pagination
-	var allOrderIDs []string
-	page := 1
-
-	for page <= maxPages {
-		// Get orders for current page
-		market := 0
-		if marketID != nil {
-			market = *marketID
-		}
-		pageOrders, err := c.GetMyOrders(market, openStatus, maxPageLimit, page)
-		if err != nil {
-			return nil, &OpenAPIError{Message: fmt.Sprintf("failed to get open orders page %d: %v", page, err)}
-		}
+// invalidateCancelledMarkets invalidates each market with a successfully cancelled order once
+func (c *Client) invalidateCancelledMarkets(results []BatchCancelResult, markets map[string]int) {
+	if !c.autoInvalidate {
+		return
+	}
 
-		// Parse response to extract order list
-		orders, ok := parseOrdersList(pageOrders)
-		if !ok || len(orders) == 0 {
-			// No more orders on this page
-			break
+	seen := make(map[int]bool)
+	var marketIDs []int
+	for _, r := range results {
+		if !r.Success {
+			continue
+		}
+		marketID, ok := markets[r.OrderID]
+		if !ok {
+			marketID, ok = c.submittedOrderMarket(r.OrderID)
+		}
+		if ok && !seen[marketID] {
+			seen[marketID] = true
+			marketIDs = append(marketIDs, marketID)
 		}
+	}
+	c.invalidateAfterTrade(marketIDs...)
+}
 
-		// Filter by side if specified and extract order IDs
-		for _, order := range orders {
-			if side != nil {
-				orderSide, sideOk := order["side"]
-				if sideOk {
-					// Handle both int and float64 JSON parsing
-					var orderSideInt int
-					switch v := orderSide.(type) {
-					case float64:
-						orderSideInt = int(v)
-					case int:
-						orderSideInt = v
-					default:
-						continue // Skip if side is not a valid type
-					}
-					if orderSideInt != int(*side) {
-						continue // Skip orders that don't match the filter
-					}
-				}
+// forEachConcurrently calls fn with each index in [0, n) on up to workers goroutines and
+// waits for the calls to return. It stops handing out indexes once ctx is done and
+// returns how many were started; those are always the lowest indexes.
+func forEachConcurrently(ctx context.Context, n, workers int, fn func(i int)) int {
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
 			}
+		}()
+	}
 
-			if orderID, ok := order["order_id"].(string); ok && orderID != "" {
-				allOrderIDs = append(allOrderIDs, orderID)
-			}
+	started := 0
+dispatch:
+	for ; started < n; started++ {
+		// Check first so that a done ctx wins over an idle worker
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indexes <- started:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return started
+}
+
+// cancelBatchOrder cancels one order of a batch and records the outcome
+func (c *Client) cancelBatchOrder(index int, orderID string) BatchCancelResult {
+	result, err := c.cancelOrder(orderID)
+	if err != nil {
+		return BatchCancelResult{
+			Index:   index,
+			Success: false,
+			Error:   err.Error(),
+			OrderID: orderID,
 		}
+	}
+	return BatchCancelResult{
+		Index:   index,
+		Success: true,
+		Result:  result,
+		OrderID: orderID,
+	}
+}
+
+// CancelAllOrders cancels all open orders, optionally filtered by market and/or side.
+// Open orders are collected across all pages before cancelling. If some orders could not
+// be cancelled, the result is returned with a *PartialFailureError listing them.
+func (c *Client) CancelAllOrders(marketID *int, side *OrderSide) (*CancelAllOrdersResult, error) {
+	orderIDs, markets, err := c.collectOpenOrderIDs(context.Background(), marketID, func(order *OrderRecord) bool {
+		// Filter by side if specified
+		return side == nil || order.Side == *side
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cancelOrderIDs(context.Background(), orderIDs, markets)
+}
+
+// CancelOrdersOlderThan cancels open orders created more than age ago, optionally
+// filtered by market. Like CancelAllOrders, it returns a *PartialFailureError with the
+// result if some orders could not be cancelled.
+func (c *Client) CancelOrdersOlderThan(ctx context.Context, marketID *int, age time.Duration) (*CancelAllOrdersResult, error) {
+	if age < 0 {
+		return nil, &InvalidParamError{Message: "age must not be negative"}
+	}
+
+	cutoff := c.clock().Add(-age).Unix()
+	orderIDs, markets, err := c.collectOpenOrderIDs(ctx, marketID, func(order *OrderRecord) bool {
+		return order.CreatedAt < cutoff
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.cancelOrderIDs(ctx, orderIDs, markets)
+}
+
+// collectOpenOrderIDs returns the IDs of all open orders (across all pages) accepted by keep,
+// together with the market of each order
+func (c *Client) collectOpenOrderIDs(ctx context.Context, marketID *int, keep func(*OrderRecord) bool) ([]string, map[string]int, error) {
+	market := 0
+	if marketID != nil {
+		market = *marketID
+	}
 
-		// If we got fewer orders than the limit, we've reached the last page
-		if len(orders) < maxPageLimit {
+	var orderIDs []string
+	markets := make(map[string]int)
+	it := c.IterateMyOrders(market, OrderStatusFilterOpen)
+	for {
+		order, ok, err := it.Next(ctx)
+		if err != nil {
+			return nil, nil, &OpenAPIError{Message: fmt.Sprintf("failed to get open orders: %v", err)}
+		}
+		if !ok {
 			break
 		}
 
-		page++
+		if order.OrderID != "" && keep(order) {
+			orderIDs = append(orderIDs, order.OrderID)
+			markets[order.OrderID] = order.MarketID
+		}
 	}
 
-	if len(allOrderIDs) == 0 {
+	return orderIDs, markets, nil
+}
+
+// cancelOrderIDs cancels the given orders and summarizes the outcome. If any order could
+// not be cancelled, the summary is returned together with a *PartialFailureError.
+func (c *Client) cancelOrderIDs(ctx context.Context, orderIDs []string, markets map[string]int) (*CancelAllOrdersResult, error) {
+	if len(orderIDs) == 0 {
 		return &CancelAllOrdersResult{
 			TotalOrders: 0,
 			Cancelled:   0,
@@ -931,54 +2155,30 @@ func (c *Client) CancelAllOrders(marketID *int, side *OrderSide) (*CancelAllOrde
 	}
 
 	// Cancel all orders in batch
-	results, err := c.CancelOrdersBatch(allOrderIDs)
-	if err != nil {
+	results, err := c.cancelOrdersBatch(ctx, orderIDs, markets)
+	if results == nil {
 		return nil, err
 	}
 
 	// Count successes and failures
 	cancelled := 0
-	failed := 0
+	var failures []CancelFailure
 	for _, r := range results {
 		if r.Success {
 			cancelled++
 		} else {
-			failed++
+			failures = append(failures, CancelFailure{OrderID: r.OrderID, Reason: r.Error})
 		}
 	}
 
-	return &CancelAllOrdersResult{
-		TotalOrders: len(allOrderIDs),
+	summary := &CancelAllOrdersResult{
+		TotalOrders: len(orderIDs),
 		Cancelled:   cancelled,
-		Failed:      failed,
+		Failed:      len(failures),
 		Results:     results,
-	}, nil
-}
-
-// parseOrdersList attempts to parse the orders list from a GetMyOrders response
-func parseOrdersList(response interface{}) ([]map[string]interface{}, bool) {
-	// Try to parse as a map with result.list structure
-	respMap, ok := response.(map[string]interface{})
-	if !ok {
-		return nil, false
 	}
-
-	result, ok := respMap["result"].(map[string]interface{})
-	if !ok {
-		return nil, false
+	if len(failures) > 0 {
+		return summary, &PartialFailureError{Total: len(orderIDs), Failures: failures, Err: err}
 	}
-
-	list, ok := result["list"].([]interface{})
-	if !ok {
-		return nil, false
-	}
-
-	orders := make([]map[string]interface{}, 0, len(list))
-	for _, item := range list {
-		if order, ok := item.(map[string]interface{}); ok {
-			orders = append(orders, order)
-		}
-	}
-
-	return orders, true
+	return summary, nil
 }
@@ -0,0 +1,152 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCloseAbortsWorkAfterShutdownTimeout(t *testing.T) {
+	f := newFakeAPI(t)
+	release := make(chan struct{})
+	defer close(release)
+	f.handle("/market/9", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, `{"code":0,"result":{"data":{"marketId":9,"status":2}}}`)
+	})
+	c := newTestClient(t, f, WithShutdownTimeout(200*time.Millisecond))
+
+	events, err := c.WatchMarketStatus(context.Background(), 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.NewWSClient(WSConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.GetMarket(9, false)
+		errc <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	c.Close()
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("Close took %v, want about the shutdown timeout", d)
+	}
+	if err := <-errc; err == nil {
+		t.Fatal("in-flight request succeeded, want it aborted")
+	}
+	for range events {
+	}
+
+	start = time.Now()
+	c.Close()
+	if d := time.Since(start); d > 50*time.Millisecond {
+		t.Fatalf("second Close took %v, want a no-op", d)
+	}
+	if _, err := c.GetMarket(1, false); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("GetMarket after Close = %v, want ErrClientClosed", err)
+	}
+	if _, err := c.WatchMarketStatus(context.Background(), 1, time.Second); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("WatchMarketStatus after Close = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestCloseDrainsInFlightRequests(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/market/9", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, `{"code":0,"result":{"data":{"marketId":9,"status":2}}}`)
+	})
+	c := newTestClient(t, f)
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := c.GetMarket(9, false)
+		errc <- err
+	}()
+	time.Sleep(30 * time.Millisecond)
+	c.Close()
+	if err := <-errc; err != nil {
+		t.Fatalf("in-flight request failed across Close: %v", err)
+	}
+}
+
+func TestCloseStopsAttachedWebSocket(t *testing.T) {
+	server := newFakeWSServer(t)
+	f := newFakeAPI(t)
+	before := runtime.NumGoroutine()
+	c := newTestClient(t, f)
+
+	var closed atomic.Bool
+	var received atomic.Int32
+	ws, err := c.NewWSClient(WSConfig{
+		Endpoint:          server.url(),
+		MessageBufferSize: 4,
+		ReconnectInterval: time.Millisecond,
+		OnMessage: func(int, []byte) {
+			if closed.Load() {
+				t.Error("OnMessage called after Close returned")
+			}
+			received.Add(1)
+			time.Sleep(time.Millisecond)
+		},
+		OnDisconnect: func() {
+			if closed.Load() {
+				t.Error("OnDisconnect called after Close returned")
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	traffic := make(chan struct{})
+	go func() {
+		defer close(traffic)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			server.mu.Lock()
+			for _, conn := range server.conns {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"channel":"market.last.price","data":{"price":"0.5"}}`))
+			}
+			server.mu.Unlock()
+			time.Sleep(200 * time.Microsecond)
+		}
+	}()
+	waitFor(t, func() bool { return received.Load() > 20 })
+
+	// Drop the connection while Close runs so a reconnect races the shutdown
+	go server.dropAll()
+	c.Close()
+	closed.Store(true)
+	close(stop)
+	<-traffic
+
+	for range ws.Messages() {
+	}
+	if err := ws.Connect(context.Background()); !errors.Is(err, ErrWSClosed) {
+		t.Fatalf("Connect after Close = %v, want ErrWSClosed", err)
+	}
+	waitFor(t, func() bool { return runtime.NumGoroutine() <= before })
+}
@@ -0,0 +1,46 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+)
+
+// CancelByClientOrderID cancels the open order placed with clientOrderID, for when the
+// server order ID is not known, e.g. after PlaceOrder timed out. The order ID comes from
+// the orders this client submitted or, failing that, from a scan of the user's open
+// orders. It fails with ErrOrderNotFound if no open order has that client order ID.
+func (c *Client) CancelByClientOrderID(ctx context.Context, clientOrderID string) (interface{}, error) {
+	if clientOrderID == "" {
+		return nil, &InvalidParamError{Message: "client_order_id must be a non-empty string"}
+	}
+
+	orderID, err := c.resolveClientOrderID(ctx, clientOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.CancelOrder(orderID)
+}
+
+// resolveClientOrderID returns the server order ID of the order placed with clientOrderID
+func (c *Client) resolveClientOrderID(ctx context.Context, clientOrderID string) (string, error) {
+	if submitted, ok := c.submittedOrders.findByClientOrderID(clientOrderID); ok {
+		return submitted.OrderID, nil
+	}
+
+	orders := c.IterateMyOrders(0, OrderStatusFilterOpen)
+	for {
+		order, ok, err := orders.Next(ctx)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			break
+		}
+		if order.ClientOrderID == clientOrderID {
+			return order.OrderID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: client order ID %s", ErrOrderNotFound, clientOrderID)
+}
@@ -0,0 +1,61 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCancelByClientOrderID(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			io.WriteString(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"o9"}}}`)
+			return
+		}
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":2,"list":[{"orderId":"o1","clientOrderId":"ref-1"},{"orderId":"o2","clientOrderId":"ref-2"}]}}`)
+	})
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok"}`)
+	})
+	c := newTestClient(t, f)
+
+	// Resolved by scanning the open orders
+	if _, err := c.CancelByClientOrderID(context.Background(), "ref-2"); err != nil {
+		t.Fatal(err)
+	}
+	if id := f.lastBody("/order/cancel")["order_id"]; id != "o2" {
+		t.Fatalf("cancelled %v, want o2", id)
+	}
+
+	// Orders this client placed are resolved without a scan
+	data := limitBuy("0.5", "10")
+	data.ClientOrderID = strPtr("placed-here")
+	if _, err := c.PlaceOrder(context.Background(), data, false); err != nil {
+		t.Fatal(err)
+	}
+	scans := f.count("/order")
+	if _, err := c.CancelByClientOrderID(context.Background(), "placed-here"); err != nil {
+		t.Fatal(err)
+	}
+	if id := f.lastBody("/order/cancel")["order_id"]; id != "o9" {
+		t.Fatalf("cancelled %v, want o9", id)
+	}
+	if n := f.count("/order"); n != scans {
+		t.Errorf("listed orders %d times for a submitted order, want 0", n-scans)
+	}
+
+	cancels := f.count("/order/cancel")
+	if _, err := c.CancelByClientOrderID(context.Background(), "missing"); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("unknown reference = %v, want ErrOrderNotFound", err)
+	}
+	var invalid *InvalidParamError
+	if _, err := c.CancelByClientOrderID(context.Background(), ""); !errors.As(err, &invalid) {
+		t.Errorf("empty reference = %v, want InvalidParamError", err)
+	}
+	if n := f.count("/order/cancel"); n != cancels {
+		t.Errorf("sent %d cancels for unresolved references, want 0", n-cancels)
+	}
+}
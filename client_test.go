@@ -0,0 +1,972 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fixedOrderParams always returns the same salt, nonce and expiration
+type fixedOrderParams struct{}
+
+func (fixedOrderParams) NextSalt() (string, error)  { return "12345", nil }
+func (fixedOrderParams) NextNonce() (string, error) { return "7", nil }
+func (fixedOrderParams) DefaultExpiration() string  { return "1999999999" }
+
+func TestPlaceOrderUsesOrderParamsProvider(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f, WithOrderParamsProvider(fixedOrderParams{}))
+
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	body := f.lastBody("/order")
+	if body["salt"] != "12345" || body["nonce"] != "7" || body["expiration"] != "1999999999" {
+		t.Fatalf("order params = salt %v, nonce %v, expiration %v; want the provider's", body["salt"], body["nonce"], body["expiration"])
+	}
+}
+
+func TestPlaceOrderMakerOverride(t *testing.T) {
+	const delegated = "0x2222222222222222222222222222222222222222"
+	proxy, eoa := SignatureTypePolyProxy, SignatureTypeEOA
+
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	order := limitBuy("0.5", "10")
+	order.Maker = strPtr(delegated)
+	order.SignatureType = &proxy
+	if _, err := c.PlaceOrder(context.Background(), order, false); err != nil {
+		t.Fatal(err)
+	}
+	body := f.lastBody("/order")
+	if body["maker"] != delegated || body["signature_type"] != "2" {
+		t.Fatalf("proxy order sent maker %v, signature type %v; want %s, 2", body["maker"], body["signature_type"], delegated)
+	}
+
+	// A Safe order must be made by the configured multisig
+	order = limitBuy("0.5", "10")
+	order.Maker = strPtr(delegated)
+	_, err := c.PlaceOrder(context.Background(), order, false)
+	var invalid *InvalidParamError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Safe order with a foreign maker: err = %v, want InvalidParamError", err)
+	}
+
+	// EOA orders default the maker to the signer
+	order = limitBuy("0.5", "10")
+	order.SignatureType = &eoa
+	if _, err := c.PlaceOrder(context.Background(), order, false); err != nil {
+		t.Fatal(err)
+	}
+	if body := f.lastBody("/order"); body["maker"] != body["signer"] {
+		t.Fatalf("EOA order maker = %v, want the signer %v", body["maker"], body["signer"])
+	}
+}
+
+func TestGetTradingStatus(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	statuses, err := c.GetTradingStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].QuoteToken != testQuoteToken || statuses[0].Exchange != testExchange || statuses[0].Symbol != "USDT" {
+		t.Fatalf("statuses = %+v, want one for USDT", statuses)
+	}
+	if statuses[0].ERC20Approved || statuses[0].ERC1155Approved {
+		t.Fatalf("status with no approvals = %+v", statuses[0])
+	}
+
+	// The token reports 0 decimals, so an allowance of 1e9 meets the threshold
+	f.rpc.allowance.Store(1_000_000_000)
+	f.rpc.approved.Store(true)
+	statuses, err = c.GetTradingStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !statuses[0].ERC20Approved || !statuses[0].ERC1155Approved {
+		t.Fatalf("status with all approvals = %+v", statuses[0])
+	}
+}
+
+func TestGetTradingStatusRequiresSigner(t *testing.T) {
+	f := newFakeAPI(t)
+	c, err := NewClient(ClientConfig{Host: f.srv.URL, APIKey: "test-key", ChainID: ChainIDBNBMainnet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err := c.GetTradingStatus(context.Background()); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("GetTradingStatus on a read-only client = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestWaitForOrderFill(t *testing.T) {
+	f := newFakeAPI(t)
+	// open, then partially filled, then filled
+	var polls atomic.Int32
+	f.handle("/order/abc", func(w http.ResponseWriter, r *http.Request) {
+		status, filled := OrderStatusPending, "0"
+		switch n := polls.Add(1); {
+		case n == 2:
+			filled = "5"
+		case n >= 3:
+			status, filled = OrderStatusFilled, "10"
+		}
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"abc","status":%d,"shares":"10","filledShares":"%s"}}}`, status, filled)
+	})
+	c := newTestClient(t, f)
+
+	order, err := c.WaitForOrderFill(context.Background(), "abc", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != OrderStatusFilled || order.FilledShares != "10" || polls.Load() != 3 {
+		t.Fatalf("WaitForOrderFill = %+v after %d polls, want filled after 3", order, polls.Load())
+	}
+}
+
+func TestWaitForOrderFillHonorsContext(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order/abc", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"abc","status":%d}}}`, OrderStatusPending)
+	})
+	c := newTestClient(t, f)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	order, err := c.WaitForOrderFill(ctx, "abc", 5*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if order == nil || order.OrderID != "abc" {
+		t.Fatalf("order = %+v, want the last state seen", order)
+	}
+}
+
+func TestNewClientValidatesConfig(t *testing.T) {
+	f := newFakeAPI(t)
+	tests := []struct {
+		name   string
+		modify func(*ClientConfig)
+		want   string // expected in the error; empty if the config is valid
+	}{
+		{"valid", func(c *ClientConfig) {}, ""},
+		{"0x-prefixed private key", func(c *ClientConfig) { c.PrivateKey = "0x" + c.PrivateKey }, ""},
+		{"read-only", func(c *ClientConfig) { c.PrivateKey, c.RPCURL, c.MultiSigAddr = "", "", "" }, ""},
+		{"empty host", func(c *ClientConfig) { c.Host = "" }, "host is required"},
+		{"host without scheme", func(c *ClientConfig) { c.Host = "api.example.com" }, "host must be an http(s) URL"},
+		{"missing API key", func(c *ClientConfig) { c.APIKey = "" }, "api_key is required"},
+		{"unsupported chain", func(c *ClientConfig) { c.ChainID = 1 }, "chain_id must be one of"},
+		{"malformed RPC URL", func(c *ClientConfig) { c.RPCURL = "::bad" }, "rpc_url must be"},
+		{"missing RPC URL", func(c *ClientConfig) { c.RPCURL = "" }, "rpc_url is required"},
+		{"bad private key", func(c *ClientConfig) { c.PrivateKey = "zz" }, "private_key must be a 32-byte hex string"},
+		{"invalid multisig", func(c *ClientConfig) { c.MultiSigAddr = "0x12" }, "multi_sig_addr must be a hex address"},
+		{"invalid multisend", func(c *ClientConfig) { c.MultisendAddr = "nope" }, "multisend_addr must be a hex address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig(f)
+			tt.modify(&config)
+			c, err := NewClient(config)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				c.Close()
+				return
+			}
+			var invalid *InvalidParamError
+			if !errors.As(err, &invalid) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("NewClient error = %v, want InvalidParamError containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateListsEveryProblem(t *testing.T) {
+	err := ClientConfig{ChainID: ChainIDBNBMainnet, PrivateKey: "zz", RPCURL: "::bad"}.Validate()
+	if err == nil {
+		t.Fatal("empty config passed validation")
+	}
+	for _, want := range []string{"host is required", "api_key is required", "rpc_url must be", "private_key must be", "multi_sig_addr must be"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "zz") {
+		t.Errorf("error %q echoes the private key", err)
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	f := newFakeAPI(t)
+	c, err := NewClient(ClientConfig{Host: f.srv.URL, APIKey: "test-key", ChainID: ChainIDBNBMainnet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if !c.IsReadOnly() {
+		t.Fatal("client without private key and RPC URL is not read-only")
+	}
+	if _, err := c.GetMarket(1, false); err != nil {
+		t.Fatalf("read-only GetMarket: %v", err)
+	}
+	if _, err := c.Split(context.Background(), 1, big.NewInt(1), true); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("Split error = %v, want ErrReadOnly", err)
+	}
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("PlaceOrder error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestPlaceOrderRejectedWhileExchangePaused(t *testing.T) {
+	f := newFakeAPI(t)
+	f.rpc.paused.Store(true)
+	c := newTestClient(t, f)
+
+	_, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false)
+	if !errors.Is(err, ErrExchangePaused) {
+		t.Fatalf("PlaceOrder error = %v, want ErrExchangePaused", err)
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Fatalf("%d orders sent to a paused exchange", n)
+	}
+	// The paused check comes before the fee lookup and signing
+	if n := f.rpc.feeCalls.Load(); n != 0 {
+		t.Fatalf("fetched fee rates %d times for an order on a paused exchange", n)
+	}
+}
+
+func TestIsTradingPausedCachesBriefly(t *testing.T) {
+	f := newFakeAPI(t)
+	// A fixed order clock must not keep the paused state cached forever
+	fixed := time.Unix(1700000000, 0)
+	c := newTestClient(t, f, WithClock(func() time.Time { return fixed }))
+	c.pausedCacheTTL = 20 * time.Millisecond
+
+	paused, err := c.IsTradingPaused(context.Background(), testExchange)
+	if err != nil || paused {
+		t.Fatalf("IsTradingPaused = %v, %v; want false", paused, err)
+	}
+
+	f.rpc.paused.Store(true)
+	if paused, err := c.IsTradingPaused(context.Background(), testExchange); err != nil || paused {
+		t.Fatalf("IsTradingPaused within the cache TTL = %v, %v; want cached false", paused, err)
+	}
+	if n := f.rpc.pauseCall.Load(); n != 1 {
+		t.Fatalf("paused() called %d times, want 1", n)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if paused, err := c.IsTradingPaused(context.Background(), testExchange); err != nil || !paused {
+		t.Fatalf("IsTradingPaused after the cache TTL = %v, %v; want true", paused, err)
+	}
+}
+
+func TestPlaceOrderConvertsAmountExactly(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	// 1.1 as a float64 is 1.100000000000000088...
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "1.1"), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.lastBody("/order")["maker_amount"]; got != "1100000000000000000" {
+		t.Fatalf("maker_amount = %v, want 1100000000000000000", got)
+	}
+}
+
+func TestPlaceOrderVerified(t *testing.T) {
+	tests := []struct {
+		status   OrderStatus
+		accepted bool
+	}{
+		{OrderStatusPending, true},
+		{OrderStatusFilled, true},
+		{OrderStatusCancelled, false},
+		{OrderStatusFailed, false},
+	}
+	for _, tt := range tests {
+		f := newFakeAPI(t)
+		f.handle("/order/ord-1", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"ord-1","status":%d}}}`, tt.status)
+		})
+		c := newTestClient(t, f)
+
+		order, err := c.PlaceOrderVerified(context.Background(), limitBuy("0.5", "10"), false)
+		if tt.accepted && err != nil {
+			t.Errorf("status %d: %v", tt.status, err)
+		}
+		if !tt.accepted && !errors.Is(err, ErrOrderNotAccepted) {
+			t.Errorf("status %d: error = %v, want ErrOrderNotAccepted", tt.status, err)
+		}
+		if order == nil || order.Status != tt.status {
+			t.Errorf("status %d: read back %+v", tt.status, order)
+		}
+	}
+}
+
+func TestPlaceOrderVerifiedOrderMissing(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order/ord-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":404,"msg":"not found"}`)
+	})
+	c := newTestClient(t, f)
+
+	if _, err := c.PlaceOrderVerified(context.Background(), limitBuy("0.5", "10"), false); !errors.Is(err, ErrOrderNotAccepted) {
+		t.Fatalf("error = %v, want ErrOrderNotAccepted", err)
+	}
+}
+
+func TestPlaceOrderExpiresAt(t *testing.T) {
+	f := newFakeAPI(t)
+	now := time.Unix(1700000000, 0)
+	c := newTestClient(t, f, WithClock(func() time.Time { return now }))
+
+	order := limitBuy("0.5", "10")
+	expiresAt := now.Add(time.Hour)
+	order.ExpiresAt = &expiresAt
+	signed, request, err := c.BuildSignedOrder(context.Background(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Order.Expiration != "1700003600" || request["expiration"] != "1700003600" || request["order_exp_time"] != "1700003600" {
+		t.Fatalf("signed expiration %s, request %v/%v; want 1700003600", signed.Order.Expiration, request["expiration"], request["order_exp_time"])
+	}
+
+	// Without ExpiresAt the order is good-till-cancelled
+	order.ExpiresAt = nil
+	signed, request, err = c.BuildSignedOrder(context.Background(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Order.Expiration != "0" || request["order_exp_time"] != "0" {
+		t.Fatalf("signed expiration %s, order_exp_time %v; want 0", signed.Order.Expiration, request["order_exp_time"])
+	}
+}
+
+func TestPlaceOrderRejectsPastExpiration(t *testing.T) {
+	f := newFakeAPI(t)
+	now := time.Unix(1700000000, 0)
+	c := newTestClient(t, f, WithClock(func() time.Time { return now }))
+
+	for _, expiresAt := range []time.Time{now, now.Add(-time.Second)} {
+		order := limitBuy("0.5", "10")
+		order.ExpiresAt = &expiresAt
+		var invalid *InvalidParamError
+		if _, err := c.PlaceOrder(context.Background(), order, false); !errors.As(err, &invalid) {
+			t.Fatalf("expiresAt %s: error = %v, want InvalidParamError", expiresAt, err)
+		}
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Fatalf("%d expired orders sent", n)
+	}
+}
+
+func TestPlaceOrderSafeRate(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f,
+		WithOrderParamsProvider(fixedOrderParams{}),
+		WithClock(func() time.Time { return time.Unix(1700000000, 0) }),
+	)
+
+	order := limitBuy("0.5", "10")
+	_, unset, err := c.BuildSignedOrder(context.Background(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unset["safe_rate"] != "0" {
+		t.Fatalf("default safe_rate = %v, want 0", unset["safe_rate"])
+	}
+
+	order.SafeRate = strPtr("0.05")
+	_, request, err := c.BuildSignedOrder(context.Background(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request["safe_rate"] != "0.05" {
+		t.Fatalf("safe_rate = %v, want 0.05", request["safe_rate"])
+	}
+	// safe_rate is not part of the signed order
+	if request["signature"] != unset["signature"] {
+		t.Fatal("safe_rate changed the order signature")
+	}
+
+	for _, bad := range []string{"1.5", "-0.1", "x", ""} {
+		order.SafeRate = strPtr(bad)
+		var invalid *InvalidParamError
+		if _, _, err := c.BuildSignedOrder(context.Background(), order); !errors.As(err, &invalid) {
+			t.Errorf("safe_rate %q: error = %v, want InvalidParamError", bad, err)
+		}
+	}
+}
+
+func TestPlaceOrderFeeRateBps(t *testing.T) {
+	f := newFakeAPI(t)
+	f.rpc.makerBps.Store(100)
+	f.rpc.takerBps.Store(800)
+	c := newTestClient(t, f)
+
+	feeRate := func(order PlaceOrderDataInput) interface{} {
+		t.Helper()
+		_, request, err := c.BuildSignedOrder(context.Background(), order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return request["fee_rate_bps"]
+	}
+
+	market := PlaceOrderDataInput{MarketID: 1, TokenID: "111", Side: OrderSideBuy, OrderType: OrderTypeMarket, MakerAmountInQuoteToken: strPtr("10")}
+	if got := feeRate(market); got != "800" {
+		t.Errorf("market order fee_rate_bps = %v, want the taker rate 800", got)
+	}
+	if got := feeRate(limitBuy("0.5", "10")); got != "100" {
+		t.Errorf("limit order fee_rate_bps = %v, want the maker rate 100", got)
+	}
+	if n := f.rpc.feeCalls.Load(); n != 1 {
+		t.Errorf("fee rates fetched %d times, want 1 (cached)", n)
+	}
+
+	override := limitBuy("0.5", "10")
+	override.FeeRateBps = strPtr("42")
+	if got := feeRate(override); got != "42" {
+		t.Errorf("overridden fee_rate_bps = %v, want 42", got)
+	}
+	override.FeeRateBps = strPtr("-1")
+	if _, _, err := c.BuildSignedOrder(context.Background(), override); err == nil {
+		t.Error("negative fee rate accepted")
+	}
+}
+
+func TestGetFeeRatesIsCached(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetFeeRates(context.Background(), 7); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := f.rpc.feeCalls.Load(); n != 1 {
+		t.Fatalf("fee rates fetched %d times, want 1", n)
+	}
+
+	c.ClearFeeCache()
+	if _, err := c.GetFeeRates(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.rpc.feeCalls.Load(); n != 2 {
+		t.Fatalf("fee rates fetched %d times after ClearFeeCache, want 2", n)
+	}
+
+	// Entries are per token
+	if _, err := c.GetFeeRates(context.Background(), 8); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.rpc.feeCalls.Load(); n != 3 {
+		t.Fatalf("fee rates fetched %d times for a second token, want 3", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetFeeRates(ctx, 9); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetFeeRates with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestGetFeeRatesCacheExpires(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f, func(cfg *ClientConfig) { cfg.FeeRatesCacheTTL = 20 * time.Millisecond })
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetFeeRates(context.Background(), 7); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := f.rpc.feeCalls.Load(); n != 1 {
+		t.Fatalf("fee rates fetched %d times within the TTL, want 1", n)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := c.GetFeeRates(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.rpc.feeCalls.Load(); n != 2 {
+		t.Fatalf("fee rates fetched %d times after the TTL, want 2", n)
+	}
+}
+
+func TestConcurrentPlaceOrderEnablesTradingOnce(t *testing.T) {
+	f := newFakeAPI(t)
+	f.rpc.allowance.Store(1 << 62)
+	f.rpc.approved.Store(true)
+	c := newTestClient(t, f)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), true); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// One quote token: its exchange and ConditionalTokens allowances, checked once
+	if n := f.rpc.allowanceCalls.Load(); n != 2 {
+		t.Fatalf("allowance read %d times, want 2", n)
+	}
+	if n := f.count("/order"); n != 8 {
+		t.Fatalf("placed %d orders, want 8", n)
+	}
+}
+
+func TestPlaceOrderClientOrderID(t *testing.T) {
+	f := newFakeAPI(t)
+	var mu sync.Mutex
+	var keys []string
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		first := len(keys) == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"ord-1"}}}`)
+	})
+	c := newTestClient(t, f)
+
+	// Retrying with the same ClientOrderID sends the same key
+	data := limitBuy("0.5", "10")
+	data.ClientOrderID = strPtr("my-key-1")
+	if _, err := c.PlaceOrder(context.Background(), data, false); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+	resp, err := c.PlaceOrder(context.Background(), data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys[0] != "my-key-1" || keys[1] != "my-key-1" {
+		t.Fatalf("sent keys %v", keys)
+	}
+	if resp.ClientOrderID != "my-key-1" || f.lastBody("/order")["client_order_id"] != "my-key-1" {
+		t.Fatalf("client order ID %q, body %v", resp.ClientOrderID, f.lastBody("/order"))
+	}
+
+	// Without one, the order salt is used
+	data.ClientOrderID = nil
+	resp, err = c.PlaceOrder(context.Background(), data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := f.lastBody("/order")
+	if resp.ClientOrderID == "" || body["client_order_id"] != body["salt"] || keys[2] != resp.ClientOrderID {
+		t.Fatalf("client order ID %q, body %v", resp.ClientOrderID, body)
+	}
+
+	data.ClientOrderID = strPtr("has space")
+	var invalid *InvalidParamError
+	if _, err := c.PlaceOrder(context.Background(), data, false); !errors.As(err, &invalid) {
+		t.Fatalf("expected InvalidParamError, got %v", err)
+	}
+}
+
+func TestPlaceOrderVerifiesExchangeCode(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f, WithExchangeCodeCheck())
+
+	f.rpc.noCode.Store(true)
+	_, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false)
+	if !errors.Is(err, ErrExchangeNotContract) {
+		t.Fatalf("expected ErrExchangeNotContract, got %v", err)
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Fatalf("submitted %d orders, want 0", n)
+	}
+
+	// Once verified, the address is not checked again
+	f.rpc.noCode.Store(false)
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	f.rpc.noCode.Store(true)
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatalf("verified exchange checked again: %v", err)
+	}
+
+	// The check is off by default
+	if _, err := newTestClient(t, f).PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlaceOrderRejectsNonFinitePrices(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	for _, price := range []string{"NaN", "Inf", "-Inf", "+Inf", "infinity", "nan"} {
+		var invalid *InvalidParamError
+		if _, err := c.PlaceOrder(context.Background(), limitBuy(price, "10"), false); !errors.As(err, &invalid) {
+			t.Errorf("price %q: expected InvalidParamError, got %v", price, err)
+		}
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Fatalf("submitted %d orders, want 0", n)
+	}
+}
+
+func TestPlaceOrderTimestampPrecision(t *testing.T) {
+	now := time.Unix(1700000000, 123456789)
+	for _, tc := range []struct {
+		precision TimestampPrecision
+		want      float64
+	}{
+		{TimestampSeconds, 1700000000},
+		{TimestampMilliseconds, 1700000000123},
+	} {
+		f := newFakeAPI(t)
+		c := newTestClient(t, f, WithOrderTimestampPrecision(tc.precision), WithClock(func() time.Time { return now }))
+		if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+			t.Fatal(err)
+		}
+		if got := f.lastBody("/order")["timestamp"]; got != tc.want {
+			t.Errorf("precision %v: timestamp = %v, want %v", tc.precision, got, tc.want)
+		}
+	}
+
+	f := newFakeAPI(t)
+	c := newTestClient(t, f, WithClock(func() time.Time { return now }))
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.lastBody("/order")["timestamp"]; got != float64(1700000000) {
+		t.Errorf("default timestamp = %v, want seconds", got)
+	}
+}
+
+func TestCancelOrdersBatchBoundsConcurrency(t *testing.T) {
+	f := newFakeAPI(t)
+	var inFlight, peak atomic.Int32
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f, func(cfg *ClientConfig) { cfg.CancelConcurrency = 3 })
+
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("o%d", i)
+	}
+	results, err := c.CancelOrdersBatch(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r.Index != i || r.OrderID != ids[i] || !r.Success {
+			t.Fatalf("result %d = %+v, want order %s cancelled", i, r, ids[i])
+		}
+	}
+	if n := peak.Load(); n != 3 {
+		t.Errorf("peak concurrent cancels = %d, want 3", n)
+	}
+}
+
+func TestCancelOrdersBatchStopsOnContext(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f, func(cfg *ClientConfig) { cfg.CancelConcurrency = 2 })
+
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("o%d", i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	results, err := c.CancelOrdersBatch(ctx, ids)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("got %d results, want %d", len(results), len(ids))
+	}
+	if !results[0].Success {
+		t.Errorf("first cancel = %+v, want it sent before the deadline", results[0])
+	}
+	if last := results[19]; last.Success || last.OrderID != "o19" || last.Index != 19 {
+		t.Errorf("last cancel = %+v, want it skipped", last)
+	}
+	if n := f.count("/order/cancel"); n >= len(ids) {
+		t.Errorf("sent %d cancels, want fewer than %d", n, len(ids))
+	}
+}
+
+func TestPlaceOrdersBatchConcurrent(t *testing.T) {
+	f := newFakeAPI(t)
+	f.rpc.allowance.Store(1 << 62)
+	f.rpc.approved.Store(true)
+	c := newTestClient(t, f, WithOrderConcurrency(4))
+
+	var orders []PlaceOrderDataInput
+	for i := 0; i < 12; i++ {
+		price := "0.5"
+		if i%3 == 1 {
+			price = "1.5" // out of range, rejected before it is sent
+		}
+		orders = append(orders, limitBuy(price, "10"))
+	}
+	results, err := c.PlaceOrdersBatch(context.Background(), orders, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		wantSuccess := i%3 != 1
+		if r.Index != i || r.Success != wantSuccess || r.Order.Price != orders[i].Price {
+			t.Errorf("result %d = index %d, success %v, price %s; want success %v", i, r.Index, r.Success, r.Order.Price, wantSuccess)
+		}
+	}
+	if n := f.count("/order"); n != 8 {
+		t.Errorf("placed %d orders, want 8", n)
+	}
+	if n := f.rpc.allowanceCalls.Load(); n != 2 {
+		t.Errorf("allowance read %d times, want trading enabled once", n)
+	}
+}
+
+func TestPlaceOrdersBatchStopsOnContext(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	orders := []PlaceOrderDataInput{limitBuy("0.5", "10"), limitBuy("0.5", "10")}
+	results, err := c.PlaceOrdersBatch(ctx, orders, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+	if len(results) != len(orders) {
+		t.Fatalf("got %d results, want %d", len(results), len(orders))
+	}
+	for i, r := range results {
+		if r.Index != i || r.Success || r.Error != context.Canceled.Error() {
+			t.Errorf("result %d = %+v, want it skipped with the context error", i, r)
+		}
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Errorf("placed %d orders after cancellation, want 0", n)
+	}
+}
+
+func TestCancelAllOrdersPartialFailure(t *testing.T) {
+	f := newFakeAPI(t)
+	serveOrders(f, 6)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		// One cancel at a time, so the latest body is this request's
+		if id := f.lastBody("/order/cancel")["order_id"]; id == "o2" || id == "o5" {
+			http.Error(w, "already filled", http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f, WithCancelConcurrency(1))
+
+	result, err := c.CancelAllOrders(nil, nil)
+	var partial *PartialFailureError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected PartialFailureError, got %v", err)
+	}
+	if result == nil || result.Cancelled != 4 || result.Failed != 2 {
+		t.Fatalf("result = %+v, want 4 cancelled and 2 failed", result)
+	}
+	if ids := partial.FailedOrderIDs(); len(ids) != 2 || ids[0] != "o2" || ids[1] != "o5" {
+		t.Errorf("failed orders = %v, want [o2 o5]", ids)
+	}
+	if partial.Total != 6 || !strings.Contains(err.Error(), "failed to cancel 2 of 6 orders") || !strings.Contains(err.Error(), "o2: ") {
+		t.Errorf("error = %q, total %d", err, partial.Total)
+	}
+	if partial.Err != nil {
+		t.Errorf("Err = %v, want nil when every cancel was sent", partial.Err)
+	}
+
+	serveOrders(f, 2)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	if _, err := c.CancelAllOrders(nil, nil); err != nil {
+		t.Errorf("CancelAllOrders with no failures = %v, want nil", err)
+	}
+}
+
+func TestPlaceOrderValidatesTokenBelongsToMarket(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handleMarket(`{"marketId":2,"status":2,"chainId":"56","quoteToken":"` + testQuoteToken + `","yesTokenId":"333","noTokenId":"444","conditionId":"cd"}`)
+	c := newTestClient(t, f)
+
+	for _, tokenID := range []string{"111", "222"} {
+		order := limitBuy("0.5", "10")
+		order.TokenID = tokenID
+		if _, err := c.PlaceOrder(context.Background(), order, false); err != nil {
+			t.Errorf("token %s of market 1: %v", tokenID, err)
+		}
+	}
+
+	// 333 is market 2's YES token
+	order := limitBuy("0.5", "10")
+	order.TokenID = "333"
+	var invalid *InvalidParamError
+	if _, err := c.PlaceOrder(context.Background(), order, false); !errors.As(err, &invalid) {
+		t.Fatalf("token from another market: expected InvalidParamError, got %v", err)
+	}
+	if n := f.count("/order"); n != 2 {
+		t.Fatalf("placed %d orders, want 2", n)
+	}
+
+	unchecked := newTestClient(t, f, WithTokenValidationDisabled())
+	if _, err := unchecked.PlaceOrder(context.Background(), order, false); err != nil {
+		t.Fatalf("with token validation disabled: %v", err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/user/auth", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("apikey") {
+		case "good-key":
+			io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+		case "revoked-key":
+			// The API rejects keys in the response code of an HTTP 200
+			io.WriteString(w, `{"code":401,"msg":"invalid apikey"}`)
+		case "busy-key":
+			io.WriteString(w, `{"code":500,"msg":"busy"}`)
+		default:
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		}
+	})
+
+	c := newTestClient(t, f, WithAPIKey("good-key"))
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("authorized ping: %v", err)
+	}
+
+	unauthorized := newTestClient(t, f, WithAPIKey("bad-key"))
+	if err := unauthorized.Ping(context.Background()); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("unauthorized ping = %v, want ErrInvalidAPIKey", err)
+	}
+
+	revoked := newTestClient(t, f, WithAPIKey("revoked-key"))
+	err := revoked.Ping(context.Background())
+	var apiErr *APIError
+	if !errors.Is(err, ErrInvalidAPIKey) || !errors.As(err, &apiErr) || apiErr.Code != 401 {
+		t.Errorf("ping with a rejected key = %v, want ErrInvalidAPIKey wrapping an APIError with code 401", err)
+	}
+	busy := newTestClient(t, f, WithAPIKey("busy-key"))
+	if err := busy.Ping(context.Background()); err == nil || errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("ping with another API error = %v, want it not to be ErrInvalidAPIKey", err)
+	}
+
+	unreachable := newTestClient(t, f, WithHost("http://127.0.0.1:1"))
+	if err := unreachable.Ping(context.Background()); !errors.Is(err, ErrAPIUnreachable) {
+		t.Errorf("unreachable ping = %v, want ErrAPIUnreachable", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Ping(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled ping = %v, want context.Canceled", err)
+	}
+}
+
+func TestNewClientVerifiesAuthAddress(t *testing.T) {
+	f := newFakeAPI(t)
+	config := testConfig(f)
+	config.VerifyAuthAddress = true
+	key, err := crypto.HexToECDSA(config.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	serveUserAuth(f, testMultiSig, signer)
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("matching addresses: %v", err)
+	}
+	c.Close()
+
+	serveUserAuth(f, testMultiSig, "0x3333333333333333333333333333333333333333")
+	if _, err := NewClient(config); !errors.Is(err, ErrAuthAddressMismatch) {
+		t.Errorf("another wallet = %v, want ErrAuthAddressMismatch", err)
+	}
+	serveUserAuth(f, "0x2222222222222222222222222222222222222222", "")
+	if _, err := NewClient(config); !errors.Is(err, ErrAuthAddressMismatch) {
+		t.Errorf("another multi-sig = %v, want ErrAuthAddressMismatch", err)
+	}
+
+	// The check is opt-in
+	config.VerifyAuthAddress = false
+	calls := f.count("/user/auth")
+	c, err = NewClient(config)
+	if err != nil {
+		t.Fatalf("without VerifyAuthAddress: %v", err)
+	}
+	c.Close()
+	if n := f.count("/user/auth"); n != calls {
+		t.Errorf("made %d auth requests without VerifyAuthAddress, want 0", n-calls)
+	}
+}
+
+func TestPlaceOrderRejectsMarketPastCutoff(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	serveCutoff := func(f *fakeAPI, cutoff time.Time) {
+		f.handleMarket(fmt.Sprintf(`{"marketId":1,"status":2,"chainId":"56","quoteToken":"%s","yesTokenId":"111","noTokenId":"222","conditionId":"ab","cutoffAt":%d}`, testQuoteToken, cutoff.Unix()))
+	}
+	clock := WithClock(func() time.Time { return now })
+
+	f := newFakeAPI(t)
+	serveCutoff(f, now.Add(-time.Minute))
+	c := newTestClient(t, f, clock)
+	var invalid *InvalidParamError
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); !errors.As(err, &invalid) || !strings.Contains(err.Error(), "stopped trading") {
+		t.Fatalf("order past the cutoff = %v, want an InvalidParamError", err)
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Fatalf("sent %d orders past the cutoff, want 0", n)
+	}
+
+	unchecked := newTestClient(t, f, clock, WithCutoffCheckDisabled())
+	if _, err := unchecked.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatalf("with the cutoff check disabled: %v", err)
+	}
+
+	f = newFakeAPI(t)
+	serveCutoff(f, now.Add(time.Hour))
+	c = newTestClient(t, f, clock)
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatalf("order before the cutoff: %v", err)
+	}
+}
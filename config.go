@@ -1,13 +1,22 @@
 package opinionclob
 
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // ChainID represents a blockchain chain ID
 type ChainID int
 
 const (
 	ChainIDBNBMainnet ChainID = 56 // BNB Chain (BSC) mainnet
+	ChainIDBNBTestnet ChainID = 97 // BNB Chain (BSC) testnet
 )
 
-// SupportedChainIDs lists all supported chain IDs
+// SupportedChainIDs lists all supported chain IDs. BNB testnet has no published
+// contract addresses, so it is only supported once registered with RegisterChain.
 var SupportedChainIDs = []ChainID{ChainIDBNBMainnet}
 
 // ContractAddresses holds contract addresses for each chain
@@ -26,3 +35,107 @@ var DefaultContractAddresses = map[ChainID]ContractAddresses{
 	},
 }
 
+// WSEndpoints maps chain IDs to their WebSocket endpoints, used when WSConfig.Endpoint
+// is empty. BNB testnet has no published endpoint; chains without an entry need one
+// added with RegisterWSEndpoint or WSConfig.Endpoint set explicitly.
+var WSEndpoints = map[ChainID]string{
+	ChainIDBNBMainnet: DefaultWSEndpoint,
+}
+
+// chainsMu guards SupportedChainIDs, DefaultContractAddresses and WSEndpoints
+var chainsMu sync.RWMutex
+
+// RegisterChain adds a chain to SupportedChainIDs and merges contracts into its default
+// contract addresses. Empty fields keep the chain's existing defaults.
+func RegisterChain(chainID ChainID, contracts ContractAddresses) error {
+	if chainID <= 0 {
+		return &InvalidParamError{Message: fmt.Sprintf("invalid chain_id: %d", chainID)}
+	}
+
+	addrs := []struct {
+		name  string
+		value string
+	}{
+		{"conditional_tokens", contracts.ConditionalTokens},
+		{"multisend", contracts.Multisend},
+		{"fee_manager", contracts.FeeManager},
+	}
+	for _, addr := range addrs {
+		if addr.value != "" && !common.IsHexAddress(addr.value) {
+			return &InvalidParamError{Message: fmt.Sprintf("%s must be a hex address, got: %q", addr.name, addr.value)}
+		}
+	}
+
+	chainsMu.Lock()
+	defer chainsMu.Unlock()
+
+	if !isChainSupported(chainID) {
+		SupportedChainIDs = append(SupportedChainIDs, chainID)
+	}
+
+	merged := DefaultContractAddresses[chainID]
+	if contracts.ConditionalTokens != "" {
+		merged.ConditionalTokens = contracts.ConditionalTokens
+	}
+	if contracts.Multisend != "" {
+		merged.Multisend = contracts.Multisend
+	}
+	if contracts.FeeManager != "" {
+		merged.FeeManager = contracts.FeeManager
+	}
+	DefaultContractAddresses[chainID] = merged
+
+	return nil
+}
+
+// RegisterWSEndpoint sets the WebSocket endpoint used for a chain when WSConfig.Endpoint
+// is empty. The endpoint must be a ws:// or wss:// URL.
+func RegisterWSEndpoint(chainID ChainID, endpoint string) error {
+	if chainID <= 0 {
+		return &InvalidParamError{Message: fmt.Sprintf("invalid chain_id: %d", chainID)}
+	}
+	if err := ValidateWSEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	chainsMu.Lock()
+	defer chainsMu.Unlock()
+
+	WSEndpoints[chainID] = endpoint
+	return nil
+}
+
+// lookupChain reports whether a chain is supported and returns its default contract addresses
+func lookupChain(chainID ChainID) (ContractAddresses, bool) {
+	chainsMu.RLock()
+	defer chainsMu.RUnlock()
+
+	return DefaultContractAddresses[chainID], isChainSupported(chainID)
+}
+
+// lookupWSEndpoint returns the WebSocket endpoint registered for a chain
+func lookupWSEndpoint(chainID ChainID) (string, bool) {
+	chainsMu.RLock()
+	defer chainsMu.RUnlock()
+
+	endpoint, ok := WSEndpoints[chainID]
+	return endpoint, ok
+}
+
+// supportedChainIDs returns a copy of SupportedChainIDs
+func supportedChainIDs() []ChainID {
+	chainsMu.RLock()
+	defer chainsMu.RUnlock()
+
+	return append([]ChainID(nil), SupportedChainIDs...)
+}
+
+// isChainSupported checks SupportedChainIDs; callers must hold chainsMu
+func isChainSupported(chainID ChainID) bool {
+	for _, supportedID := range SupportedChainIDs {
+		if chainID == supportedID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// feeManagerUsed returns the contract c reads fee rates from
+func feeManagerUsed(t *testing.T, c *Client, f *fakeAPI) string {
+	t.Helper()
+	if _, err := c.GetFeeRates(context.Background(), 111); err != nil {
+		t.Fatal(err)
+	}
+	to, _ := f.rpc.feeTo.Load().(string)
+	return to
+}
+
+func TestClientUsesChainContractAddresses(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	if c.chainID != ChainIDBNBMainnet {
+		t.Fatalf("chain ID = %d, want %d", c.chainID, ChainIDBNBMainnet)
+	}
+	want := DefaultContractAddresses[ChainIDBNBMainnet].FeeManager
+	if got := feeManagerUsed(t, c, f); !strings.EqualFold(got, want) {
+		t.Fatalf("fee manager = %s, want %s", got, want)
+	}
+}
+
+func TestTestnetRequiresRegistration(t *testing.T) {
+	f := newFakeAPI(t)
+	config := testConfig(f)
+	config.ChainID = ChainIDBNBTestnet
+
+	var invalid *InvalidParamError
+	if _, err := NewClient(config); !errors.As(err, &invalid) || !strings.Contains(err.Error(), "chain_id must be one of") {
+		t.Fatalf("NewClient on unregistered testnet error = %v, want unsupported chain", err)
+	}
+}
+
+func TestRegisterChain(t *testing.T) {
+	const chainID ChainID = 4321
+	if err := RegisterChain(chainID, ContractAddresses{ConditionalTokens: "nothex"}); err == nil {
+		t.Fatal("RegisterChain accepted a non-hex address")
+	}
+	if err := RegisterChain(chainID, ContractAddresses{ConditionalTokens: "0x0000000000000000000000000000000000000009"}); err != nil {
+		t.Fatal(err)
+	}
+	// Later registrations merge into the chain's defaults
+	if err := RegisterChain(chainID, ContractAddresses{Multisend: "0x0000000000000000000000000000000000000008"}); err != nil {
+		t.Fatal(err)
+	}
+	addrs, ok := lookupChain(chainID)
+	if !ok || addrs.ConditionalTokens == "" || addrs.Multisend == "" || addrs.FeeManager != "" {
+		t.Fatalf("lookupChain = %+v, %v", addrs, ok)
+	}
+
+	f := newFakeAPI(t)
+	config := testConfig(f)
+	config.ChainID = chainID
+	if _, err := NewClient(config); err == nil || !strings.Contains(err.Error(), "fee_manager_addr is required for chain_id 4321") {
+		t.Fatalf("NewClient without a fee manager error = %v", err)
+	}
+
+	config.FeeManagerAddr = "0x0000000000000000000000000000000000000007"
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if c.chainID != chainID {
+		t.Fatalf("chain ID = %d, want %d", c.chainID, chainID)
+	}
+	if got := feeManagerUsed(t, c, f); !strings.EqualFold(got, config.FeeManagerAddr) {
+		t.Fatalf("fee manager = %s, want %s", got, config.FeeManagerAddr)
+	}
+
+	// Read-only clients need no contract addresses
+	readOnly, err := NewClient(ClientConfig{Host: f.srv.URL, APIKey: "test-key", ChainID: chainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readOnly.Close()
+}
+
+func TestRegisterChainMergesDefaults(t *testing.T) {
+	want, _ := lookupChain(ChainIDBNBMainnet)
+	var invalid *InvalidParamError
+	if err := RegisterChain(ChainIDBNBMainnet, ContractAddresses{FeeManager: "0x12"}); !errors.As(err, &invalid) || !strings.Contains(err.Error(), "fee_manager") {
+		t.Errorf("RegisterChain with a short fee manager = %v, want a fee_manager InvalidParamError", err)
+	}
+	if err := RegisterChain(ChainIDBNBMainnet, ContractAddresses{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := lookupChain(ChainIDBNBMainnet); got != want {
+		t.Errorf("re-registering mainnet without addresses changed its defaults to %+v, want %+v", got, want)
+	}
+}
+
+func TestWSEndpointPerChain(t *testing.T) {
+	if ws := NewWSClient(WSConfig{ChainID: ChainIDBNBMainnet}); ws.config.Endpoint != DefaultWSEndpoint {
+		t.Errorf("mainnet endpoint = %q, want %q", ws.config.Endpoint, DefaultWSEndpoint)
+	}
+
+	const chainID ChainID = 4322
+	var invalid *InvalidParamError
+	if err := NewWSClient(WSConfig{ChainID: chainID}).Connect(context.Background()); !errors.As(err, &invalid) {
+		t.Errorf("Connect on a chain without an endpoint: expected InvalidParamError, got %v", err)
+	}
+	if err := RegisterWSEndpoint(chainID, "https://ws.example.com"); !errors.As(err, &invalid) {
+		t.Errorf("RegisterWSEndpoint with https: expected InvalidParamError, got %v", err)
+	}
+	if err := RegisterWSEndpoint(chainID, "wss://ws.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if ws := NewWSClient(WSConfig{ChainID: chainID}); ws.config.Endpoint != "wss://ws.example.com" {
+		t.Errorf("registered endpoint = %q, want wss://ws.example.com", ws.config.Endpoint)
+	}
+	if ws := NewWSClient(WSConfig{ChainID: chainID, Endpoint: "ws://override"}); ws.config.Endpoint != "ws://override" {
+		t.Errorf("explicit endpoint = %q, want ws://override", ws.config.Endpoint)
+	}
+
+	if err := NewWSClient(WSConfig{Endpoint: "http://ws.example.com"}).Connect(context.Background()); !errors.As(err, &invalid) {
+		t.Errorf("Connect to an http endpoint: expected InvalidParamError, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// CostBasisMethod selects how sells are matched against earlier buys
+type CostBasisMethod int
+
+const (
+	// CostBasisAverage values every held share at the running average purchase price
+	CostBasisAverage CostBasisMethod = iota
+	// CostBasisFIFO matches sells against the oldest remaining buys first
+	CostBasisFIFO
+)
+
+// CostBasis is the cost basis of one outcome token reconstructed from trade history
+type CostBasis struct {
+	TokenID       string
+	MarketID      int
+	OutcomeSide   int
+	Method        CostBasisMethod
+	Shares        *big.Rat // shares still held according to the trade history
+	AvgEntryPrice *big.Rat // CostBasis / Shares; 0 if nothing is held
+	CostBasis     *big.Rat // purchase cost of the held shares
+	RealizedPnL   *big.Rat // profit locked in by sells, net of fees
+	Fees          *big.Rat
+}
+
+// ComputeCostBasis reconstructs the cost basis and realized PnL of an outcome token of a
+// market from the user's trade history in that market, independent of the positions API
+func (c *Client) ComputeCostBasis(ctx context.Context, marketID int, tokenID string, method CostBasisMethod) (*CostBasis, error) {
+	if tokenID == "" {
+		return nil, &InvalidParamError{Message: "token_id is required"}
+	}
+	if method != CostBasisAverage && method != CostBasisFIFO {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid cost basis method: %d", method)}
+	}
+
+	// Trades carry an outcome side rather than a token ID
+	market, err := c.GetMarket(marketID, true)
+	if err != nil {
+		return nil, err
+	}
+	var outcomeSide int
+	switch tokenID {
+	case market.YesTokenID:
+		outcomeSide = OutcomeSideYes
+	case market.NoTokenID:
+		outcomeSide = OutcomeSideNo
+	default:
+		return nil, &InvalidParamError{Message: fmt.Sprintf("token %s is not an outcome of market %d", tokenID, marketID)}
+	}
+
+	trades, err := c.allMyTrades(ctx, &marketID, time.Time{}, func(t *Trade) bool {
+		return t.OutcomeSide == outcomeSide
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := newCostLedger(method)
+	for _, t := range sortTradesByTime(trades) {
+		if err := ledger.apply(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return &CostBasis{
+		TokenID:       tokenID,
+		MarketID:      marketID,
+		OutcomeSide:   outcomeSide,
+		Method:        method,
+		Shares:        ledger.shares,
+		AvgEntryPrice: ledger.avgEntryPrice(),
+		CostBasis:     ledger.cost,
+		RealizedPnL:   ledger.realized,
+		Fees:          ledger.fees,
+	}, nil
+}
+
+// costLot is shares bought together at one price
+type costLot struct {
+	shares *big.Rat
+	price  *big.Rat
+}
+
+// costLedger tracks the holding of one outcome as its trades are applied in chronological
+// order, matching sells against earlier buys by method
+type costLedger struct {
+	method   CostBasisMethod
+	shares   *big.Rat
+	cost     *big.Rat // purchase cost of the held shares
+	realized *big.Rat // net of fees
+	fees     *big.Rat
+	lots     []costLot // FIFO only, oldest first
+}
+
+// newCostLedger returns an empty ledger
+func newCostLedger(method CostBasisMethod) *costLedger {
+	return &costLedger{
+		method:   method,
+		shares:   new(big.Rat),
+		cost:     new(big.Rat),
+		realized: new(big.Rat),
+		fees:     new(big.Rat),
+	}
+}
+
+// apply adds one trade to the ledger
+func (l *costLedger) apply(t Trade) error {
+	amounts, err := t.Amounts()
+	if err != nil {
+		return err
+	}
+	price, shares := amounts.Price, amounts.Shares
+
+	l.fees.Add(l.fees, amounts.Fee)
+	l.realized.Sub(l.realized, amounts.Fee)
+
+	switch t.Side {
+	case OrderSideBuy:
+		l.shares.Add(l.shares, shares)
+		l.cost.Add(l.cost, new(big.Rat).Mul(shares, price))
+		if l.method == CostBasisFIFO {
+			l.lots = append(l.lots, costLot{shares: shares, price: price})
+		}
+	case OrderSideSell:
+		// Shares sold beyond the tracked holding (e.g. obtained by split) carry no cost basis
+		if shares.Cmp(l.shares) > 0 {
+			excess := new(big.Rat).Sub(shares, l.shares)
+			l.realized.Add(l.realized, excess.Mul(excess, price))
+			shares = new(big.Rat).Set(l.shares)
+		}
+		if shares.Sign() <= 0 {
+			break
+		}
+
+		cost := l.sellCost(shares)
+		proceeds := new(big.Rat).Mul(shares, price)
+		l.realized.Add(l.realized, proceeds.Sub(proceeds, cost))
+		l.cost.Sub(l.cost, cost)
+		l.shares.Sub(l.shares, shares)
+		if l.shares.Sign() <= 0 {
+			l.shares, l.cost, l.lots = new(big.Rat), new(big.Rat), nil
+		}
+	default:
+		return &OpenAPIError{Message: fmt.Sprintf("invalid side in trade %s: %d", t.TradeNo, t.Side)}
+	}
+	return nil
+}
+
+// sellCost returns the cost basis of shares sold out of the holding
+func (l *costLedger) sellCost(shares *big.Rat) *big.Rat {
+	if l.method != CostBasisFIFO {
+		cost := new(big.Rat).Mul(shares, l.cost)
+		return cost.Quo(cost, l.shares)
+	}
+
+	cost := new(big.Rat)
+	remaining := new(big.Rat).Set(shares)
+	for remaining.Sign() > 0 && len(l.lots) > 0 {
+		lot := l.lots[0]
+		take := lot.shares
+		if take.Cmp(remaining) > 0 {
+			take = remaining
+		}
+		take = new(big.Rat).Set(take)
+		cost.Add(cost, new(big.Rat).Mul(take, lot.price))
+		lot.shares.Sub(lot.shares, take)
+		remaining.Sub(remaining, take)
+		if lot.shares.Sign() <= 0 {
+			l.lots = l.lots[1:]
+		}
+	}
+	return cost
+}
+
+// avgEntryPrice returns the average cost per held share, or 0 if nothing is held
+func (l *costLedger) avgEntryPrice() *big.Rat {
+	if l.shares.Sign() <= 0 {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Quo(l.cost, l.shares)
+}
+
+// sortTradesByTime returns a copy of trades in chronological order
+func sortTradesByTime(trades []Trade) []Trade {
+	sorted := make([]Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt < sorted[j].CreatedAt
+	})
+	return sorted
+}
@@ -0,0 +1,68 @@
+package opinionclob
+
+import (
+	"context"
+	"testing"
+)
+
+// serveCostBasisTrades serves buys of 10 YES at 0.4 and 10 at 0.6, a partial close of 15 at
+// 0.7 with a 0.1 fee, and a buy of 4 NO at 0.3, out of chronological order
+func serveCostBasisTrades(f *fakeAPI) {
+	serveTrades(f, `[
+		{"tradeNo":"3","marketId":1,"outcomeSide":1,"side":"Sell","price":"0.7","shares":"15","fee":"0.1","createdAt":3},
+		{"tradeNo":"1","marketId":1,"outcomeSide":1,"side":"Buy","price":"0.4","shares":"10","createdAt":1},
+		{"tradeNo":"2","marketId":1,"outcomeSide":1,"side":"Buy","price":"0.6","shares":"10","createdAt":2},
+		{"tradeNo":"4","marketId":1,"outcomeSide":2,"side":"Buy","price":"0.3","shares":"4","createdAt":4}
+	]`)
+}
+
+func TestComputeCostBasisAverage(t *testing.T) {
+	f := newFakeAPI(t)
+	serveCostBasisTrades(f)
+	c := newTestClient(t, f)
+
+	basis, err := c.ComputeCostBasis(context.Background(), 1, "111", CostBasisAverage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 15 sold at 0.7 against an average of 0.5: 3 profit less the fee
+	if basis.MarketID != 1 || basis.OutcomeSide != OutcomeSideYes || !ratIs(basis.Shares, "5") || !ratIs(basis.AvgEntryPrice, "0.5") || !ratIs(basis.CostBasis, "2.5") || !ratIs(basis.RealizedPnL, "2.9") {
+		t.Fatalf("got %+v", basis)
+	}
+}
+
+func TestComputeCostBasisFIFO(t *testing.T) {
+	f := newFakeAPI(t)
+	serveCostBasisTrades(f)
+	c := newTestClient(t, f)
+
+	basis, err := c.ComputeCostBasis(context.Background(), 1, "111", CostBasisFIFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10 sold against the 0.4 lot and 5 against the 0.6 lot, leaving 5 at 0.6
+	if !ratIs(basis.Shares, "5") || !ratIs(basis.AvgEntryPrice, "0.6") || !ratIs(basis.RealizedPnL, "3.4") || !ratIs(basis.Fees, "0.1") {
+		t.Fatalf("got %+v", basis)
+	}
+
+	no, err := c.ComputeCostBasis(context.Background(), 1, "222", CostBasisFIFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if no.OutcomeSide != OutcomeSideNo || !ratIs(no.Shares, "4") || !ratIs(no.AvgEntryPrice, "0.3") {
+		t.Fatalf("got %+v", no)
+	}
+}
+
+func TestComputeCostBasisRejectsForeignToken(t *testing.T) {
+	f := newFakeAPI(t)
+	serveCostBasisTrades(f)
+	c := newTestClient(t, f)
+
+	if _, err := c.ComputeCostBasis(context.Background(), 1, "999", CostBasisAverage); err == nil {
+		t.Fatal("expected an error for a token outside the market")
+	}
+	if n := f.count("/trade"); n != 0 {
+		t.Fatalf("fetched trades %d times, want 0", n)
+	}
+}
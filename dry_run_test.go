@@ -0,0 +1,88 @@
+package opinionclob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// goldenKey is a well-known test key; never fund it
+const goldenKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestPlaceOrderDryRunGolden(t *testing.T) {
+	f := newFakeAPI(t)
+	dryRun := func() []byte {
+		c := newTestClient(t, f,
+			WithPrivateKey(goldenKey),
+			WithOrderParamsProvider(FixedOrderParamsProvider{Salt: "42", Nonce: "0", Expiration: "0"}),
+			WithClock(func() time.Time { return time.Unix(1700000000, 0) }),
+		)
+		body, err := c.PlaceOrderDryRun(limitBuy("0.5", "10"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return body
+	}
+
+	got := dryRun()
+	if again := dryRun(); !bytes.Equal(got, again) {
+		t.Fatalf("dry run is not deterministic:\n%s\n%s", got, again)
+	}
+
+	golden := filepath.Join("testdata", "place_order_dry_run.golden")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("signed order request differs from %s:\ngot  %s\nwant %s", golden, got, want)
+	}
+}
+
+func TestBuildSignedOrderMatchesPlaceOrder(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f,
+		WithOrderParamsProvider(FixedOrderParamsProvider{Salt: "42", Nonce: "0", Expiration: "0"}),
+		WithClock(func() time.Time { return time.Unix(1700000000, 0) }),
+	)
+
+	signed, payload, err := c.BuildSignedOrder(context.Background(), limitBuy("0.5", "10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed.Signature == "" {
+		t.Fatal("order was not signed")
+	}
+	if n := f.count("/order"); n != 0 {
+		t.Fatalf("BuildSignedOrder sent %d orders, want 0", n)
+	}
+
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	// Compare after a JSON round trip, as the fake API decodes what was sent
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal(encoded, &want); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.lastBody("/order"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("PlaceOrder sent %v, want the built payload %v", got, want)
+	}
+}
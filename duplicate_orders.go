@@ -0,0 +1,83 @@
+package opinionclob
+
+import (
+	"context"
+	"math/big"
+	"sort"
+)
+
+// DuplicateOrderGroup is a set of open orders resting on the same token, side and price
+type DuplicateOrderGroup struct {
+	TokenID string
+	Side    OrderSide
+	Price   string        // price of the oldest order in the group
+	Orders  []OrderRecord // oldest first
+}
+
+// duplicateKey identifies orders that rest at the same level
+type duplicateKey struct {
+	tokenID string
+	side    OrderSide
+	price   string // normalized so "0.5" and "0.50" match
+}
+
+// FindDuplicateOrders fetches the user's open orders in a market (or all markets if
+// marketID is 0) and returns the groups of two or more orders resting on the same token,
+// side and price. Orders in a group are oldest first, so callers that keep one order per
+// level can cancel Orders[1:].
+func (c *Client) FindDuplicateOrders(ctx context.Context, marketID int) ([]DuplicateOrderGroup, error) {
+	groups := make(map[duplicateKey][]OrderRecord)
+
+	orders := c.IterateMyOrders(marketID, OrderStatusFilterOpen)
+	for {
+		order, ok, err := orders.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		key := duplicateKey{tokenID: order.TokenID, side: order.Side, price: normalizePrice(order.Price)}
+		groups[key] = append(groups[key], *order)
+	}
+
+	var duplicates []DuplicateOrderGroup
+	for key, group := range groups {
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool { return group[i].CreatedAt < group[j].CreatedAt })
+		duplicates = append(duplicates, DuplicateOrderGroup{
+			TokenID: key.tokenID,
+			Side:    key.side,
+			Price:   group[0].Price,
+			Orders:  group,
+		})
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		a, b := duplicates[i], duplicates[j]
+		if a.TokenID != b.TokenID {
+			return a.TokenID < b.TokenID
+		}
+		if a.Side != b.Side {
+			return a.Side < b.Side
+		}
+		if a.Orders[0].CreatedAt != b.Orders[0].CreatedAt {
+			return a.Orders[0].CreatedAt < b.Orders[0].CreatedAt
+		}
+		return a.Price < b.Price
+	})
+
+	return duplicates, nil
+}
+
+// normalizePrice returns a canonical form of a decimal price, or the input if it does not parse
+func normalizePrice(price string) string {
+	r, ok := new(big.Rat).SetString(price)
+	if !ok {
+		return price
+	}
+	return r.RatString()
+}
@@ -0,0 +1,45 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicateOrders(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":0,"result":{"total":6,"list":[
+			{"orderId":"a","tokenId":"111","side":0,"price":"0.5","createdAt":3},
+			{"orderId":"b","tokenId":"111","side":0,"price":"0.50","createdAt":1},
+			{"orderId":"c","tokenId":"111","side":1,"price":"0.5","createdAt":2},
+			{"orderId":"d","tokenId":"222","side":0,"price":"0.4","createdAt":5},
+			{"orderId":"e","tokenId":"222","side":0,"price":"0.4","createdAt":4},
+			{"orderId":"f","tokenId":"222","side":0,"price":"0.4","createdAt":6}]}}`)
+	})
+	c := newTestClient(t, f)
+
+	groups, err := c.FindDuplicateOrders(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2: %+v", len(groups), groups)
+	}
+
+	ids := func(orders []OrderRecord) string {
+		var s []string
+		for _, o := range orders {
+			s = append(s, o.OrderID)
+		}
+		return strings.Join(s, ",")
+	}
+	if g := groups[0]; g.TokenID != "111" || g.Side != OrderSideBuy || g.Price != "0.50" || ids(g.Orders) != "b,a" {
+		t.Errorf("first group = %s %v %s [%s], want 111 BUY 0.50 [b,a]", g.TokenID, g.Side, g.Price, ids(g.Orders))
+	}
+	if g := groups[1]; g.TokenID != "222" || ids(g.Orders) != "e,d,f" {
+		t.Errorf("second group = %s [%s], want 222 [e,d,f]", g.TokenID, ids(g.Orders))
+	}
+}
@@ -1,6 +1,11 @@
 package opinionclob
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 var (
 	// ErrInvalidParam represents an invalid parameter error
@@ -17,6 +22,37 @@ var (
 	
 	// ErrInsufficientGasBalance represents insufficient gas balance error
 	ErrInsufficientGasBalance = errors.New("insufficient gas balance")
+	
+	// ErrReadOnly is returned by signing and on-chain operations of a client without a private key
+	ErrReadOnly = errors.New("client is read-only: PrivateKey and RPCURL are required for trading and chain operations")
+	
+	// ErrExchangePaused is returned when placing an order on a paused exchange contract
+	ErrExchangePaused = errors.New("exchange is paused")
+	
+	// ErrOrderNotAccepted is returned when a placed order cannot be confirmed by reading it back
+	ErrOrderNotAccepted = errors.New("order not accepted")
+	
+	// ErrExchangeNotContract is returned when an exchange address reported by the API has no contract code
+	ErrExchangeNotContract = errors.New("exchange address has no contract code")
+	
+	// ErrClientClosed is returned by API requests and background operations started after Close
+	ErrClientClosed = errors.New("client is closed")
+	
+	// ErrWSClosed is returned by Connect on a WebSocket client that has been closed
+	ErrWSClosed = errors.New("websocket client is closed")
+	
+	// ErrInvalidAPIKey is returned by Ping when the API rejects the API key
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	
+	// ErrAPIUnreachable is returned by Ping when the API cannot be reached
+	ErrAPIUnreachable = errors.New("API unreachable")
+	
+	// ErrAuthAddressMismatch is returned by CheckAuthAddress when the API key belongs to a
+	// different signer or multi-sig wallet than the client is configured with
+	ErrAuthAddressMismatch = errors.New("API key does not belong to the configured account")
+	
+	// ErrOrderNotFound is returned by CancelByClientOrderID when no open order has the client order ID
+	ErrOrderNotFound = errors.New("order not found")
 )
 
 // InvalidParamError represents an invalid parameter error with context
@@ -37,3 +73,65 @@ func (e *OpenAPIError) Error() string {
 	return e.Message
 }
 
+// APIError is a request the API answered with a non-200 status or a non-zero code.
+// RequestID is the X-Request-ID the request was sent with, for matching it to backend logs.
+type APIError struct {
+	StatusCode int // HTTP status; zero when the API reported the error in its response code
+	Code       int // API response code; zero for HTTP errors
+	Message    string
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	msg := "API error: " + e.Message
+	if e.StatusCode != 0 {
+		msg = fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	}
+	if e.RequestID != "" {
+		msg += " (request ID: " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// newAPIError returns the error for a response that reported a non-zero code
+func newAPIError(resp *http.Response, code int, msg string) *APIError {
+	return &APIError{Code: code, Message: msg, RequestID: requestID(resp)}
+}
+
+// CancelFailure is an order that could not be cancelled, and why
+type CancelFailure struct {
+	OrderID string
+	Reason  string
+}
+
+// PartialFailureError is returned alongside the result of a bulk cancel when some of
+// its orders could not be cancelled
+type PartialFailureError struct {
+	Total    int // orders the cancel was attempted for
+	Failures []CancelFailure
+	// Err is the context error if the cancel stopped early, in which case the orders
+	// never sent are among Failures
+	Err error
+}
+
+func (e *PartialFailureError) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		reasons[i] = f.OrderID + ": " + f.Reason
+	}
+	return fmt.Sprintf("failed to cancel %d of %d orders: %s", len(e.Failures), e.Total, strings.Join(reasons, "; "))
+}
+
+// Unwrap returns the context error that stopped the cancel early, if any
+func (e *PartialFailureError) Unwrap() error {
+	return e.Err
+}
+
+// FailedOrderIDs returns the IDs of the orders that were not cancelled
+func (e *PartialFailureError) FailedOrderIDs() []string {
+	ids := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		ids[i] = f.OrderID
+	}
+	return ids
+}
@@ -0,0 +1,252 @@
+package opinionclob
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	testQuoteToken = "0x55d398326f99059fF775485246999027B3197955"
+	testExchange   = "0x5F45344126D6488025B0b84A3A8189F2487a7246"
+	testMultiSig   = "0x1111111111111111111111111111111111111111"
+)
+
+// fakeAPI is an Opinion API server that records requests. It serves one USDT quote token
+// and binary market 1 (tokens "111" and "222") and accepts every order by default.
+type fakeAPI struct {
+	srv      *httptest.Server
+	rpc      *fakeRPC
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	bodies   map[string][]map[string]interface{}
+	calls    map[string]int
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	f := &fakeAPI{
+		rpc:      newFakeRPC(t),
+		handlers: make(map[string]http.HandlerFunc),
+		bodies:   make(map[string][]map[string]interface{}),
+		calls:    make(map[string]int),
+	}
+	f.handle("/quoteToken", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":1,"list":[{"id":1,"quoteTokenAddress":"`+testQuoteToken+`","ctfExchangeAddress":"`+testExchange+`","decimal":18,"symbol":"USDT","chainId":"56"}]}}`)
+	})
+	f.handleMarket(`{"marketId":1,"status":2,"chainId":"56","quoteToken":"` + testQuoteToken + `","yesTokenId":"111","noTokenId":"222","conditionId":"ab"}`)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"ord-1"}}}`)
+	})
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.calls[r.URL.Path]++
+		if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+			var m map[string]interface{}
+			json.Unmarshal(body, &m)
+			f.bodies[r.URL.Path] = append(f.bodies[r.URL.Path], m)
+		}
+		h := f.handlers[r.URL.Path]
+		f.mu.Unlock()
+		if h == nil {
+			http.NotFound(w, r)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+// handle serves path with h, replacing any earlier handler
+func (f *fakeAPI) handle(path string, h http.HandlerFunc) {
+	f.mu.Lock()
+	f.handlers[path] = h
+	f.mu.Unlock()
+}
+
+// handleMarket serves the market JSON object under /market/{marketId}
+func (f *fakeAPI) handleMarket(market string) {
+	var m struct {
+		MarketID int `json:"marketId"`
+	}
+	if err := json.Unmarshal([]byte(market), &m); err != nil {
+		panic(err)
+	}
+	f.handle(fmt.Sprintf("/market/%d", m.MarketID), func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"data":`+market+`}}`)
+	})
+}
+
+// count returns how many requests were made to path
+func (f *fakeAPI) count(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[path]
+}
+
+// lastBody returns the JSON body of the latest request to path, or nil
+func (f *fakeAPI) lastBody(path string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	bodies := f.bodies[path]
+	if len(bodies) == 0 {
+		return nil
+	}
+	return bodies[len(bodies)-1]
+}
+
+// bodyField returns the string field key of every JSON body sent to path, sorted
+func (f *fakeAPI) bodyField(path, key string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var values []string
+	for _, body := range f.bodies[path] {
+		if v, ok := body[key].(string); ok {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// fakeRPC is a JSON-RPC endpoint that answers eth_call with the exchange paused flag
+// and FeeManager rates, and every other call with a zero word
+type fakeRPC struct {
+	srv            *httptest.Server
+	paused         atomic.Bool
+	noCode         atomic.Bool // eth_getCode returns no code
+	makerBps       atomic.Int64
+	takerBps       atomic.Int64
+	feeCalls       atomic.Int32
+	feeTo          atomic.Value // string: contract the latest fee rate call went to
+	pauseCall      atomic.Int32
+	allowance      atomic.Int64 // every ERC20 allowance
+	allowanceCalls atomic.Int32
+	approved       atomic.Bool // isApprovedForAll
+}
+
+const (
+	pausedSelector         = "0x5c975abb" // paused()
+	feeRateSettingSelector = "0x27f68850" // getFeeRateSettings(uint256)
+	allowanceSelector      = "0xdd62ed3e" // allowance(address,address)
+	approvedForAllSelector = "0xe985e9c5" // isApprovedForAll(address,address)
+)
+
+func newFakeRPC(t *testing.T) *fakeRPC {
+	f := &fakeRPC{}
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []struct {
+				To string `json:"to"`
+			} `json:"params"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &req)
+
+		word := func(v int64) string { return fmt.Sprintf("%064x", v) }
+		result := "0x" + word(0)
+		switch {
+		case strings.Contains(string(body), feeRateSettingSelector):
+			f.feeCalls.Add(1)
+			if len(req.Params) > 0 {
+				f.feeTo.Store(req.Params[0].To)
+			}
+			result = "0x" + word(f.makerBps.Load()) + word(f.takerBps.Load()) + word(1) + word(0)
+		case strings.Contains(string(body), pausedSelector):
+			f.pauseCall.Add(1)
+			if f.paused.Load() {
+				result = "0x" + word(1)
+			}
+		case strings.Contains(string(body), allowanceSelector):
+			f.allowanceCalls.Add(1)
+			result = "0x" + word(f.allowance.Load())
+		case strings.Contains(string(body), approvedForAllSelector):
+			if f.approved.Load() {
+				result = "0x" + word(1)
+			}
+		case req.Method == "eth_getCode" && f.noCode.Load():
+			result = "0x"
+		case req.Method == "eth_getBalance":
+			result = "0xde0b6b3a7640000" // 1 BNB
+		case req.Method == "eth_gasPrice":
+			result = "0x3b9aca00"
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"%s"}`, req.ID, result)
+	}))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+// testConfig returns a trading config for f with a fresh signing key
+func testConfig(f *fakeAPI) ClientConfig {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	return ClientConfig{
+		Host:         f.srv.URL,
+		APIKey:       "test-key",
+		ChainID:      ChainIDBNBMainnet,
+		RPCURL:       f.rpc.srv.URL,
+		PrivateKey:   hex.EncodeToString(crypto.FromECDSA(key)),
+		MultiSigAddr: testMultiSig,
+	}
+}
+
+// newTestClient returns a client for f, closed when the test ends
+func newTestClient(t *testing.T, f *fakeAPI, opts ...ClientOption) *Client {
+	t.Helper()
+	c, err := NewClientWithOptions(append([]ClientOption{WithConfig(testConfig(f))}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+// limitBuy is a limit BUY of amount quote tokens of token "111" in market 1
+func limitBuy(price, amount string) PlaceOrderDataInput {
+	return PlaceOrderDataInput{
+		MarketID:                1,
+		TokenID:                 "111",
+		Side:                    OrderSideBuy,
+		OrderType:               OrderTypeLimit,
+		Price:                   price,
+		MakerAmountInQuoteToken: &amount,
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+// servePages serves total list items in pages of the request's "limit" (default 20),
+// rendering item i (0-based) with item
+func (f *fakeAPI) servePages(path string, total int, item func(i int) string) {
+	f.handle(path, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if page < 1 {
+			page = 1
+		}
+		if limit < 1 {
+			limit = 20
+		}
+		var items []string
+		for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+			items = append(items, item(i))
+		}
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"total":%d,"list":[%s]}}`, total, strings.Join(items, ","))
+	})
+}
@@ -0,0 +1,146 @@
+package opinionclob
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeWSServer is a WebSocket server for exercising WSClient against real connections
+type fakeWSServer struct {
+	srv      *httptest.Server
+	mu       sync.Mutex
+	conns    []*websocket.Conn
+	queries  []string // raw query of each accepted connection
+	accepts  atomic.Int32
+	refuse   atomic.Bool // reject upgrades with 503
+	silent   atomic.Bool // accept connections but never read from them
+	compress atomic.Bool // negotiate permessage-deflate and compress what the server writes
+	onMsg    func(conn *websocket.Conn, data []byte)
+}
+
+func newFakeWSServer(t *testing.T) *fakeWSServer {
+	f := &fakeWSServer{}
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.refuse.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		upgrader := websocket.Upgrader{EnableCompression: f.compress.Load()}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.EnableWriteCompression(true)
+		f.accepts.Add(1)
+		f.mu.Lock()
+		f.conns = append(f.conns, conn)
+		f.queries = append(f.queries, r.URL.RawQuery)
+		onMsg := f.onMsg
+		f.mu.Unlock()
+		if f.silent.Load() {
+			return
+		}
+		go func() {
+			for {
+				_, data, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if onMsg != nil {
+					onMsg(conn, data)
+				}
+			}
+		}()
+	}))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeWSServer) url() string {
+	return "ws" + strings.TrimPrefix(f.srv.URL, "http")
+}
+
+// handleMessages sets the handler for messages read from connections accepted from now on
+func (f *fakeWSServer) handleMessages(fn func(conn *websocket.Conn, data []byte)) {
+	f.mu.Lock()
+	f.onMsg = fn
+	f.mu.Unlock()
+}
+
+// lastQuery returns the raw query of the most recently accepted connection
+func (f *fakeWSServer) lastQuery() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.queries) == 0 {
+		return ""
+	}
+	return f.queries[len(f.queries)-1]
+}
+
+// dropAll closes every accepted connection from the server side
+func (f *fakeWSServer) dropAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = nil
+}
+
+// waitFor polls cond until it holds, failing the test after five seconds
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met within 5s")
+}
+
+// reconnectAttempt returns the client's current reconnect attempt number
+func reconnectAttempt(ws *WSClient) int {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	return ws.reconnectAttempt
+}
+
+// broadcast writes data to every accepted connection
+func (f *fakeWSServer) broadcast(t *testing.T, data string) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.conns {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// ackSubscriptions makes the server acknowledge every SUBSCRIBE message, rejecting those
+// for which reject returns true
+func (f *fakeWSServer) ackSubscriptions(reject func(msg map[string]interface{}) bool) {
+	f.handleMessages(func(conn *websocket.Conn, data []byte) {
+		var msg map[string]interface{}
+		if json.Unmarshal(data, &msg) != nil || msg["action"] != ActionSubscribe {
+			return
+		}
+		msg["code"] = 0
+		if reject != nil && reject(msg) {
+			msg["code"] = 400
+			msg["msg"] = "rejected"
+		}
+		ack, _ := json.Marshal(msg)
+		conn.WriteMessage(websocket.TextMessage, ack)
+	})
+}
@@ -0,0 +1,35 @@
+// Package enumjson encodes the SDK's integer enums in JSON by name and decodes them from a
+// name, a number or a numeric string. It is shared by the root and chain packages.
+package enumjson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Encode encodes an enum value as its quoted name, or as the number n if known is false
+func Encode(name string, known bool, n int) []byte {
+	if !known {
+		return []byte(strconv.Itoa(n))
+	}
+	return []byte(strconv.Quote(name))
+}
+
+// Decode decodes an enum value sent as a number, a numeric string or one of names
+// (keys in lower case, matched case-insensitively). ok is false for null.
+func Decode(data []byte, kind string, names map[string]int) (n int, ok bool, err error) {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "null" {
+		return 0, false, nil
+	}
+	if n, found := names[strings.ToLower(raw)]; found {
+		return n, true, nil
+	}
+
+	n, err = strconv.Atoi(raw)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s: %s", kind, data)
+	}
+	return n, true, nil
+}
@@ -0,0 +1,34 @@
+package enumjson
+
+import "testing"
+
+func TestEncode(t *testing.T) {
+	if got := string(Encode("buy", true, 0)); got != `"buy"` {
+		t.Errorf("Encode known = %s, want \"buy\"", got)
+	}
+	if got := string(Encode("", false, 7)); got != "7" {
+		t.Errorf("Encode unknown = %s, want 7", got)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	names := map[string]int{"buy": 0, "sell": 1}
+	tests := []struct {
+		data    string
+		want    int
+		wantOK  bool
+		wantErr bool
+	}{
+		{`"SELL"`, 1, true, false},
+		{`1`, 1, true, false},
+		{`"5"`, 5, true, false},
+		{`null`, 0, false, false},
+		{`"hold"`, 0, false, true},
+	}
+	for _, tt := range tests {
+		n, ok, err := Decode([]byte(tt.data), "order side", names)
+		if n != tt.want || ok != tt.wantOK || (err != nil) != tt.wantErr {
+			t.Errorf("Decode(%s) = %d, %v, %v; want %d, %v, error %v", tt.data, n, ok, err, tt.want, tt.wantOK, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// localLevel is a maintained orderbook level with its parsed price
+type localLevel struct {
+	price *big.Rat
+	level OrderBookLevel
+}
+
+// LocalOrderbook maintains a token's orderbook from a GetOrderbook snapshot and
+// market.depth.diff updates. It is safe for concurrent use.
+//
+// Depth diffs carry no sequence number, so a missed or reordered update cannot be
+// detected directly. Instead the book marks itself stale when a diff is malformed or
+// leaves the book crossed, or when MarkStale is called. MarkStaleOnReconnect calls it
+// whenever the WebSocket feeding the book loses its connection, when diffs may be
+// missed. A stale book stays stale until Reset with a fresh snapshot;
+// Client.SyncLocalOrderbook does this automatically.
+type LocalOrderbook struct {
+	mu             sync.RWMutex
+	market         string
+	tokenID        string
+	timestamp      int64
+	bids           map[string]localLevel // keyed by normalized price
+	asks           map[string]localLevel
+	needsResync    bool
+	onResyncNeeded func()
+	resyncCh       chan struct{} // signalled when the book becomes stale
+}
+
+// NewLocalOrderbook creates a local orderbook seeded from a snapshot
+func NewLocalOrderbook(book *OrderBook) (*LocalOrderbook, error) {
+	lob := &LocalOrderbook{resyncCh: make(chan struct{}, 1)}
+	if err := lob.Reset(book); err != nil {
+		return nil, err
+	}
+	return lob, nil
+}
+
+// NewLocalOrderbook fetches a token's orderbook and returns a local copy to keep up to
+// date with ApplyDiff
+func (c *Client) NewLocalOrderbook(tokenID string) (*LocalOrderbook, error) {
+	book, err := c.GetOrderbook(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalOrderbook(book)
+}
+
+// Reset replaces the book's contents with a snapshot and clears the resync flag
+func (lob *LocalOrderbook) Reset(book *OrderBook) error {
+	if book == nil {
+		return &InvalidParamError{Message: "orderbook is required"}
+	}
+
+	bids, err := seedLevels(book.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := seedLevels(book.Asks)
+	if err != nil {
+		return err
+	}
+
+	lob.mu.Lock()
+	defer lob.mu.Unlock()
+	lob.market = book.Market
+	lob.tokenID = book.TokenID
+	lob.timestamp = book.Timestamp
+	lob.bids = bids
+	lob.asks = asks
+	lob.needsResync = false
+	// Drop a pending resync signal that this snapshot already answers
+	select {
+	case <-lob.resyncCh:
+	default:
+	}
+	return nil
+}
+
+// SyncLocalOrderbook keeps lob in step with the server until ctx is done: it resets the
+// book from GetOrderbook as soon as it becomes stale and, if interval is positive, also
+// every interval to bound any drift that went undetected. Failed fetches are logged and
+// retried after orderbookResyncRetryDelay. It also returns when the client is closed.
+func (c *Client) SyncLocalOrderbook(ctx context.Context, lob *LocalOrderbook, interval time.Duration) {
+	ctx, cancel := c.bindContext(ctx)
+	defer cancel()
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-lob.resyncCh:
+		case <-tick:
+		case <-retry:
+		}
+
+		retry = nil
+		if err := c.ResyncLocalOrderbook(lob); err != nil {
+			c.logger.Warn("orderbook resync failed", "tokenId", lob.TokenID(), "error", err)
+			retry = time.After(orderbookResyncRetryDelay)
+		}
+	}
+}
+
+// orderbookResyncRetryDelay is how long SyncLocalOrderbook waits before retrying a failed resync
+const orderbookResyncRetryDelay = time.Second
+
+// ResyncLocalOrderbook resets lob from a fresh GetOrderbook snapshot
+func (c *Client) ResyncLocalOrderbook(lob *LocalOrderbook) error {
+	book, err := c.GetOrderbook(lob.TokenID())
+	if err != nil {
+		return err
+	}
+	return lob.Reset(book)
+}
+
+// TokenID returns the token whose book this is
+func (lob *LocalOrderbook) TokenID() string {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+	return lob.tokenID
+}
+
+// seedLevels indexes snapshot levels by normalized price, skipping empty levels
+func seedLevels(raw []OrderBookLevel) (map[string]localLevel, error) {
+	levels := make(map[string]localLevel, len(raw))
+	for _, l := range raw {
+		price, size, err := parseLevel(l.Price, l.Size)
+		if err != nil {
+			return nil, err
+		}
+		if size.Sign() == 0 {
+			continue
+		}
+		levels[price.RatString()] = localLevel{price: price, level: l}
+	}
+	return levels, nil
+}
+
+// parseLevel parses a level's price and size, which must be non-negative decimals
+func parseLevel(priceStr, sizeStr string) (*big.Rat, *big.Rat, error) {
+	price, err := parseAmount("orderbook price", priceStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	size, err := parseAmount("orderbook size", sizeStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if price.Sign() <= 0 || size.Sign() < 0 {
+		return nil, nil, &InvalidParamError{Message: fmt.Sprintf("invalid orderbook level: price %s, size %s", priceStr, sizeStr)}
+	}
+	return price, size, nil
+}
+
+// SetOnResyncNeeded sets a callback run each time the book becomes stale. It is
+// called without the book's lock held, so it may read the book or call Reset.
+func (lob *LocalOrderbook) SetOnResyncNeeded(fn func()) {
+	lob.mu.Lock()
+	defer lob.mu.Unlock()
+	lob.onResyncNeeded = fn
+}
+
+// MarkStale flags the book as possibly missing updates until the next Reset
+func (lob *LocalOrderbook) MarkStale() {
+	lob.mu.Lock()
+	notify := lob.markStaleLocked()
+	lob.mu.Unlock()
+	notify()
+}
+
+// MarkStaleOnReconnect marks the book stale whenever ws loses its connection and again
+// once it is back, since diffs sent in between are lost and a snapshot taken while it
+// was down may miss some too. It returns a function that stops watching ws.
+func (lob *LocalOrderbook) MarkStaleOnReconnect(ws *WSClient) (stop func()) {
+	lost := false // listeners are called one at a time
+	return ws.AddStateListener(func(state WSState, err error) {
+		switch state {
+		case WSStateReconnecting, WSStateDisconnected, WSStateFailed:
+			lost = true
+			lob.MarkStale()
+		case WSStateConnected:
+			if lost {
+				lost = false
+				lob.MarkStale()
+			}
+		}
+	})
+}
+
+// markStaleLocked sets the resync flag and returns a function that reports the
+// transition once the lock is released
+func (lob *LocalOrderbook) markStaleLocked() func() {
+	if lob.needsResync {
+		return func() {}
+	}
+	lob.needsResync = true
+
+	select {
+	case lob.resyncCh <- struct{}{}:
+	default:
+	}
+
+	fn := lob.onResyncNeeded
+	if fn == nil {
+		return func() {}
+	}
+	return fn
+}
+
+// ApplyDiff applies a depth update: the level at the diff's price is set to its size,
+// and a size of 0 removes it. Diffs for other tokens are ignored. A malformed diff or
+// one that leaves the book crossed marks the book stale.
+func (lob *LocalOrderbook) ApplyDiff(diff *MarketDepthDiff) error {
+	lob.mu.Lock()
+	stale, err := lob.applyDiffLocked(diff)
+	notify := func() {}
+	if stale {
+		notify = lob.markStaleLocked()
+	}
+	lob.mu.Unlock()
+
+	notify()
+	return err
+}
+
+// applyDiffLocked applies a diff and reports whether it revealed a gap
+func (lob *LocalOrderbook) applyDiffLocked(diff *MarketDepthDiff) (bool, error) {
+	if diff.TokenID != lob.tokenID {
+		return false, nil
+	}
+
+	var levels map[string]localLevel
+	switch strings.ToLower(diff.Side) {
+	case "bids":
+		levels = lob.bids
+	case "asks":
+		levels = lob.asks
+	default:
+		return true, &OpenAPIError{Message: fmt.Sprintf("invalid depth diff side: %q", diff.Side)}
+	}
+
+	price, size, err := parseLevel(diff.Price, diff.Size)
+	if err != nil {
+		return true, err
+	}
+
+	key := price.RatString()
+	if size.Sign() == 0 {
+		delete(levels, key)
+	} else {
+		levels[key] = localLevel{price: price, level: OrderBookLevel{Price: diff.Price, Size: diff.Size}}
+	}
+
+	// A crossed book means an update was missed or applied out of order
+	bid, hasBid := bestLevel(lob.bids, true)
+	ask, hasAsk := bestLevel(lob.asks, false)
+	return hasBid && hasAsk && bid.price.Cmp(ask.price) >= 0, nil
+}
+
+// bestLevel returns the highest (high=true) or lowest priced level
+func bestLevel(levels map[string]localLevel, high bool) (localLevel, bool) {
+	var best localLevel
+	found := false
+	for _, l := range levels {
+		if !found {
+			best, found = l, true
+			continue
+		}
+		cmp := l.price.Cmp(best.price)
+		if (high && cmp > 0) || (!high && cmp < 0) {
+			best = l
+		}
+	}
+	return best, found
+}
+
+// NeedsResync reports whether the book is stale: it may have diverged and should be
+// Reset from a fresh snapshot
+func (lob *LocalOrderbook) NeedsResync() bool {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+	return lob.needsResync
+}
+
+// BestBid returns the highest bid level, if any
+func (lob *LocalOrderbook) BestBid() (OrderBookLevel, bool) {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+	l, ok := bestLevel(lob.bids, true)
+	return l.level, ok
+}
+
+// BestAsk returns the lowest ask level, if any
+func (lob *LocalOrderbook) BestAsk() (OrderBookLevel, bool) {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+	l, ok := bestLevel(lob.asks, false)
+	return l.level, ok
+}
+
+// Snapshot returns a copy of the book with bids sorted highest first and asks lowest first
+func (lob *LocalOrderbook) Snapshot() *OrderBook {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+	return &OrderBook{
+		Market:    lob.market,
+		TokenID:   lob.tokenID,
+		Timestamp: lob.timestamp,
+		Bids:      sortedLevels(lob.bids, true),
+		Asks:      sortedLevels(lob.asks, false),
+	}
+}
+
+// sortedLevels lists levels by price, highest first if high is set
+func sortedLevels(levels map[string]localLevel, high bool) []OrderBookLevel {
+	sorted := make([]localLevel, 0, len(levels))
+	for _, l := range levels {
+		sorted = append(sorted, l)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		cmp := sorted[i].price.Cmp(sorted[j].price)
+		if high {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	result := make([]OrderBookLevel, len(sorted))
+	for i, l := range sorted {
+		result[i] = l.level
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestLocalOrderbook seeds a book for token t with bids 0.4x10 and 0.45x5, and asks
+// 0.5x3 plus an empty 0.6 level
+func newTestLocalOrderbook(t *testing.T) *LocalOrderbook {
+	t.Helper()
+	lob, err := NewLocalOrderbook(&OrderBook{
+		TokenID: "t",
+		Bids:    []OrderBookLevel{{"0.4", "10"}, {"0.45", "5"}},
+		Asks:    []OrderBookLevel{{"0.5", "3"}, {"0.6", "0"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lob
+}
+
+func TestLocalOrderbookAppliesDiffs(t *testing.T) {
+	lob := newTestLocalOrderbook(t)
+	diffs := []MarketDepthDiff{
+		{TokenID: "t", Side: "bids", Price: "0.45", Size: "0"}, // removes a level
+		{TokenID: "t", Side: "bids", Price: "0.42", Size: "7"},
+		{TokenID: "t", Side: "asks", Price: "0.55", Size: "2"},
+		{TokenID: "t", Side: "asks", Price: "0.50", Size: "4"}, // same level as 0.5
+		{TokenID: "x", Side: "asks", Price: "0.1", Size: "4"},  // another token
+	}
+	for i := range diffs {
+		if err := lob.ApplyDiff(&diffs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	book := lob.Snapshot()
+	if bids := fmt.Sprint(book.Bids); bids != "[{0.42 7} {0.4 10}]" {
+		t.Errorf("bids %s", bids)
+	}
+	if asks := fmt.Sprint(book.Asks); asks != "[{0.50 4} {0.55 2}]" {
+		t.Errorf("asks %s", asks)
+	}
+	if bid, ok := lob.BestBid(); !ok || bid.Price != "0.42" {
+		t.Errorf("best bid %v", bid)
+	}
+	if ask, ok := lob.BestAsk(); !ok || ask.Price != "0.50" {
+		t.Errorf("best ask %v", ask)
+	}
+	if lob.NeedsResync() {
+		t.Error("book needs a resync after consistent diffs")
+	}
+}
+
+func TestLocalOrderbookFlagsResync(t *testing.T) {
+	lob := newTestLocalOrderbook(t)
+	seed := lob.Snapshot()
+
+	// A bid at the best ask crosses the book
+	lob.ApplyDiff(&MarketDepthDiff{TokenID: "t", Side: "bids", Price: "0.5", Size: "1"})
+	if !lob.NeedsResync() {
+		t.Fatal("crossed book not flagged")
+	}
+	if err := lob.Reset(seed); err != nil {
+		t.Fatal(err)
+	}
+	if lob.NeedsResync() {
+		t.Fatal("still flagged after Reset")
+	}
+
+	if err := lob.ApplyDiff(&MarketDepthDiff{TokenID: "t", Side: "mid", Price: "0.5", Size: "1"}); err == nil || !lob.NeedsResync() {
+		t.Fatalf("unknown side: err %v, needs resync %v", err, lob.NeedsResync())
+	}
+}
+
+func TestLocalOrderbookMarkedStaleOnReconnect(t *testing.T) {
+	server := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: server.url(), ReconnectInterval: 10 * time.Millisecond})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+
+	lob := newTestLocalOrderbook(t)
+	var resyncs atomic.Int32
+	lob.SetOnResyncNeeded(func() { resyncs.Add(1) })
+	stop := lob.MarkStaleOnReconnect(ws)
+	var reconnects atomic.Int32
+	ws.AddStateListener(func(state WSState, err error) {
+		if state == WSStateConnected {
+			reconnects.Add(1)
+		}
+	})
+
+	// Diffs are lost while the connection is down
+	server.refuse.Store(true)
+	server.dropAll()
+	waitFor(t, lob.NeedsResync)
+	seed := &OrderBook{TokenID: "t", Bids: []OrderBookLevel{{"0.4", "10"}}}
+	if err := lob.Reset(seed); err != nil {
+		t.Fatal(err)
+	}
+
+	// A snapshot taken while it was down may miss some too
+	server.refuse.Store(false)
+	waitFor(t, ws.IsConnected)
+	waitFor(t, lob.NeedsResync)
+	if n := resyncs.Load(); n != 2 {
+		t.Fatalf("resync needed %d times, want 2", n)
+	}
+
+	stop()
+	lob.Reset(seed)
+	server.dropAll()
+	waitFor(t, func() bool { return reconnects.Load() == 2 })
+	if lob.NeedsResync() {
+		t.Fatal("book marked stale after stop")
+	}
+}
+
+func TestSyncLocalOrderbookResyncsStaleBook(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/token/orderbook", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"tokenId":"t","bids":[{"price":"0.41","size":"9"}],"asks":[{"price":"0.5","size":"3"}]}}`)
+	})
+	c := newTestClient(t, f)
+	lob := newTestLocalOrderbook(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.SyncLocalOrderbook(ctx, lob, 0)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// A dropped diff that removed the 0.5 ask leaves a bid crossing it
+	lob.ApplyDiff(&MarketDepthDiff{TokenID: "t", Side: "bids", Price: "0.55", Size: "1"})
+	waitFor(t, func() bool {
+		bid, _ := lob.BestBid()
+		return !lob.NeedsResync() && bid.Price == "0.41"
+	})
+	if n := f.count("/token/orderbook"); n != 1 {
+		t.Fatalf("fetched the orderbook %d times, want 1", n)
+	}
+}
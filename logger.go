@@ -0,0 +1,20 @@
+package opinionclob
+
+import (
+	"github.com/kaifufi/opinion-labs-sdk-go/chain"
+)
+
+// Logger receives structured log events from the API, WebSocket and chain layers.
+// keysAndValues are alternating field names and values, so a *slog.Logger can be used directly.
+type Logger = chain.Logger
+
+// NopLogger discards all log events; it is the default logger
+type NopLogger = chain.NopLogger
+
+// loggerOrNop returns l, or a NopLogger if l is nil
+func loggerOrNop(l Logger) Logger {
+	if l == nil {
+		return NopLogger{}
+	}
+	return l
+}
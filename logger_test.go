@@ -0,0 +1,59 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var _ Logger = slog.Default()
+
+// recordingLogger keeps every event as "LEVEL msg key=value ..."
+type recordingLogger struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *recordingLogger) record(level, msg string, keysAndValues []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	line := level + " " + msg
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		line += fmt.Sprintf(" %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	l.events = append(l.events, line)
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...interface{}) { l.record("DEBUG", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...interface{})  { l.record("INFO", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...interface{})  { l.record("WARN", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...interface{}) { l.record("ERROR", msg, kv) }
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.events, "\n")
+}
+
+func TestLoggerReceivesOrderEvents(t *testing.T) {
+	f := newFakeAPI(t)
+	logger := &recordingLogger{}
+	c := newTestClient(t, f, WithLogger(logger))
+
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := logger.String()
+	for _, want := range []string{"DEBUG api request method=POST endpoint=/order", "DEBUG placing order", "INFO order placed orderId=ord-1", "[REDACTED]"} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("missing %q in logs:\n%s", want, logs)
+		}
+	}
+	if strings.Contains(logs, `"signature":"0x`) {
+		t.Errorf("order signature leaked into logs:\n%s", logs)
+	}
+}
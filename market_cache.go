@@ -0,0 +1,118 @@
+package opinionclob
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// ClearMarketCache drops all cached markets so the next GetMarket calls refetch them
+func (c *Client) ClearMarketCache() {
+	c.marketCache.clear()
+}
+
+// InvalidateMarket drops a market from the cache so the next GetMarket call refetches it.
+// The client does this itself after orders, cancels, splits, merges and redeems unless
+// ClientConfig.DisableCacheInvalidation is set.
+func (c *Client) InvalidateMarket(marketID int) {
+	c.marketCache.remove(marketID)
+}
+
+// invalidateAfterTrade invalidates markets changed by a trading action, if enabled
+func (c *Client) invalidateAfterTrade(marketIDs ...int) {
+	if !c.autoInvalidate {
+		return
+	}
+	for _, marketID := range marketIDs {
+		c.InvalidateMarket(marketID)
+	}
+}
+
+// marketCache is a bounded LRU of markets keyed by market ID whose entries expire after ttl.
+// A zero ttl disables caching.
+type marketCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List // front = most recently used
+	items    map[int]*list.Element
+}
+
+// marketCacheItem is the list element value of a cached market
+type marketCacheItem struct {
+	marketID  int
+	market    *Market
+	timestamp time.Time
+}
+
+func newMarketCache(capacity int, ttl time.Duration) *marketCache {
+	return &marketCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		items:    make(map[int]*list.Element),
+	}
+}
+
+func (c *marketCache) get(marketID int) (*Market, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[marketID]
+	if !ok {
+		return nil, false
+	}
+
+	item := elem.Value.(*marketCacheItem)
+	if time.Since(item.timestamp) >= c.ttl {
+		c.order.Remove(elem)
+		delete(c.items, marketID)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+
+	return item.market, true
+}
+
+func (c *marketCache) add(marketID int, market *Market) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := &marketCacheItem{marketID: marketID, market: market, timestamp: time.Now()}
+	if elem, ok := c.items[marketID]; ok {
+		elem.Value = item
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[marketID] = c.order.PushFront(item)
+
+	// Evict the least recently used entry
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*marketCacheItem).marketID)
+	}
+}
+
+func (c *marketCache) remove(marketID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[marketID]; ok {
+		c.order.Remove(elem)
+		delete(c.items, marketID)
+	}
+}
+
+func (c *marketCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[int]*list.Element)
+}
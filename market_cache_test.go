@@ -0,0 +1,260 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDisableCacheFetchesEveryCall(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f, WithCacheDisabled())
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetMarket(1, true); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GetQuoteTokens(true); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.feeRateSettings(context.Background(), "111"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := f.count("/market/1"); n != 3 {
+		t.Fatalf("fetched market %d times, want 3", n)
+	}
+	if n := f.count("/quoteToken"); n != 3 {
+		t.Fatalf("fetched quote tokens %d times, want 3", n)
+	}
+	if n := f.rpc.feeCalls.Load(); n != 3 {
+		t.Fatalf("fetched fee rates %d times, want 3", n)
+	}
+}
+
+func TestMarketCachedByDefault(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetMarket(1, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := f.count("/market/1"); n != 1 {
+		t.Fatalf("fetched market %d times, want 1", n)
+	}
+}
+
+func TestConcurrentGetMarketSharesOneRequest(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/market/1", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"data":{"marketId":1,"status":2}}}`)
+	})
+	c := newTestClient(t, f)
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if m, err := c.GetMarket(1, true); err != nil || m.MarketID != 1 {
+				t.Errorf("GetMarket: %v, %v", m, err)
+			}
+			if _, err := c.GetQuoteTokens(true); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if n := f.count("/market/1"); n != 1 {
+		t.Fatalf("fetched market %d times, want 1", n)
+	}
+	if n := f.count("/quoteToken"); n != 1 {
+		t.Fatalf("fetched quote tokens %d times, want 1", n)
+	}
+}
+
+func TestQuoteTokensCacheUnderConcurrentRefresh(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/quoteToken", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":1,"list":[{"id":1,"quoteTokenAddress":"`+testQuoteToken+`","ctfExchangeAddress":"`+testExchange+`","decimal":18,"symbol":"USDT","chainId":"56"}]}}`)
+	})
+	c := newTestClient(t, f)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				tokens, err := c.GetQuoteTokens(true)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if len(tokens.Result.List) != 1 {
+					t.Errorf("got %d quote tokens, want 1", len(tokens.Result.List))
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := f.count("/quoteToken"); n != 1 {
+		t.Fatalf("fetched quote tokens %d times, want 1", n)
+	}
+}
+
+// serveMarkets serves markets 1..n, each with its own ID
+func serveMarkets(f *fakeAPI, n int) {
+	for id := 1; id <= n; id++ {
+		f.handleMarket(fmt.Sprintf(`{"marketId":%d,"status":2}`, id))
+	}
+}
+
+func TestMarketCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	f := newFakeAPI(t)
+	serveMarkets(f, 3)
+	c := newTestClient(t, f, func(cfg *ClientConfig) { cfg.MarketCacheMaxEntries = 2 })
+
+	for _, id := range []int{1, 2, 1, 3} { // 3 evicts 2, the least recently used
+		if _, err := c.GetMarket(id, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := c.marketCache.order.Len(); n != 2 {
+		t.Fatalf("cache holds %d markets, want 2", n)
+	}
+	c.GetMarket(1, true)
+	c.GetMarket(2, true)
+	if f.count("/market/1") != 1 || f.count("/market/2") != 2 {
+		t.Fatalf("fetched market 1 %d times and market 2 %d times, want 1 and 2", f.count("/market/1"), f.count("/market/2"))
+	}
+
+	c.ClearMarketCache()
+	c.GetMarket(1, true)
+	if n := f.count("/market/1"); n != 2 {
+		t.Fatalf("fetched market 1 %d times after ClearMarketCache, want 2", n)
+	}
+}
+
+func TestMarketCacheRefetchesExpiredEntries(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f, WithCacheTTLs(time.Hour, 20*time.Millisecond))
+
+	c.GetMarket(1, true)
+	c.GetMarket(1, true)
+	time.Sleep(30 * time.Millisecond)
+	c.GetMarket(1, true)
+	if n := f.count("/market/1"); n != 2 {
+		t.Fatalf("fetched market %d times, want 2", n)
+	}
+}
+
+func TestTradingActionsInvalidateMarket(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	f.handle("/order/o2", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"o2","marketId":1}}}`)
+	})
+	c := newTestClient(t, f)
+
+	c.GetMarket(1, true)
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	c.GetMarket(1, true)
+	c.GetMarket(1, true)
+	if n := f.count("/market/1"); n != 2 {
+		t.Fatalf("fetched market %d times, want 2", n)
+	}
+
+	// A submitted order's market is known without a lookup
+	if _, err := c.CancelOrder("ord-1"); err != nil {
+		t.Fatal(err)
+	}
+	c.GetMarket(1, true)
+	if n := f.count("/market/1"); n != 3 {
+		t.Fatalf("market not invalidated after cancelling a submitted order")
+	}
+
+	// Orders placed elsewhere are not looked up just to clear the cache
+	if _, err := c.CancelOrder("o2"); err != nil {
+		t.Fatal(err)
+	}
+	c.GetMarket(1, true)
+	if n := f.count("/market/1"); n != 3 {
+		t.Fatalf("fetched market %d times after cancelling an unknown order, want 3", n)
+	}
+	if n := f.count("/order/o2"); n != 0 {
+		t.Fatalf("looked up the cancelled order %d times, want 0", n)
+	}
+}
+
+func TestBulkCancelInvalidatesEachMarketOnce(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handleMarket(`{"marketId":2,"status":2,"chainId":"56","quoteToken":"` + testQuoteToken + `","yesTokenId":"333","noTokenId":"444","conditionId":"cd"}`)
+	f.servePages("/order", 6, func(i int) string {
+		return fmt.Sprintf(`{"orderId":"o%d","marketId":%d,"side":0,"status":1}`, i, 1+i%2)
+	})
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f)
+
+	c.GetMarket(1, true)
+	c.GetMarket(2, true)
+	if _, err := c.CancelAllOrders(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.count("/order/cancel"); n != 6 {
+		t.Fatalf("sent %d cancels, want 6", n)
+	}
+	for i := 0; i < 6; i++ {
+		if n := f.count(fmt.Sprintf("/order/o%d", i)); n != 0 {
+			t.Fatalf("looked up order o%d %d times, want 0", i, n)
+		}
+	}
+	c.GetMarket(1, true)
+	c.GetMarket(2, true)
+	if n1, n2 := f.count("/market/1"), f.count("/market/2"); n1 != 2 || n2 != 2 {
+		t.Fatalf("fetched markets 1 and 2 %d and %d times, want 2 each", n1, n2)
+	}
+}
+
+func TestDisableCacheInvalidationKeepsMarket(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f, func(cfg *ClientConfig) { cfg.DisableCacheInvalidation = true })
+
+	c.GetMarket(1, true)
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CancelOrder("o2"); err != nil {
+		t.Fatal(err)
+	}
+	c.GetMarket(1, true)
+	if n := f.count("/market/1"); n != 1 {
+		t.Fatalf("fetched market %d times, want 1", n)
+	}
+	if n := f.count("/order/o2"); n != 0 {
+		t.Fatalf("looked up the cancelled order %d times, want 0", n)
+	}
+}
@@ -0,0 +1,59 @@
+package opinionclob
+
+import (
+	"context"
+)
+
+// marketIteratorPageLimit is the page size used by MarketIterator (the API maximum)
+const marketIteratorPageLimit = 20
+
+// MarketIterator walks all markets matching a filter, fetching pages on demand
+type MarketIterator struct {
+	client    *Client
+	topicType TopicType
+	status    *TopicStatusFilter
+	sortBy    *TopicSortType
+
+	page    int      // last page fetched
+	buffer  []Market // markets of the current page not yet returned
+	hasMore bool     // whether another page may exist
+}
+
+// IterateMarkets returns an iterator over all markets matching the given filters.
+// Pages are fetched lazily as Next is called.
+func (c *Client) IterateMarkets(topicType TopicType, status *TopicStatusFilter, sortBy *TopicSortType) *MarketIterator {
+	return &MarketIterator{
+		client:    c,
+		topicType: topicType,
+		status:    status,
+		sortBy:    sortBy,
+		hasMore:   true,
+	}
+}
+
+// Next returns the next market. The boolean is false once all markets have been returned.
+func (it *MarketIterator) Next(ctx context.Context) (*Market, bool, error) {
+	for len(it.buffer) == 0 {
+		if !it.hasMore {
+			return nil, false, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		result, err := it.client.GetMarkets(it.topicType, it.page+1, marketIteratorPageLimit, it.status, it.sortBy)
+		if err != nil {
+			return nil, false, err
+		}
+
+		it.page++
+		it.buffer = result.Result.List
+		// A short page means there is nothing left to fetch
+		it.hasMore = len(result.Result.List) >= marketIteratorPageLimit
+	}
+
+	market := it.buffer[0]
+	it.buffer = it.buffer[1:]
+
+	return &market, true, nil
+}
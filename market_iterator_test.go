@@ -0,0 +1,56 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestIterateMarketsFetchesEveryPage(t *testing.T) {
+	f := newFakeAPI(t)
+	f.servePages("/market", 45, func(i int) string { return fmt.Sprintf(`{"marketId":%d}`, i+1) })
+	c := newTestClient(t, f)
+
+	it := c.IterateMarkets(TopicTypeAll, nil, nil)
+	n := 0
+	for {
+		market, ok, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+		n++
+		if market.MarketID != n {
+			t.Fatalf("market %d has ID %d", n, market.MarketID)
+		}
+	}
+	if n != 45 {
+		t.Fatalf("iterated %d markets, want 45", n)
+	}
+	if pages := f.count("/market"); pages != 3 {
+		t.Fatalf("fetched %d pages, want 3", pages)
+	}
+}
+
+func TestIterateMarketsStopsOnCancelledContext(t *testing.T) {
+	f := newFakeAPI(t)
+	f.servePages("/market", 45, func(i int) string { return fmt.Sprintf(`{"marketId":%d}`, i+1) })
+	c := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.IterateMarkets(TopicTypeAll, nil, nil)
+	for i := 0; i < 20; i++ {
+		if _, _, err := it.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancel()
+	if _, _, err := it.Next(ctx); err != context.Canceled {
+		t.Fatalf("Next after cancel at a page boundary = %v, want %v", err, context.Canceled)
+	}
+	if pages := f.count("/market"); pages != 1 {
+		t.Fatalf("fetched %d pages, want 1", pages)
+	}
+}
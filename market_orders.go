@@ -0,0 +1,37 @@
+package opinionclob
+
+import (
+	"context"
+)
+
+// MarketBuy places a market BUY order spending quoteAmount of the market's quote token.
+// Trading must already be enabled (see EnableTrading).
+func (c *Client) MarketBuy(ctx context.Context, marketID int, tokenID, quoteAmount string) (*PlaceOrderResponse, error) {
+	if quoteAmount == "" {
+		return nil, &InvalidParamError{Message: "quoteAmount is required for market buy"}
+	}
+
+	return c.PlaceOrder(ctx, PlaceOrderDataInput{
+		MarketID:                marketID,
+		TokenID:                 tokenID,
+		MakerAmountInQuoteToken: &quoteAmount,
+		Side:                    OrderSideBuy,
+		OrderType:               OrderTypeMarket,
+	}, false)
+}
+
+// MarketSell places a market SELL order selling baseAmount outcome tokens.
+// Trading must already be enabled (see EnableTrading).
+func (c *Client) MarketSell(ctx context.Context, marketID int, tokenID, baseAmount string) (*PlaceOrderResponse, error) {
+	if baseAmount == "" {
+		return nil, &InvalidParamError{Message: "baseAmount is required for market sell"}
+	}
+
+	return c.PlaceOrder(ctx, PlaceOrderDataInput{
+		MarketID:               marketID,
+		TokenID:                tokenID,
+		MakerAmountInBaseToken: &baseAmount,
+		Side:                   OrderSideSell,
+		OrderType:              OrderTypeMarket,
+	}, false)
+}
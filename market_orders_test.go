@@ -0,0 +1,93 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMarketBuy(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	var invalid *InvalidParamError
+	if _, err := c.MarketBuy(context.Background(), 1, "111", ""); !errors.As(err, &invalid) {
+		t.Fatalf("MarketBuy without an amount error = %v, want InvalidParamError", err)
+	}
+
+	result, err := c.MarketBuy(context.Background(), 1, "111", "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Result.OrderData.OrderID != "ord-1" {
+		t.Fatalf("order ID = %q, want ord-1", result.Result.OrderData.OrderID)
+	}
+	sent := f.lastBody("/order")
+	if sent["trading_method"] != float64(OrderTypeMarket) || sent["side"] != "0" || sent["maker_amount"] != "10000000000000000000" {
+		t.Fatalf("sent %v; want a market BUY spending 10 quote tokens", sent)
+	}
+}
+
+func TestMarketSell(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	var invalid *InvalidParamError
+	if _, err := c.MarketSell(context.Background(), 1, "111", ""); !errors.As(err, &invalid) {
+		t.Fatalf("MarketSell without an amount error = %v, want InvalidParamError", err)
+	}
+
+	if _, err := c.MarketSell(context.Background(), 1, "111", "10"); err != nil {
+		t.Fatal(err)
+	}
+	sent := f.lastBody("/order")
+	if sent["trading_method"] != float64(OrderTypeMarket) || sent["side"] != "1" || sent["maker_amount"] != "10000000000000000000" {
+		t.Fatalf("sent %v; want a market SELL of 10 shares", sent)
+	}
+}
+
+func TestPlaceOrderRejectsWrongMarketAmount(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	buy := PlaceOrderDataInput{MarketID: 1, TokenID: "111", Side: OrderSideBuy, OrderType: OrderTypeMarket, MakerAmountInBaseToken: strPtr("10")}
+	sell := PlaceOrderDataInput{MarketID: 1, TokenID: "111", Side: OrderSideSell, OrderType: OrderTypeMarket, MakerAmountInQuoteToken: strPtr("10")}
+	for _, order := range []PlaceOrderDataInput{buy, sell} {
+		var invalid *InvalidParamError
+		if _, err := c.PlaceOrder(context.Background(), order, false); !errors.As(err, &invalid) {
+			t.Errorf("market %v with the wrong amount field: error = %v, want InvalidParamError", order.Side, err)
+		}
+	}
+}
+
+func TestMarketOrderPriceModes(t *testing.T) {
+	f := newFakeAPI(t)
+	tests := []struct {
+		mode    MarketOrderPriceMode
+		price   interface{}
+		present bool
+	}{
+		{MarketOrderPriceZero, "0", true},
+		{MarketOrderPriceEmpty, "", true},
+		{MarketOrderPriceOmit, nil, false},
+	}
+	for _, tt := range tests {
+		c := newTestClient(t, f, WithMarketOrderPrice(tt.mode))
+		if _, err := c.MarketBuy(context.Background(), 1, "111", "10"); err != nil {
+			t.Fatal(err)
+		}
+		price, present := f.lastBody("/order")["price"]
+		if present != tt.present || price != tt.price {
+			t.Errorf("mode %d sent price %v (present %v), want %v (present %v)", tt.mode, price, present, tt.price, tt.present)
+		}
+	}
+
+	// Limit orders always send their price
+	c := newTestClient(t, f, WithMarketOrderPrice(MarketOrderPriceOmit))
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	if price := f.lastBody("/order")["price"]; price != "0.5" {
+		t.Fatalf("limit order sent price %v, want 0.5", price)
+	}
+}
@@ -0,0 +1,74 @@
+package opinionclob
+
+import (
+	"context"
+	"time"
+)
+
+// MarketStatusChange is a market's move from one status to another
+type MarketStatusChange struct {
+	MarketID int
+	From     TopicStatus
+	To       TopicStatus
+	Market   *Market // market as fetched by the poll that saw the change
+}
+
+// WatchMarketStatus polls a market every pollInterval and sends an event each time its
+// status changes (e.g. ACTIVATED -> RESOLVING -> RESOLVED). The first poll runs before it
+// returns and only sets the starting status. The channel is closed once the market reaches
+// a final status (resolved, failed or deleted), ctx is done or the client is closed;
+// failed polls are retried on the next tick.
+func (c *Client) WatchMarketStatus(ctx context.Context, marketID int, pollInterval time.Duration) (<-chan MarketStatusChange, error) {
+	if pollInterval <= 0 {
+		return nil, &InvalidParamError{Message: "pollInterval must be positive"}
+	}
+
+	market, err := c.GetMarket(marketID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan MarketStatusChange, 1)
+	err = c.goBackground(ctx, func(ctx context.Context) {
+		c.watchMarketStatus(ctx, marketID, TopicStatus(market.Status), pollInterval, events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// watchMarketStatus runs the polling loop of WatchMarketStatus
+func (c *Client) watchMarketStatus(ctx context.Context, marketID int, status TopicStatus, pollInterval time.Duration, events chan<- MarketStatusChange) {
+	defer close(events)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for !status.IsFinal() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		market, err := c.GetMarket(marketID, false)
+		if err != nil {
+			c.logger.Warn("market status poll failed", "marketId", marketID, "error", err)
+			continue
+		}
+
+		next := TopicStatus(market.Status)
+		if next == status {
+			continue
+		}
+
+		select {
+		case events <- MarketStatusChange{MarketID: marketID, From: status, To: next, Market: market}:
+		case <-ctx.Done():
+			return
+		}
+		status = next
+	}
+}
@@ -0,0 +1,58 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchMarketStatus(t *testing.T) {
+	f := newFakeAPI(t)
+	var polls atomic.Int32
+	f.handle("/market/1", func(w http.ResponseWriter, r *http.Request) {
+		n := polls.Add(1)
+		status := TopicStatusActivated
+		switch {
+		case n == 4:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case n >= 6:
+			status = TopicStatusResolved
+		case n >= 3:
+			status = TopicStatusResolving
+		}
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"data":{"marketId":1,"status":%d}}}`, status)
+	})
+	c := newTestClient(t, f)
+
+	events, err := c.WatchMarketStatus(context.Background(), 1, 5*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for ev := range events {
+		got = append(got, fmt.Sprintf("%d->%d", ev.From, ev.To))
+	}
+	want := fmt.Sprintf("[%d->%d %d->%d]", TopicStatusActivated, TopicStatusResolving, TopicStatusResolving, TopicStatusResolved)
+	if fmt.Sprint(got) != want {
+		t.Fatalf("events %v, want %s", got, want)
+	}
+}
+
+func TestWatchMarketStatusStopsOnContext(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := c.WatchMarketStatus(ctx, 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, ok := <-events; ok {
+		t.Fatal("expected the channel to be closed")
+	}
+}
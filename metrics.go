@@ -0,0 +1,50 @@
+package opinionclob
+
+import (
+	"strings"
+
+	"github.com/kaifufi/opinion-labs-sdk-go/chain"
+)
+
+// MetricsCollector receives request timings and order, reconnect and transaction counts.
+// See the prommetrics package for a Prometheus exposition adapter.
+type MetricsCollector = chain.MetricsCollector
+
+// NopMetrics discards all metrics; it is the default collector
+type NopMetrics = chain.NopMetrics
+
+// Order events reported to MetricsCollector.IncOrder
+const (
+	OrderEventPlaced    = "placed"
+	OrderEventRejected  = "rejected"
+	OrderEventCancelled = "cancelled"
+)
+
+// metricsOrNop returns m, or a NopMetrics if m is nil
+func metricsOrNop(m MetricsCollector) MetricsCollector {
+	if m == nil {
+		return NopMetrics{}
+	}
+	return m
+}
+
+// metricsRoute strips the query string and ID path segments from an endpoint so that
+// route labels stay low-cardinality
+func metricsRoute(endpoint string) string {
+	path := endpoint
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/market/categorical/"):
+		return "/market/categorical/:id"
+	case strings.HasPrefix(path, "/market/") && strings.HasSuffix(path, "/trade"):
+		return "/market/:id/trade"
+	case strings.HasPrefix(path, "/market/"):
+		return "/market/:id"
+	case strings.HasPrefix(path, "/order/") && path != "/order/cancel":
+		return "/order/:id"
+	}
+	return path
+}
@@ -0,0 +1,76 @@
+package opinionclob
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaifufi/opinion-labs-sdk-go/prommetrics"
+)
+
+var _ MetricsCollector = prommetrics.New("opinion")
+
+// recordingMetrics keeps request labels and order events
+type recordingMetrics struct {
+	mu       sync.Mutex
+	requests []string
+	orders   []string
+}
+
+func (m *recordingMetrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, method+" "+route+" "+strconv.Itoa(status))
+}
+
+func (m *recordingMetrics) IncOrder(event string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orders = append(m.orders, event)
+}
+
+func (m *recordingMetrics) IncReconnect(success bool)   {}
+func (m *recordingMetrics) IncTransaction(success bool) {}
+
+func TestMetricsCollectorLabels(t *testing.T) {
+	f := newFakeAPI(t)
+	metrics := &recordingMetrics{}
+	c := newTestClient(t, f, WithMetrics(metrics))
+
+	if _, err := c.GetMarket(1, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+		t.Fatal(err)
+	}
+	c.GetOrderByID("abc")
+
+	requests := strings.Join(metrics.requests, "\n")
+	for _, want := range []string{"GET /market/:id 200", "GET /quoteToken 200", "POST /order 200", "GET /order/:id 404"} {
+		if !strings.Contains(requests, want) {
+			t.Errorf("missing request %q in:\n%s", want, requests)
+		}
+	}
+	if len(metrics.orders) != 1 || metrics.orders[0] != OrderEventPlaced {
+		t.Errorf("order events %v, want [%s]", metrics.orders, OrderEventPlaced)
+	}
+}
+
+func TestMetricsRoute(t *testing.T) {
+	tests := map[string]string{
+		"/order/cancel":          "/order/cancel",
+		"/order/abc":             "/order/:id",
+		"/market/7":              "/market/:id",
+		"/market/7/trade":        "/market/:id/trade",
+		"/market/categorical/5":  "/market/categorical/:id",
+		"/trade?page=2&limit=20": "/trade",
+	}
+	for endpoint, want := range tests {
+		if got := metricsRoute(endpoint); got != want {
+			t.Errorf("metricsRoute(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
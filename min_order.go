@@ -0,0 +1,74 @@
+package opinionclob
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DefaultMinOrderAmount is the minimum order value, in quote token units, used when
+// neither the market nor the config specifies one
+const DefaultMinOrderAmount = "1"
+
+// ParseMinOrderAmount parses a minimum order value, which must be a non-negative decimal
+func ParseMinOrderAmount(amount string) (*big.Rat, error) {
+	minimum, err := parseAmount("minimum order amount", amount)
+	if err != nil {
+		return nil, err
+	}
+	if minimum.Sign() < 0 {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("minimum order amount must not be negative, got: %q", amount)}
+	}
+	return minimum, nil
+}
+
+// marketMinOrderAmount returns the market's minimum order value, falling back to the
+// client's default. It is never below one unit of the quote token's smallest denomination.
+func (c *Client) marketMinOrderAmount(market *Market, currencyDecimal int) (*big.Rat, error) {
+	minimum := c.minOrderAmount
+	if market.MinOrderAmount != "" {
+		var err error
+		minimum, err = ParseMinOrderAmount(market.MinOrderAmount)
+		if err != nil {
+			return nil, &OpenAPIError{Message: fmt.Sprintf("invalid minimum order amount for market %d: %s", market.MarketID, market.MinOrderAmount)}
+		}
+	}
+
+	smallestUnit := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currencyDecimal)), nil))
+	if minimum.Cmp(smallestUnit) < 0 {
+		return smallestUnit, nil
+	}
+	return minimum, nil
+}
+
+// checkMinOrderAmount rejects an order whose value in quote token is below the market's
+// minimum. A market sell has no price to value its shares at, so its share count, which
+// is at least its value, is checked instead.
+func (c *Client) checkMinOrderAmount(market *Market, data PlaceOrderDataInput, makerAmount, limitPrice *big.Rat, currencyDecimal int) error {
+	minimum, err := c.marketMinOrderAmount(market, currencyDecimal)
+	if err != nil {
+		return err
+	}
+
+	value := makerAmount
+	if data.Side == OrderSideSell && limitPrice != nil {
+		value = new(big.Rat).Mul(makerAmount, limitPrice)
+	}
+	if value.Cmp(minimum) >= 0 {
+		return nil
+	}
+
+	if data.Side == OrderSideSell && limitPrice == nil {
+		return &InvalidParamError{Message: fmt.Sprintf("order of %s shares is below the minimum order amount %s for market %d", formatAmount(makerAmount, currencyDecimal), formatAmount(minimum, currencyDecimal), market.MarketID)}
+	}
+	return &InvalidParamError{Message: fmt.Sprintf("order value %s is below the minimum order amount %s for market %d", formatAmount(value, currencyDecimal), formatAmount(minimum, currencyDecimal), market.MarketID)}
+}
+
+// formatAmount formats amount with up to decimals digits, trimming trailing zeros
+func formatAmount(amount *big.Rat, decimals int) string {
+	s := amount.FloatString(decimals)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMinimumOrderAmount(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handleMarket(`{"marketId":2,"status":2,"chainId":"56","quoteToken":"` + testQuoteToken + `","yesTokenId":"111","noTokenId":"222","conditionId":"ab","minOrderAmount":"5"}`)
+	c := newTestClient(t, f)
+
+	order := func(marketID int, side OrderSide, orderType OrderType, price string, base, quote *string) PlaceOrderDataInput {
+		return PlaceOrderDataInput{
+			MarketID:                marketID,
+			TokenID:                 "111",
+			Side:                    side,
+			OrderType:               orderType,
+			Price:                   price,
+			MakerAmountInBaseToken:  base,
+			MakerAmountInQuoteToken: quote,
+			FeeRateBps:              strPtr("0"),
+		}
+	}
+	tests := []struct {
+		name    string
+		data    PlaceOrderDataInput
+		wantErr string
+	}{
+		{"buy below the default", order(1, OrderSideBuy, OrderTypeLimit, "0.5", nil, strPtr("0.5")), "order value 0.5 is below the minimum order amount 1 for market 1"},
+		{"buy valued in shares", order(1, OrderSideBuy, OrderTypeLimit, "0.5", strPtr("2"), nil), ""},
+		{"sell below the default", order(1, OrderSideSell, OrderTypeLimit, "0.4", strPtr("2"), nil), "order value 0.8 is below"},
+		{"market sell of few shares", order(1, OrderSideSell, OrderTypeMarket, "", strPtr("0.9"), nil), "order of 0.9 shares"},
+		{"below the market minimum", order(2, OrderSideBuy, OrderTypeLimit, "0.5", nil, strPtr("4")), "minimum order amount 5 for market 2"},
+		{"at the market minimum", order(2, OrderSideBuy, OrderTypeLimit, "0.5", nil, strPtr("5")), ""},
+	}
+	for _, tt := range tests {
+		_, _, err := c.BuildSignedOrder(context.Background(), tt.data)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		var invalid *InvalidParamError
+		if !errors.As(err, &invalid) || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want InvalidParamError containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	// A zero minimum in the config allows dust down to the smallest unit
+	unlimited := newTestClient(t, f, func(cfg *ClientConfig) { cfg.MinOrderAmount = "0" })
+	if _, _, err := unlimited.BuildSignedOrder(context.Background(), order(1, OrderSideBuy, OrderTypeLimit, "0.5", nil, strPtr("0.01"))); err != nil {
+		t.Errorf("with MinOrderAmount 0: %v", err)
+	}
+	if err := (ClientConfig{MinOrderAmount: "-1"}).Validate(); err == nil || !strings.Contains(err.Error(), "min_order_amount") {
+		t.Errorf("Validate with a negative minimum = %v, want a min_order_amount error", err)
+	}
+}
@@ -1,5 +1,13 @@
 package opinionclob
 
+import (
+	"math/big"
+	"time"
+
+	"github.com/kaifufi/opinion-labs-sdk-go/chain"
+	"github.com/kaifufi/opinion-labs-sdk-go/internal/enumjson"
+)
+
 // TopicStatus represents the status of a market topic
 type TopicStatus int
 
@@ -12,6 +20,61 @@ const (
 	TopicStatusDeleted
 )
 
+// IsFinal reports whether the market status can no longer change
+func (s TopicStatus) IsFinal() bool {
+	return s >= TopicStatusResolved && s <= TopicStatusDeleted
+}
+
+// String returns the name of the status, e.g. "Activated"
+func (s TopicStatus) String() string {
+	if name, ok := s.name(); ok {
+		return name
+	}
+	return "unknown"
+}
+
+// name returns the status's name, or false if it has none
+func (s TopicStatus) name() (string, bool) {
+	switch s {
+	case TopicStatusCreated:
+		return "Created", true
+	case TopicStatusActivated:
+		return "Activated", true
+	case TopicStatusResolving:
+		return "Resolving", true
+	case TopicStatusResolved:
+		return "Resolved", true
+	case TopicStatusFailed:
+		return "Failed", true
+	case TopicStatusDeleted:
+		return "Deleted", true
+	default:
+		return "", false
+	}
+}
+
+// MarshalJSON encodes the status by name; unknown values are encoded as numbers
+func (s TopicStatus) MarshalJSON() ([]byte, error) {
+	name, ok := s.name()
+	return enumjson.Encode(name, ok, int(s)), nil
+}
+
+// UnmarshalJSON decodes a status sent as a number, a numeric string or its name
+func (s *TopicStatus) UnmarshalJSON(data []byte) error {
+	n, ok, err := enumjson.Decode(data, "topic status", map[string]int{
+		"created":   int(TopicStatusCreated),
+		"activated": int(TopicStatusActivated),
+		"resolving": int(TopicStatusResolving),
+		"resolved":  int(TopicStatusResolved),
+		"failed":    int(TopicStatusFailed),
+		"deleted":   int(TopicStatusDeleted),
+	})
+	if ok {
+		*s = TopicStatus(n)
+	}
+	return err
+}
+
 // TopicType represents the type of market
 type TopicType int
 
@@ -46,11 +109,11 @@ const (
 )
 
 // OrderSide represents the side of an order
-type OrderSide int
+type OrderSide = chain.OrderSide
 
 const (
-	OrderSideBuy OrderSide = iota
-	OrderSideSell
+	OrderSideBuy  = chain.OrderSideBuy
+	OrderSideSell = chain.OrderSideSell
 )
 
 // OrderType represents the type of order
@@ -61,13 +124,83 @@ const (
 	OrderTypeLimit
 )
 
+// String returns "Market" or "Limit"
+func (t OrderType) String() string {
+	if name, ok := t.name(); ok {
+		return name
+	}
+	return "unknown"
+}
+
+// name returns the order type's name, or false if it has none
+func (t OrderType) name() (string, bool) {
+	switch t {
+	case OrderTypeMarket:
+		return "Market", true
+	case OrderTypeLimit:
+		return "Limit", true
+	default:
+		return "", false
+	}
+}
+
+// MarshalJSON encodes the order type by name; unknown values are encoded as numbers.
+// Order requests send the numeric trading method, not this form.
+func (t OrderType) MarshalJSON() ([]byte, error) {
+	name, ok := t.name()
+	return enumjson.Encode(name, ok, int(t)), nil
+}
+
+// UnmarshalJSON decodes an order type sent as a number, a numeric string or "market"/"limit"
+func (t *OrderType) UnmarshalJSON(data []byte) error {
+	n, ok, err := enumjson.Decode(data, "order type", map[string]int{
+		"market": int(OrderTypeMarket),
+		"limit":  int(OrderTypeLimit),
+	})
+	if ok {
+		*t = OrderType(n)
+	}
+	return err
+}
+
+// MarketOrderPriceMode selects how the price field of a market order request is sent.
+// Market orders execute against the book, so the gateway ignores their price.
+type MarketOrderPriceMode int
+
+const (
+	// MarketOrderPriceZero sends "price": "0" (the Opinion gateway's expectation)
+	MarketOrderPriceZero MarketOrderPriceMode = iota
+	// MarketOrderPriceEmpty sends "price": ""
+	MarketOrderPriceEmpty
+	// MarketOrderPriceOmit leaves the price field out of the request
+	MarketOrderPriceOmit
+)
+
+// TimestampPrecision selects the unit of the timestamp sent with order requests
+type TimestampPrecision int
+
+const (
+	// TimestampSeconds sends Unix seconds (the default)
+	TimestampSeconds TimestampPrecision = iota
+	// TimestampMilliseconds sends Unix milliseconds
+	TimestampMilliseconds
+)
+
+// unix returns t as a Unix timestamp in this precision
+func (p TimestampPrecision) unix(t time.Time) int64 {
+	if p == TimestampMilliseconds {
+		return t.UnixMilli()
+	}
+	return t.Unix()
+}
+
 // SignatureType represents the signature type for orders
-type SignatureType int
+type SignatureType = chain.SignatureType
 
 const (
-	SignatureTypeEOA SignatureType = iota
-	SignatureTypePolyGnosisSafe
-	SignatureTypePolyProxy
+	SignatureTypeEOA            = chain.SignatureTypeEOA
+	SignatureTypePolyGnosisSafe = chain.SignatureTypePolyGnosisSafe
+	SignatureTypePolyProxy      = chain.SignatureTypePolyProxy
 )
 
 // TransactionResult represents the result of a blockchain transaction
@@ -86,52 +219,307 @@ type PlaceOrderDataInput struct {
 	Price                   string
 	Side                    OrderSide
 	OrderType               OrderType
+	Maker                   *string        // Optional: maker address (defaults to the multisig)
+	SignatureType           *SignatureType // Optional: signature type (defaults to SignatureTypePolyGnosisSafe)
+	ExpiresAt               *time.Time     // Optional: good-till-date expiration (defaults to good-till-cancelled)
+	SafeRate                *string        // Optional: slippage bound as a fraction in [0, 1] (defaults to "0"); not part of the signed order
+	FeeRateBps              *string        // Optional: fee rate to sign (defaults to the FeeManager taker rate for market orders, maker rate for limit orders)
+	ClientOrderID           *string        // Optional: idempotency key; reuse it when retrying the same order (defaults to the order salt)
 }
 
-// OrderData represents the data for building an order
-type OrderData struct {
-	Maker         string
-	Taker         string
-	TokenID       string
-	MakerAmount   string
-	TakerAmount   string
-	Side          OrderSide
-	FeeRateBps    string
-	Nonce         string
-	Signer        string
-	Expiration    string
-	SignatureType SignatureType
+// TradingStatus reports whether trading is enabled for a quote token
+type TradingStatus struct {
+	QuoteToken      string
+	Symbol          string
+	Exchange        string
+	ERC20Approved   bool // allowances to the CTF exchange and ConditionalTokens are sufficient
+	ERC1155Approved bool // ConditionalTokens setApprovalForAll granted to the CTF exchange
 }
 
-// SignedOrder represents an order with its signature
-type SignedOrder struct {
-	Order     *Order
-	Signature string
+// Enabled reports whether all approvals for the quote token are in place
+func (s TradingStatus) Enabled() bool {
+	return s.ERC20Approved && s.ERC1155Approved
 }
 
+// OrderParamsProvider supplies salt, nonce and default expiration for new orders
+type OrderParamsProvider = chain.OrderParamsProvider
+
+// FixedOrderParamsProvider returns the same salt, nonce and expiration for every order
+type FixedOrderParamsProvider = chain.FixedOrderParamsProvider
+
+// OrderData represents the data for building an order
+type OrderData = chain.OrderData
+
+// SignedOrder represents an order with its signature
+type SignedOrder = chain.SignedOrder
+
 // Order represents an EIP712 order structure
-type Order struct {
-	Salt          string
-	Maker         string
-	Signer        string
-	Taker         string
-	TokenID       string
-	MakerAmount   string
-	TakerAmount   string
-	Expiration    string
-	Nonce         string
-	FeeRateBps    string
-	Side          string
-	SignatureType string
+type Order = chain.Order
+
+// OrderStatus represents the lifecycle status of an order
+type OrderStatus int
+
+const (
+	OrderStatusPending OrderStatus = iota + 1
+	OrderStatusFilled
+	OrderStatusCancelled
+	OrderStatusExpired
+	OrderStatusFailed
+)
+
+// IsFinal reports whether the order can no longer change
+func (s OrderStatus) IsFinal() bool {
+	return s >= OrderStatusFilled && s <= OrderStatusFailed
 }
 
-// FeeRateSettings represents fee rate settings from the FeeManager contract
-type FeeRateSettings struct {
-	MakerMaxFeeRate float64
-	TakerMaxFeeRate float64
-	Enabled         bool
+// OrderStatusFilterOpen is the GetMyOrders status filter for pending/open orders
+const OrderStatusFilterOpen = "1"
+
+// OrderRecord represents an order as returned by the order listing API
+type OrderRecord struct {
+	OrderID       string      `json:"orderId"`
+	MarketID      int         `json:"marketId"`
+	RootMarketID  int         `json:"rootMarketId"`
+	TokenID       string      `json:"tokenId"`
+	Side          OrderSide   `json:"side"`
+	OutcomeSide   int         `json:"outcomeSide"`
+	Price         string      `json:"price"`
+	Shares        string      `json:"shares"`
+	Amount        string      `json:"amount"`
+	FilledShares  string      `json:"filledShares"`
+	FilledAmount  string      `json:"filledAmount"`
+	Status        OrderStatus `json:"status"`
+	TradingMethod int         `json:"tradingMethod"`
+	QuoteToken    string      `json:"quoteToken"`
+	CreatedAt     int64       `json:"createdAt"` // Unix seconds
+	ExpiresAt     int64       `json:"expiresAt"`
+	ChainID       string      `json:"chainId"`
+	ClientOrderID string      `json:"clientOrderId"` // idempotency key the order was placed with, if reported
+}
+
+// MyOrdersResponse represents the API response for listing the user's orders
+type MyOrdersResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		Total int           `json:"total"`
+		List  []OrderRecord `json:"list"`
+	} `json:"result"`
+}
+
+// PlaceOrderResponse represents the API response for PlaceOrder
+type PlaceOrderResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		OrderData OrderRecord `json:"orderData"`
+	} `json:"result"`
+	ClientOrderID string `json:"-"` // idempotency key the order was submitted with
+}
+
+// GetOrderResponse represents the API response for GetOrderByID
+type GetOrderResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		OrderData OrderRecord `json:"orderData"`
+	} `json:"result"`
+}
+
+// Trade represents a trade execution as returned by the trade history API
+type Trade struct {
+	OrderID      string    `json:"orderId"`
+	TradeNo      string    `json:"tradeNo"`
+	MarketID     int       `json:"marketId"`
+	RootMarketID int       `json:"rootMarketId"`
+	TxHash       string    `json:"txHash"`
+	Side         OrderSide `json:"side"`
+	OutcomeSide  int       `json:"outcomeSide"`
+	Price        string    `json:"price"`
+	Shares       string    `json:"shares"`
+	Amount       string    `json:"amount"`
+	Fee          string    `json:"fee"`
+	UsdAmount    string    `json:"usdAmount"`
+	Profit       string    `json:"profit"`
+	Status       int       `json:"status"`
+	QuoteToken   string    `json:"quoteToken"`
+	CreatedAt    int64     `json:"createdAt"` // Unix seconds
 }
 
+// TradeAmounts holds a trade's decimal string fields parsed exactly
+type TradeAmounts struct {
+	Price  *big.Rat
+	Shares *big.Rat
+	Amount *big.Rat // quote token amount exchanged
+	Fee    *big.Rat
+}
+
+// Amounts parses the trade's price, shares, amount and fee; empty fields are zero
+func (t Trade) Amounts() (TradeAmounts, error) {
+	var a TradeAmounts
+	fields := []struct {
+		name  string
+		value string
+		dest  **big.Rat
+	}{
+		{"price", t.Price, &a.Price},
+		{"shares", t.Shares, &a.Shares},
+		{"amount", t.Amount, &a.Amount},
+		{"fee", t.Fee, &a.Fee},
+	}
+	for _, f := range fields {
+		v, err := parseAPIAmount(f.name, f.value)
+		if err != nil {
+			return TradeAmounts{}, err
+		}
+		*f.dest = v
+	}
+	return a, nil
+}
+
+// Time returns when the trade was created
+func (t Trade) Time() time.Time {
+	return time.Unix(t.CreatedAt, 0)
+}
+
+// MyTradesResponse represents the API response for GetMyTrades
+type MyTradesResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		Total int     `json:"total"`
+		List  []Trade `json:"list"`
+	} `json:"result"`
+}
+
+// MarketTradesResponse represents the API response for GetMarketTrades
+type MarketTradesResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		Total int     `json:"total"`
+		List  []Trade `json:"list"`
+	} `json:"result"`
+}
+
+// Position represents the user's holding of one outcome token
+type Position struct {
+	MarketID     int    `json:"marketId"`
+	RootMarketID int    `json:"rootMarketId"`
+	TokenID      string `json:"tokenId"`
+	OutcomeSide  int    `json:"outcomeSide"`
+	Outcome      string `json:"outcome"`
+	SharesOwned  string `json:"sharesOwned"`
+	SharesFrozen string `json:"sharesFrozen"`
+	QuoteToken   string `json:"quoteToken"`
+	// AvgEntryPrice is the average price paid per share; empty if not reported
+	AvgEntryPrice string `json:"avgEntryPrice"`
+	// CurrentValueInQuoteToken is the shares valued at the latest price; empty if not reported
+	CurrentValueInQuoteToken string `json:"currentValueInQuoteToken"`
+}
+
+// IsYes reports whether the position holds the YES outcome of a binary market
+func (p Position) IsYes() bool {
+	return p.OutcomeSide == OutcomeSideYes
+}
+
+// IsNo reports whether the position holds the NO outcome of a binary market
+func (p Position) IsNo() bool {
+	return p.OutcomeSide == OutcomeSideNo
+}
+
+// MyPositionsResponse represents the API response for GetMyPositions
+type MyPositionsResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		Total int        `json:"total"`
+		List  []Position `json:"list"`
+	} `json:"result"`
+}
+
+// LatestPrice represents the last traded price of a token
+type LatestPrice struct {
+	TokenID   string `json:"tokenId"`
+	Price     string `json:"price"`
+	Side      string `json:"side"`
+	Size      string `json:"size"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// GetLatestPriceResponse represents the API response for GetLatestPrice
+type GetLatestPriceResponse struct {
+	Code   int         `json:"code"`
+	Msg    string      `json:"msg"`
+	Result LatestPrice `json:"result"`
+}
+
+// Balance is the user's balance of one quote token, in human-readable units
+type Balance struct {
+	QuoteTokenAddress string `json:"quoteToken"`
+	Symbol            string `json:"symbol"` // filled in from the quote token list if the API omits it
+	Decimals          int    `json:"tokenDecimals"`
+	Total             string `json:"totalBalance"`
+	Available         string `json:"availableBalance"`
+	Locked            string `json:"frozenBalance"` // locked by open orders
+}
+
+// AvailableWei returns the available balance in the token's smallest unit
+func (b Balance) AvailableWei() (*big.Int, error) {
+	return balanceToWei(b.Available, b.Decimals)
+}
+
+// LockedWei returns the balance locked by open orders in the token's smallest unit
+func (b Balance) LockedWei() (*big.Int, error) {
+	return balanceToWei(b.Locked, b.Decimals)
+}
+
+// TotalWei returns the total balance in the token's smallest unit
+func (b Balance) TotalWei() (*big.Int, error) {
+	return balanceToWei(b.Total, b.Decimals)
+}
+
+// balanceToWei converts a balance amount to wei, treating an empty value as zero
+func balanceToWei(amount string, decimals int) (*big.Int, error) {
+	if amount == "" {
+		return new(big.Int), nil
+	}
+	return AmountToWei(amount, decimals)
+}
+
+// UserBalances holds the user's quote token balances
+type UserBalances struct {
+	WalletAddress    string    `json:"walletAddress"`
+	MultiSignAddress string    `json:"multiSignAddress"`
+	ChainID          string    `json:"chainId"`
+	Balances         []Balance `json:"balances"`
+}
+
+// UserAuth is the user an API key authenticates as
+type UserAuth struct {
+	WalletAddress    string   `json:"walletAddress"`
+	MultiSignAddress string   `json:"multiSignAddress"`
+	Enabled          bool     `json:"enabled"`
+	Permissions      []string `json:"permissions"`
+}
+
+// UserAuthResponse represents the API response for GetUserAuth
+type UserAuthResponse struct {
+	Code   int      `json:"code"`
+	Msg    string   `json:"msg"`
+	Result UserAuth `json:"result"`
+}
+
+// BalancesResponse represents the API response for GetMyBalances
+type BalancesResponse struct {
+	Code   int          `json:"code"`
+	Msg    string       `json:"msg"`
+	Result UserBalances `json:"result"`
+}
+
+// FeeRateSettings represents fee rate settings from the FeeManager contract
+type FeeRateSettings = chain.FeeRateSettings
+
 // ChildMarket represents a child market within a categorical market
 type ChildMarket struct {
 	MarketID      int    `json:"marketId"`
@@ -179,6 +567,64 @@ type Market struct {
 	CreatedAt       int64                  `json:"createdAt"`
 	CutoffAt        int64                  `json:"cutoffAt"`
 	ResolvedAt      int64                  `json:"resolvedAt"`
+	TickSize        string                 `json:"tickSize"`       // price increment; empty if not reported
+	MinOrderAmount  string                 `json:"minOrderAmount"` // minimum order value in quote token; empty if not reported
+}
+
+// TokenIDs returns the outcome token IDs the market reports, including those of its
+// child markets, skipping empty ones
+func (m *Market) TokenIDs() []string {
+	var ids []string
+	for _, id := range []string{m.YesTokenID, m.NoTokenID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	for _, child := range m.ChildMarkets {
+		for _, id := range []string{child.YesTokenID, child.NoTokenID} {
+			if id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// CategoricalMarket is a root market whose outcomes are binary child markets
+type CategoricalMarket struct {
+	MarketID     int           `json:"marketId"` // the root market ID, rootMarketId on WebSocket channels
+	MarketTitle  string        `json:"marketTitle"`
+	Status       int           `json:"status"`
+	StatusEnum   string        `json:"statusEnum"`
+	MarketType   int           `json:"marketType"`
+	ChildMarkets []ChildMarket `json:"childMarkets"`
+	Rules        string        `json:"rules"`
+	Volume       string        `json:"volume"`
+	QuoteToken   string        `json:"quoteToken"`
+	ChainID      string        `json:"chainId"`
+	QuestionID   string        `json:"questionId"`
+	CreatedAt    int64         `json:"createdAt"`
+	CutoffAt     int64         `json:"cutoffAt"`
+	ResolvedAt   int64         `json:"resolvedAt"`
+}
+
+// Child returns the child market with the given ID
+func (m *CategoricalMarket) Child(marketID int) (*ChildMarket, bool) {
+	for i := range m.ChildMarkets {
+		if m.ChildMarkets[i].MarketID == marketID {
+			return &m.ChildMarkets[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetCategoricalMarketResponse represents the API response for GetCategoricalMarket
+type GetCategoricalMarketResponse struct {
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+	Result struct {
+		Data CategoricalMarket `json:"data"`
+	} `json:"result"`
 }
 
 // GetMarketResponse represents the API response for GetMarket
@@ -0,0 +1,376 @@
+package opinionclob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/kaifufi/opinion-labs-sdk-go/chain"
+)
+
+func TestGetMyOrdersDecodesSide(t *testing.T) {
+	f := newFakeAPI(t)
+	// Sides as the API has sent them over time: a number, a numeric string and names
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":4,"list":[
+			{"orderId":"a","marketId":1,"tokenId":"111","side":1,"price":"0.5","status":1},
+			{"orderId":"b","marketId":1,"tokenId":"111","side":"1","price":"0.5","status":1},
+			{"orderId":"c","marketId":1,"tokenId":"111","side":"Buy","price":"0.5","status":1},
+			{"orderId":"d","marketId":1,"tokenId":"111","side":"SELL","price":"0.5","status":1}]}}`)
+	})
+	c := newTestClient(t, f)
+
+	resp, err := c.GetMyOrders(0, "", 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []OrderSide{OrderSideSell, OrderSideSell, OrderSideBuy, OrderSideSell}
+	if len(resp.Result.List) != len(want) {
+		t.Fatalf("got %d orders, want %d", len(resp.Result.List), len(want))
+	}
+	for i, order := range resp.Result.List {
+		if order.Side != want[i] {
+			t.Errorf("order %s side = %v, want %v", order.OrderID, order.Side, want[i])
+		}
+	}
+}
+
+func TestOrderDataConvertsToChain(t *testing.T) {
+	data := OrderData{
+		Maker:         "0x1111111111111111111111111111111111111111",
+		Taker:         "0x2222222222222222222222222222222222222222",
+		TokenID:       "111",
+		MakerAmount:   "2000",
+		TakerAmount:   "3000",
+		Side:          OrderSideSell,
+		FeeRateBps:    "4",
+		Nonce:         "5",
+		Signer:        "0x3333333333333333333333333333333333333333",
+		Expiration:    "6",
+		SignatureType: SignatureTypePolyProxy,
+	}
+	var asChain chain.OrderData = data
+	if OrderData(asChain) != data {
+		t.Fatalf("round trip through chain.OrderData changed %+v to %+v", data, asChain)
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder, err := chain.NewOrderBuilderWithParams(testExchange, int64(ChainIDBNBMainnet), key, FixedOrderParamsProvider{Salt: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := builder.BuildSignedOrder(&data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order *Order = signed.Order
+	want := Order{
+		Salt:          "1",
+		Maker:         data.Maker,
+		Signer:        data.Signer,
+		Taker:         data.Taker,
+		TokenID:       data.TokenID,
+		MakerAmount:   data.MakerAmount,
+		TakerAmount:   data.TakerAmount,
+		Expiration:    data.Expiration,
+		Nonce:         data.Nonce,
+		FeeRateBps:    data.FeeRateBps,
+		Side:          "1",
+		SignatureType: "2",
+	}
+	if *order != want {
+		t.Fatalf("built order = %+v, want %+v", *order, want)
+	}
+}
+
+func TestGetFeeRatesReturnsChainSettings(t *testing.T) {
+	f := newFakeAPI(t)
+	f.rpc.makerBps.Store(25)
+	f.rpc.takerBps.Store(50)
+	c := newTestClient(t, f)
+
+	rates, err := c.GetFeeRates(context.Background(), 111)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings *chain.FeeRateSettings = rates
+	if !settings.Enabled || settings.MakerFeeRateBps.Int64() != 25 || settings.TakerFeeRateBps.Int64() != 50 {
+		t.Fatalf("GetFeeRates = %+v", settings)
+	}
+}
+
+// serveBalances serves a 6-decimal token with a symbol and the test quote token without one
+func serveBalances(f *fakeAPI) {
+	f.handle("/user/balance", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"balances":[
+			{"quoteToken":"0x1111111111111111111111111111111111111111","symbol":"USDC","tokenDecimals":6,"totalBalance":"12.5","availableBalance":"10.25","frozenBalance":"2.25"},
+			{"quoteToken":"`+testQuoteToken+`","tokenDecimals":18,"totalBalance":"1","availableBalance":"0.000000000000000001","frozenBalance":""}
+		]}}`)
+	})
+}
+
+func TestGetMyBalancesDecodesEveryToken(t *testing.T) {
+	f := newFakeAPI(t)
+	serveBalances(f)
+	c := newTestClient(t, f)
+
+	result, err := c.GetMyBalances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	balances := result.Result.Balances
+	if len(balances) != 2 {
+		t.Fatalf("decoded %d balances, want 2", len(balances))
+	}
+
+	usdc := balances[0]
+	available, _ := usdc.AvailableWei()
+	locked, _ := usdc.LockedWei()
+	total, _ := usdc.TotalWei()
+	if usdc.Symbol != "USDC" || usdc.Decimals != 6 || available.String() != "10250000" || locked.String() != "2250000" || total.String() != "12500000" {
+		t.Fatalf("got %+v (available %s, locked %s, total %s)", usdc, available, locked, total)
+	}
+
+	// The symbol comes from the quote token list and an empty amount is zero
+	usdt := balances[1]
+	available, _ = usdt.AvailableWei()
+	locked, _ = usdt.LockedWei()
+	if usdt.Symbol != "USDT" || available.String() != "1" || locked.Sign() != 0 {
+		t.Fatalf("got %+v (available %s, locked %s)", usdt, available, locked)
+	}
+}
+
+func TestFillBalanceSymbolsOnlyWhenMissing(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+	quoteTokenFetches := f.count("/quoteToken")
+
+	complete := []Balance{{QuoteTokenAddress: testQuoteToken, Symbol: "USDT"}}
+	c.fillBalanceSymbols(complete)
+	if n := f.count("/quoteToken") - quoteTokenFetches; n != 0 {
+		t.Fatalf("fetched the quote token list %d times with every symbol known, want 0", n)
+	}
+
+	partial := []Balance{{QuoteTokenAddress: "0x2222222222222222222222222222222222222222", Symbol: "KEEP"}, {QuoteTokenAddress: testQuoteToken}}
+	c.fillBalanceSymbols(partial)
+	if partial[0].Symbol != "KEEP" || partial[1].Symbol != "USDT" {
+		t.Fatalf("symbols = %q, %q; want KEEP, USDT", partial[0].Symbol, partial[1].Symbol)
+	}
+}
+
+func TestGetBalanceFor(t *testing.T) {
+	f := newFakeAPI(t)
+	serveBalances(f)
+	c := newTestClient(t, f)
+
+	b, err := c.GetBalanceFor("0x1111111111111111111111111111111111111111")
+	if err != nil || b.Available != "10.25" {
+		t.Fatalf("got %+v, %v", b, err)
+	}
+
+	// A supported token without a balance is zero
+	f.handle("/user/balance", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"balances":[]}}`)
+	})
+	b, err = c.GetBalanceFor(testQuoteToken)
+	if err != nil || b.Decimals != 18 || b.Symbol != "USDT" || b.Available != "0" {
+		t.Fatalf("got %+v, %v", b, err)
+	}
+
+	if _, err := c.GetBalanceFor("0x2222222222222222222222222222222222222222"); err == nil {
+		t.Fatal("expected an error for an unsupported quote token")
+	}
+}
+
+func TestGetMyTradesDecodesTrades(t *testing.T) {
+	f := newFakeAPI(t)
+	serveTrades(f, `[
+		{"orderId":"o1","tradeNo":"t1","marketId":1,"side":"Buy","outcomeSide":1,"price":"0.1","shares":"30","amount":"3","fee":"0.003","createdAt":1700000000},
+		{"orderId":"o2","tradeNo":"t2","marketId":2,"side":1,"outcomeSide":2,"price":"0.7","shares":"2","amount":"1.4","fee":"","createdAt":1700000100}
+	]`)
+	c := newTestClient(t, f)
+
+	result, err := c.GetMyTrades(nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trades := result.Result.List
+	if len(trades) != 2 {
+		t.Fatalf("decoded %d trades, want 2", len(trades))
+	}
+	if trades[0].Side != OrderSideBuy || trades[1].Side != OrderSideSell || trades[1].OutcomeSide != OutcomeSideNo {
+		t.Fatalf("decoded sides %+v", trades)
+	}
+	if !trades[1].Time().Equal(time.Unix(1700000100, 0)) {
+		t.Fatalf("decoded time %v", trades[1].Time())
+	}
+
+	// Amounts are exact, so 30 * 0.1 is 3 and an empty fee is zero
+	first, err := trades[0].Amounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if notional := new(big.Rat).Mul(first.Shares, first.Price); notional.Cmp(first.Amount) != 0 || !ratIs(first.Fee, "0.003") {
+		t.Fatalf("amounts %+v", first)
+	}
+	second, err := trades[1].Amounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Fee.Sign() != 0 || !ratIs(second.Amount, "1.4") {
+		t.Fatalf("amounts %+v", second)
+	}
+
+	trades[0].Price = "cheap"
+	if _, err := trades[0].Amounts(); err == nil {
+		t.Fatal("expected an error for an invalid price")
+	}
+}
+
+func TestGetMarketTrades(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/market/7/trade", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("page") != "2" || q.Get("limit") != "5" {
+			t.Errorf("query %q, want page 2 and limit 5", r.URL.RawQuery)
+		}
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":2,"list":[
+			{"tradeNo":"t1","marketId":7,"side":"Buy","price":"0.5","shares":"2"},
+			{"tradeNo":"t2","marketId":7,"side":"Sell","price":"0.6","shares":"3"}]}}`)
+	})
+	c := newTestClient(t, f)
+
+	trades, err := c.GetMarketTrades(7, 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2 || trades[1].Price != "0.6" || trades[1].Side != OrderSideSell {
+		t.Fatalf("GetMarketTrades = %+v", trades)
+	}
+
+	for _, bad := range []struct{ marketID, page, limit int }{{0, 1, 5}, {7, 0, 5}, {7, 1, 0}, {7, 1, 21}} {
+		if _, err := c.GetMarketTrades(bad.marketID, bad.page, bad.limit); err == nil {
+			t.Errorf("GetMarketTrades(%d, %d, %d) succeeded, want an error", bad.marketID, bad.page, bad.limit)
+		}
+	}
+}
+
+// serveUserAuth answers /user/auth with the given multi-sig and wallet addresses
+func serveUserAuth(f *fakeAPI, multiSig, wallet string) {
+	f.handle("/user/auth", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"walletAddress":"`+wallet+`","multiSignAddress":"`+multiSig+`","enabled":true,"permissions":["trade"]}}`)
+	})
+}
+
+func TestGetUserAuthDecodesAuth(t *testing.T) {
+	f := newFakeAPI(t)
+	serveUserAuth(f, testMultiSig, "")
+	c := newTestClient(t, f)
+
+	auth, err := c.GetUserAuth()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.MultiSignAddress != testMultiSig || !auth.Enabled || len(auth.Permissions) != 1 || auth.Permissions[0] != "trade" {
+		t.Fatalf("GetUserAuth = %+v", auth)
+	}
+	if err := c.CheckAuthAddress(); err != nil {
+		t.Errorf("CheckAuthAddress with the configured multi-sig: %v", err)
+	}
+
+	serveUserAuth(f, "0x2222222222222222222222222222222222222222", "")
+	if err := c.CheckAuthAddress(); !errors.Is(err, ErrAuthAddressMismatch) {
+		t.Errorf("CheckAuthAddress with another multi-sig = %v, want ErrAuthAddressMismatch", err)
+	}
+}
+
+func TestGetCategoricalMarketDecodesChildren(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/market/categorical/9", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"data":{"marketId":9,"marketTitle":"Who wins","marketType":1,"childMarkets":[
+			{"marketId":10,"marketTitle":"A","yesTokenId":"1","noTokenId":"2"},
+			{"marketId":11,"marketTitle":"B","yesTokenId":"3","noTokenId":"4"},
+			{"marketId":12,"marketTitle":"C","yesTokenId":"5","noTokenId":"6"}]}}}`)
+	})
+	c := newTestClient(t, f)
+
+	market, err := c.GetCategoricalMarket(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if market.MarketID != 9 || market.MarketTitle != "Who wins" || len(market.ChildMarkets) != 3 {
+		t.Fatalf("GetCategoricalMarket = %+v", market)
+	}
+	for i, title := range []string{"A", "B", "C"} {
+		if got := market.ChildMarkets[i]; got.MarketID != 10+i || got.MarketTitle != title {
+			t.Errorf("child %d = %d %q, want %d %q", i, got.MarketID, got.MarketTitle, 10+i, title)
+		}
+	}
+	if child, ok := market.Child(11); !ok || child.YesTokenID != "3" || child.NoTokenID != "4" {
+		t.Errorf("Child(11) = %+v, %v; want tokens 3 and 4", child, ok)
+	}
+	if _, ok := market.Child(99); ok {
+		t.Error("Child(99) found a market that is not a child")
+	}
+}
+
+func TestTopicStatusAndOrderTypeJSON(t *testing.T) {
+	statuses := map[TopicStatus]string{
+		TopicStatusCreated:   "Created",
+		TopicStatusActivated: "Activated",
+		TopicStatusResolving: "Resolving",
+		TopicStatusResolved:  "Resolved",
+		TopicStatusFailed:    "Failed",
+		TopicStatusDeleted:   "Deleted",
+	}
+	for status, want := range statuses {
+		if got := status.String(); got != want {
+			t.Errorf("TopicStatus(%d).String() = %q, want %q", int(status), got, want)
+		}
+		data, err := json.Marshal(status)
+		if err != nil || string(data) != `"`+want+`"` {
+			t.Errorf("json.Marshal(%v) = %s, %v", status, data, err)
+		}
+		var decoded TopicStatus
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != status {
+			t.Errorf("round trip of %v = %v, %v", status, decoded, err)
+		}
+	}
+
+	for orderType, want := range map[OrderType]string{OrderTypeMarket: "Market", OrderTypeLimit: "Limit"} {
+		if got := orderType.String(); got != want {
+			t.Errorf("OrderType(%d).String() = %q, want %q", int(orderType), got, want)
+		}
+		data, err := json.Marshal(orderType)
+		if err != nil || string(data) != `"`+want+`"` {
+			t.Errorf("json.Marshal(%v) = %s, %v", orderType, data, err)
+		}
+		var decoded OrderType
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != orderType {
+			t.Errorf("round trip of %v = %v, %v", orderType, decoded, err)
+		}
+	}
+
+	// Numbers from the API still decode, and values without a name encode as numbers
+	var market struct {
+		Status TopicStatus `json:"status"`
+		Type   OrderType   `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(`{"status":4,"type":"2"}`), &market); err != nil || market.Status != TopicStatusResolved || market.Type != OrderTypeLimit {
+		t.Errorf("decoded numbers = %+v, %v", market, err)
+	}
+	if data, _ := json.Marshal(TopicStatus(42)); string(data) != "42" {
+		t.Errorf("unknown status marshalled as %s, want 42", data)
+	}
+	if err := json.Unmarshal([]byte(`"pending"`), &market.Type); err == nil {
+		t.Error("json.Unmarshal accepted an unknown order type name")
+	}
+}
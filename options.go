@@ -0,0 +1,232 @@
+package opinionclob
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClientOption configures a Client created with NewClientWithOptions
+type ClientOption func(*ClientConfig)
+
+// NewClientWithOptions creates a client from functional options. Omitted options keep
+// the same defaults as NewClient; the chain defaults to BNB Chain mainnet.
+func NewClientWithOptions(opts ...ClientOption) (*Client, error) {
+	config := ClientConfig{ChainID: ChainIDBNBMainnet}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return newClient(config)
+}
+
+// WithConfig replaces the whole configuration; later options still apply on top of it
+func WithConfig(config ClientConfig) ClientOption {
+	return func(c *ClientConfig) {
+		*c = config
+	}
+}
+
+// WithHost sets the API host URL
+func WithHost(host string) ClientOption {
+	return func(c *ClientConfig) {
+		c.Host = host
+	}
+}
+
+// WithAPIKey sets the API authentication key
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *ClientConfig) {
+		c.APIKey = apiKey
+	}
+}
+
+// WithChainID sets the chain ID
+func WithChainID(chainID ChainID) ClientOption {
+	return func(c *ClientConfig) {
+		c.ChainID = chainID
+	}
+}
+
+// WithRPCURL sets the Ethereum RPC endpoint
+func WithRPCURL(rpcURL string) ClientOption {
+	return func(c *ClientConfig) {
+		c.RPCURL = rpcURL
+	}
+}
+
+// WithPrivateKey sets the private key used for signing
+func WithPrivateKey(privateKey string) ClientOption {
+	return func(c *ClientConfig) {
+		c.PrivateKey = privateKey
+	}
+}
+
+// WithMultiSigAddr sets the multi-signature wallet address
+func WithMultiSigAddr(multiSigAddr string) ClientOption {
+	return func(c *ClientConfig) {
+		c.MultiSigAddr = multiSigAddr
+	}
+}
+
+// WithContractAddresses overrides the default contract addresses; empty values keep the default
+func WithContractAddresses(addrs ContractAddresses) ClientOption {
+	return func(c *ClientConfig) {
+		c.ConditionalTokensAddr = addrs.ConditionalTokens
+		c.MultisendAddr = addrs.Multisend
+		c.FeeManagerAddr = addrs.FeeManager
+	}
+}
+
+// WithCacheTTLs sets the quote token and market cache TTLs; zero keeps the default
+func WithCacheTTLs(quoteTokens, market time.Duration) ClientOption {
+	return func(c *ClientConfig) {
+		c.QuoteTokensCacheTTL = quoteTokens
+		c.MarketCacheTTL = market
+	}
+}
+
+// WithEnableTradingCheckInterval sets how often EnableTrading re-checks approvals
+func WithEnableTradingCheckInterval(interval time.Duration) ClientOption {
+	return func(c *ClientConfig) {
+		c.EnableTradingCheckInterval = interval
+	}
+}
+
+// WithGasPriceMultiplier scales the suggested gas price of on-chain transactions (e.g. 1.1)
+func WithGasPriceMultiplier(multiplier float64) ClientOption {
+	return func(c *ClientConfig) {
+		c.GasPriceMultiplier = multiplier
+	}
+}
+
+// WithPriceTick sets the default tick size and how misaligned limit prices are handled
+func WithPriceTick(tickSize string, mode TickMode) ClientOption {
+	return func(c *ClientConfig) {
+		c.PriceTickSize = tickSize
+		c.PriceTickMode = mode
+	}
+}
+
+// WithMinOrderAmount sets the minimum order value, in quote token units, for markets that
+// do not report one
+func WithMinOrderAmount(amount string) ClientOption {
+	return func(c *ClientConfig) {
+		c.MinOrderAmount = amount
+	}
+}
+
+// WithOrderParamsProvider sets the source of order salt, nonce and expiration
+func WithOrderParamsProvider(provider OrderParamsProvider) ClientOption {
+	return func(c *ClientConfig) {
+		c.OrderParamsProvider = provider
+	}
+}
+
+// WithClock sets the source of order timestamps
+func WithClock(clock func() time.Time) ClientOption {
+	return func(c *ClientConfig) {
+		c.Clock = clock
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for API requests
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *ClientConfig) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithHeaders sets extra headers sent with every API request. Content-Type and apikey
+// are kept unless ClientConfig.OverrideStandardHeaders is set.
+func WithHeaders(headers map[string]string) ClientOption {
+	return func(c *ClientConfig) {
+		c.Headers = headers
+	}
+}
+
+// WithLogger sets the logger that receives API, order and transaction events
+func WithLogger(logger Logger) ClientOption {
+	return func(c *ClientConfig) {
+		c.Logger = logger
+	}
+}
+
+// WithCacheDisabled turns off the quote token, market, fee rate and exchange state caches
+func WithCacheDisabled() ClientOption {
+	return func(c *ClientConfig) {
+		c.DisableCache = true
+	}
+}
+
+// WithTokenValidationDisabled places orders without checking that their token ID is one
+// of the market's outcome tokens
+func WithTokenValidationDisabled() ClientOption {
+	return func(c *ClientConfig) {
+		c.DisableTokenValidation = true
+	}
+}
+
+// WithCutoffCheckDisabled places orders without checking that the market's trading
+// cutoff has not passed
+func WithCutoffCheckDisabled() ClientOption {
+	return func(c *ClientConfig) {
+		c.DisableCutoffCheck = true
+	}
+}
+
+// WithMetrics sets the collector for request, order and transaction metrics
+func WithMetrics(metrics MetricsCollector) ClientOption {
+	return func(c *ClientConfig) {
+		c.Metrics = metrics
+	}
+}
+
+// WithExchangeCodeCheck verifies that exchange addresses from the API are deployed
+// contracts before signing orders for them
+func WithExchangeCodeCheck() ClientOption {
+	return func(c *ClientConfig) {
+		c.VerifyExchangeCode = true
+	}
+}
+
+// WithAuthAddressCheck makes NewClient confirm that the API key belongs to the configured
+// signer and multi-sig wallet
+func WithAuthAddressCheck() ClientOption {
+	return func(c *ClientConfig) {
+		c.VerifyAuthAddress = true
+	}
+}
+
+// WithMarketOrderPrice sets how market orders send their price field
+func WithMarketOrderPrice(mode MarketOrderPriceMode) ClientOption {
+	return func(c *ClientConfig) {
+		c.MarketOrderPrice = mode
+	}
+}
+
+// WithShutdownTimeout sets how long Close waits for in-flight requests and background work
+func WithShutdownTimeout(timeout time.Duration) ClientOption {
+	return func(c *ClientConfig) {
+		c.ShutdownTimeout = timeout
+	}
+}
+
+// WithOrderTimestampPrecision sets the unit of the timestamp sent with order requests
+func WithOrderTimestampPrecision(precision TimestampPrecision) ClientOption {
+	return func(c *ClientConfig) {
+		c.OrderTimestampPrecision = precision
+	}
+}
+
+// WithCancelConcurrency sets how many cancel requests CancelOrdersBatch runs at once
+func WithCancelConcurrency(n int) ClientOption {
+	return func(c *ClientConfig) {
+		c.CancelConcurrency = n
+	}
+}
+
+// WithOrderConcurrency sets how many orders PlaceOrdersBatch places at once
+func WithOrderConcurrency(n int) ClientOption {
+	return func(c *ClientConfig) {
+		c.OrderConcurrency = n
+	}
+}
@@ -0,0 +1,52 @@
+package opinionclob
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithOptionsAppliesDefaults(t *testing.T) {
+	f := newFakeAPI(t)
+	config := testConfig(f)
+	c, err := NewClientWithOptions(
+		WithHost(config.Host),
+		WithAPIKey(config.APIKey),
+		WithRPCURL(config.RPCURL),
+		WithPrivateKey(config.PrivateKey),
+		WithMultiSigAddr(config.MultiSigAddr),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if c.chainID != ChainIDBNBMainnet {
+		t.Errorf("chain ID = %d, want %d", c.chainID, ChainIDBNBMainnet)
+	}
+	if c.quoteTokensCacheTTL != time.Hour || c.marketCache.ttl != 5*time.Minute || c.marketCache.capacity != 1000 {
+		t.Errorf("cache TTLs = %v/%v, capacity %d; want 1h/5m, 1000", c.quoteTokensCacheTTL, c.marketCache.ttl, c.marketCache.capacity)
+	}
+	if c.submittedOrders.capacity != 1000 || c.clock == nil {
+		t.Errorf("submitted orders capacity = %d, clock set: %v", c.submittedOrders.capacity, c.clock != nil)
+	}
+	if c.apiClient.client.Timeout != 30*time.Second {
+		t.Errorf("HTTP timeout = %v, want 30s", c.apiClient.client.Timeout)
+	}
+	if _, err := c.GetQuoteTokenRegistry(false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClientWithOptionsOverridesDefaults(t *testing.T) {
+	f := newFakeAPI(t)
+	httpClient := &http.Client{Timeout: time.Second}
+	c := newTestClient(t, f, WithHTTPClient(httpClient), WithCacheTTLs(time.Minute, time.Second))
+
+	if c.apiClient.client != httpClient {
+		t.Error("WithHTTPClient was not used")
+	}
+	if c.quoteTokensCacheTTL != time.Minute || c.marketCache.ttl != time.Second {
+		t.Errorf("cache TTLs = %v/%v, want 1m/1s", c.quoteTokensCacheTTL, c.marketCache.ttl)
+	}
+}
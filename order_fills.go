@@ -0,0 +1,74 @@
+package opinionclob
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// OrderFillSummary aggregates the trades that filled an order
+type OrderFillSummary struct {
+	OrderID      string
+	TradeCount   int
+	FilledShares *big.Rat
+	FilledAmount *big.Rat // quote token amount exchanged
+	AvgFillPrice *big.Rat // share-weighted average trade price; 0 if nothing filled
+	TotalFees    *big.Rat
+}
+
+// GetOrderAvgFillPrice fetches an order's trades and computes its share-weighted
+// average fill price and total fees
+func (c *Client) GetOrderAvgFillPrice(ctx context.Context, orderID string) (*OrderFillSummary, error) {
+	order, err := c.GetOrderByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// An order has no trades from before it was created
+	var since time.Time
+	if order.CreatedAt > 0 {
+		since = time.Unix(order.CreatedAt, 0)
+	}
+	trades, err := c.allMyTrades(ctx, &order.MarketID, since, func(t *Trade) bool {
+		return t.OrderID == orderID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeOrderFills(orderID, trades)
+}
+
+// summarizeOrderFills aggregates the trades belonging to orderID
+func summarizeOrderFills(orderID string, trades []Trade) (*OrderFillSummary, error) {
+	summary := &OrderFillSummary{
+		OrderID:      orderID,
+		FilledShares: new(big.Rat),
+		FilledAmount: new(big.Rat),
+		AvgFillPrice: new(big.Rat),
+		TotalFees:    new(big.Rat),
+	}
+	notional := new(big.Rat)
+	for _, t := range trades {
+		if t.OrderID != orderID {
+			continue
+		}
+
+		amounts, err := t.Amounts()
+		if err != nil {
+			return nil, err
+		}
+
+		summary.TradeCount++
+		summary.FilledShares.Add(summary.FilledShares, amounts.Shares)
+		summary.FilledAmount.Add(summary.FilledAmount, amounts.Amount)
+		summary.TotalFees.Add(summary.TotalFees, amounts.Fee)
+		notional.Add(notional, new(big.Rat).Mul(amounts.Shares, amounts.Price))
+	}
+
+	if summary.FilledShares.Sign() > 0 {
+		summary.AvgFillPrice.Quo(notional, summary.FilledShares)
+	}
+
+	return summary, nil
+}
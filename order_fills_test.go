@@ -0,0 +1,64 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// serveFilledOrder serves order o1, created at 1985, and 100 trades created newest first
+// from 2000, two of which fill o1
+func serveFilledOrder(f *fakeAPI) {
+	f.handle("/order/o1", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"o1","marketId":1,"status":2,"createdAt":1985}}}`)
+	})
+	f.servePages("/trade", 100, func(i int) string {
+		orderID, price, shares, amount, fee := "other", "0.9", "100", "90", "1"
+		switch i {
+		case 2:
+			orderID, price, shares, amount, fee = "o1", "0.5", "10", "5", "0.1"
+		case 5:
+			orderID, price, shares, amount, fee = "o1", "0.6", "30", "18", "0.2"
+		}
+		return fmt.Sprintf(`{"orderId":%q,"tradeNo":"t%d","side":"Buy","price":%q,"shares":%q,"amount":%q,"fee":%q,"createdAt":%d}`,
+			orderID, i, price, shares, amount, fee, 2000-i)
+	})
+}
+
+func TestGetOrderAvgFillPrice(t *testing.T) {
+	f := newFakeAPI(t)
+	serveFilledOrder(f)
+	c := newTestClient(t, f)
+
+	summary, err := c.GetOrderAvgFillPrice(context.Background(), "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// (10*0.5 + 30*0.6) / 40
+	if summary.TradeCount != 2 || !ratIs(summary.FilledShares, "40") || !ratIs(summary.FilledAmount, "23") ||
+		!ratIs(summary.AvgFillPrice, "0.575") || !ratIs(summary.TotalFees, "0.3") {
+		t.Fatalf("GetOrderAvgFillPrice = %+v", summary)
+	}
+	// The second page holds only trades from before the order, so the third is never read
+	if n := f.count("/trade"); n != 2 {
+		t.Fatalf("fetched %d trade pages, want 2", n)
+	}
+}
+
+func TestGetOrderAvgFillPriceHonorsContext(t *testing.T) {
+	f := newFakeAPI(t)
+	serveFilledOrder(f)
+	c := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetOrderAvgFillPrice(ctx, "o1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if n := f.count("/trade"); n != 0 {
+		t.Fatalf("fetched %d trade pages after cancellation", n)
+	}
+}
@@ -0,0 +1,57 @@
+package opinionclob
+
+import (
+	"context"
+)
+
+// orderIteratorPageLimit is the page size used by OrderIterator (the API maximum)
+const orderIteratorPageLimit = 20
+
+// OrderIterator walks the user's orders matching a filter, fetching pages on demand
+type OrderIterator struct {
+	client   *Client
+	marketID int
+	status   string
+
+	page    int           // last page fetched
+	buffer  []OrderRecord // orders of the current page not yet returned
+	hasMore bool          // whether another page may exist
+}
+
+// IterateMyOrders returns an iterator over the user's orders. A marketID of 0 and
+// an empty status mean no filter. Pages are fetched lazily as Next is called.
+func (c *Client) IterateMyOrders(marketID int, status string) *OrderIterator {
+	return &OrderIterator{
+		client:   c,
+		marketID: marketID,
+		status:   status,
+		hasMore:  true,
+	}
+}
+
+// Next returns the next order. The boolean is false once all orders have been returned.
+func (it *OrderIterator) Next(ctx context.Context) (*OrderRecord, bool, error) {
+	for len(it.buffer) == 0 {
+		if !it.hasMore {
+			return nil, false, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		result, err := it.client.apiClient.GetMyOrdersContext(ctx, it.marketID, it.status, orderIteratorPageLimit, it.page+1)
+		if err != nil {
+			return nil, false, err
+		}
+
+		it.page++
+		it.buffer = result.Result.List
+		// A short (or empty) page means there is nothing left to fetch
+		it.hasMore = len(result.Result.List) >= orderIteratorPageLimit
+	}
+
+	order := it.buffer[0]
+	it.buffer = it.buffer[1:]
+
+	return &order, true, nil
+}
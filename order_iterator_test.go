@@ -0,0 +1,95 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// serveOrders serves total open orders "o0", "o1", ..., alternating BUY and SELL
+func serveOrders(f *fakeAPI, total int) {
+	f.servePages("/order", total, func(i int) string {
+		return fmt.Sprintf(`{"orderId":"o%d","marketId":1,"side":%d,"status":1}`, i, i%2)
+	})
+}
+
+func TestIterateMyOrders(t *testing.T) {
+	f := newFakeAPI(t)
+	serveOrders(f, 40)
+	c := newTestClient(t, f)
+
+	it := c.IterateMyOrders(0, "")
+	n := 0
+	for {
+		order, ok, err := it.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+		if want := fmt.Sprintf("o%d", n); order.OrderID != want {
+			t.Fatalf("order %d = %s, want %s", n, order.OrderID, want)
+		}
+		n++
+	}
+	if n != 40 {
+		t.Fatalf("iterated %d orders, want 40", n)
+	}
+	// Two full pages and the empty page that ends the listing
+	if pages := f.count("/order"); pages != 3 {
+		t.Fatalf("fetched %d pages, want 3", pages)
+	}
+}
+
+func TestCancelAllOrdersBySide(t *testing.T) {
+	f := newFakeAPI(t)
+	serveOrders(f, 40)
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f)
+
+	side := OrderSideSell
+	result, err := c.CancelAllOrders(nil, &side)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalOrders != 20 || result.Cancelled != 20 || f.count("/order/cancel") != 20 {
+		t.Fatalf("CancelAllOrders = %+v with %d cancel requests; want 20 SELL orders cancelled", result, f.count("/order/cancel"))
+	}
+}
+
+func TestCancelOrdersOlderThan(t *testing.T) {
+	f := newFakeAPI(t)
+	now := time.Unix(1700000000, 0)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			io.WriteString(w, `{"code":0,"msg":"ok","result":{"list":[]}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"list":[
+			{"orderId":"hour","status":1,"createdAt":%d},
+			{"orderId":"fresh","status":1,"createdAt":%d},
+			{"orderId":"twohours","status":1,"createdAt":%d}]}}`,
+			now.Add(-time.Hour).Unix(), now.Add(-10*time.Second).Unix(), now.Add(-2*time.Hour).Unix())
+	})
+	f.handle("/order/cancel", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{}}`)
+	})
+	c := newTestClient(t, f, WithClock(func() time.Time { return now }))
+
+	result, err := c.CancelOrdersOlderThan(context.Background(), nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalOrders != 2 || result.Cancelled != 2 {
+		t.Fatalf("CancelOrdersOlderThan = %+v, want 2 of 2 cancelled", result)
+	}
+	if got := fmt.Sprint(f.bodyField("/order/cancel", "order_id")); got != "[hour twohours]" {
+		t.Fatalf("cancelled %s, want [hour twohours]", got)
+	}
+}
@@ -0,0 +1,139 @@
+package opinionclob
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// OrderBookLevel represents a single price level in the orderbook
+type OrderBookLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// OrderBook represents the orderbook of a token
+type OrderBook struct {
+	Market    string           `json:"market"`
+	TokenID   string           `json:"tokenId"`
+	Timestamp int64            `json:"timestamp"`
+	Bids      []OrderBookLevel `json:"bids"`
+	Asks      []OrderBookLevel `json:"asks"`
+}
+
+// GetOrderbookResponse represents the API response for GetOrderbook
+type GetOrderbookResponse struct {
+	Code   int       `json:"code"`
+	Msg    string    `json:"msg"`
+	Result OrderBook `json:"result"`
+}
+
+// bookLevel is a parsed orderbook level
+type bookLevel struct {
+	price float64
+	size  float64
+}
+
+// fillWalk is the result of walking the book for a hypothetical order
+type fillWalk struct {
+	best      float64 // best price before the fill
+	filled    float64 // shares consumed
+	cost      float64 // quote amount spent (buy) or received (sell)
+	remaining []bookLevel
+}
+
+// levelsFor returns the levels an order on the given side consumes, best price first.
+// A BUY consumes asks (lowest first), a SELL consumes bids (highest first).
+func (ob *OrderBook) levelsFor(side OrderSide) ([]bookLevel, error) {
+	raw := ob.Asks
+	if side == OrderSideSell {
+		raw = ob.Bids
+	}
+
+	levels := make([]bookLevel, 0, len(raw))
+	for _, l := range raw {
+		price, err := strconv.ParseFloat(l.Price, 64)
+		if err != nil {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("invalid orderbook price: %s", l.Price)}
+		}
+		size, err := strconv.ParseFloat(l.Size, 64)
+		if err != nil {
+			return nil, &InvalidParamError{Message: fmt.Sprintf("invalid orderbook size: %s", l.Size)}
+		}
+		if size <= 0 {
+			continue
+		}
+		levels = append(levels, bookLevel{price: price, size: size})
+	}
+
+	sort.Slice(levels, func(i, j int) bool {
+		if side == OrderSideSell {
+			return levels[i].price > levels[j].price
+		}
+		return levels[i].price < levels[j].price
+	})
+
+	return levels, nil
+}
+
+// walk consumes up to size shares from the side of the book an order would fill against
+func (ob *OrderBook) walk(side OrderSide, size float64) (*fillWalk, error) {
+	if size <= 0 {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("size must be positive, got: %f", size)}
+	}
+
+	levels, err := ob.levelsFor(side)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &fillWalk{}
+	if len(levels) > 0 {
+		result.best = levels[0].price
+	}
+	for i, level := range levels {
+		want := size - result.filled
+		if want <= 0 {
+			result.remaining = levels[i:]
+			break
+		}
+
+		take := level.size
+		if take > want {
+			take = want
+		}
+		result.filled += take
+		result.cost += take * level.price
+
+		if take < level.size {
+			// Partially consumed level stays at the top of the book
+			rest := append([]bookLevel{{price: level.price, size: level.size - take}}, levels[i+1:]...)
+			result.remaining = rest
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// PriceImpact estimates how far the book moves if an order of size shares is filled
+// on the given side. It returns the best price left after the fill and the percentage
+// move from the current best price (positive for buys, negative for sells).
+func (ob *OrderBook) PriceImpact(side OrderSide, size float64) (float64, float64, error) {
+	result, err := ob.walk(side, size)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if result.filled < size {
+		return 0, 0, &InvalidParamError{Message: fmt.Sprintf("insufficient liquidity: only %f of %f shares available", result.filled, size)}
+	}
+	if len(result.remaining) == 0 {
+		return 0, 0, &InvalidParamError{Message: "order would consume the entire side of the book"}
+	}
+
+	newBest := result.remaining[0].price
+	move := (newBest - result.best) / result.best * 100
+
+	return newBest, move, nil
+}
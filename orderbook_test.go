@@ -0,0 +1,41 @@
+package opinionclob
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPriceImpact(t *testing.T) {
+	ob := &OrderBook{
+		// Levels are deliberately unsorted
+		Asks: []OrderBookLevel{{"0.52", "100"}, {"0.50", "50"}, {"0.55", "200"}},
+		Bids: []OrderBookLevel{{"0.48", "100"}, {"0.45", "100"}},
+	}
+
+	tests := []struct {
+		name      string
+		side      OrderSide
+		size      float64
+		wantPrice float64
+		wantMove  float64
+	}{
+		{"buy within best level", OrderSideBuy, 10, 0.50, 0},
+		{"buy through two levels", OrderSideBuy, 150, 0.55, 10},
+		{"sell through best level", OrderSideSell, 120, 0.45, -6.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, move, err := ob.PriceImpact(tt.side, tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(price-tt.wantPrice) > 1e-9 || math.Abs(move-tt.wantMove) > 1e-9 {
+				t.Fatalf("PriceImpact = %v, %v%%; want %v, %v%%", price, move, tt.wantPrice, tt.wantMove)
+			}
+		})
+	}
+
+	if _, _, err := ob.PriceImpact(OrderSideSell, 1000); err == nil {
+		t.Fatal("PriceImpact beyond the book's depth: want an error")
+	}
+}
@@ -0,0 +1,219 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// historyPageLimit is the page size used when collecting full trade or position history
+const historyPageLimit = 20
+
+// Outcome sides of a binary market
+const (
+	OutcomeSideYes = 1
+	OutcomeSideNo  = 2
+)
+
+// OutcomePosition summarizes the user's holding of one outcome of a market
+type OutcomePosition struct {
+	OutcomeSide    int
+	TokenID        string
+	NetShares      *big.Rat // shares held according to the trade history
+	ReportedShares *big.Rat // shares held according to the positions API
+	AvgEntryPrice  *big.Rat // average cost per held share
+	CostBasis      *big.Rat // NetShares * AvgEntryPrice
+	LatestPrice    *big.Rat
+	RealizedPnL    *big.Rat // profit locked in by sells, net of fees
+	UnrealizedPnL  *big.Rat // (LatestPrice - AvgEntryPrice) * NetShares
+	Fees           *big.Rat
+}
+
+// PositionSummary aggregates the user's trades in a market into per-outcome positions
+type PositionSummary struct {
+	MarketID      int
+	Outcomes      []OutcomePosition
+	RealizedPnL   *big.Rat
+	UnrealizedPnL *big.Rat
+	Fees          *big.Rat
+}
+
+// GetPositionSummary computes net shares, average entry price and realized/unrealized
+// PnL per outcome of a market from the user's trade history, positions and latest prices
+func (c *Client) GetPositionSummary(marketID int) (*PositionSummary, error) {
+	market, err := c.GetMarket(marketID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := c.allMyTrades(context.Background(), &marketID, time.Time{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := c.allMyPositions(marketID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIDs := map[int]string{
+		OutcomeSideYes: market.YesTokenID,
+		OutcomeSideNo:  market.NoTokenID,
+	}
+	prices := make(map[string]*big.Rat, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if tokenID == "" {
+			continue
+		}
+		latest, err := c.GetLatestPrice(tokenID)
+		if err != nil {
+			return nil, err
+		}
+		price, ok := new(big.Rat).SetString(latest.Result.Price)
+		if !ok {
+			return nil, &OpenAPIError{Message: fmt.Sprintf("invalid latest price for token %s: %s", tokenID, latest.Result.Price)}
+		}
+		prices[tokenID] = price
+	}
+
+	return summarizePosition(marketID, tokenIDs, trades, positions, prices)
+}
+
+// allMyTrades fetches the user's trades in a market, or in all markets if marketID is nil,
+// keeping those keep accepts (all if keep is nil). History is listed newest first, so
+// reading stops after a page with no trade at or after since; a zero since reads it all.
+func (c *Client) allMyTrades(ctx context.Context, marketID *int, since time.Time, keep func(*Trade) bool) ([]Trade, error) {
+	var trades []Trade
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, err := c.apiClient.GetMyTrades(marketID, page, historyPageLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		recent := since.IsZero()
+		for i := range result.Result.List {
+			t := &result.Result.List[i]
+			if !since.IsZero() && time.Unix(t.CreatedAt, 0).Before(since) {
+				continue
+			}
+			recent = true
+			if keep == nil || keep(t) {
+				trades = append(trades, *t)
+			}
+		}
+		if len(result.Result.List) < historyPageLimit || !recent {
+			return trades, nil
+		}
+	}
+}
+
+// GetAllMyPositions fetches every page of the user's positions, in one market or in all
+// markets if marketID is 0
+func (c *Client) GetAllMyPositions(marketID int) ([]Position, error) {
+	return c.allMyPositions(marketID)
+}
+
+// allMyPositions fetches all of the user's positions in a market
+func (c *Client) allMyPositions(marketID int) ([]Position, error) {
+	var positions []Position
+	for page := 1; ; page++ {
+		result, err := c.apiClient.GetMyPositions(marketID, page, historyPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, result.Result.List...)
+		if len(result.Result.List) < historyPageLimit {
+			return positions, nil
+		}
+	}
+}
+
+// summarizePosition replays trades in chronological order using average-cost accounting
+func summarizePosition(marketID int, tokenIDs map[int]string, trades []Trade, positions []Position, prices map[string]*big.Rat) (*PositionSummary, error) {
+	ledgers := make(map[int]*costLedger)
+	reported := make(map[int]*big.Rat)
+	outcomeTokens := make(map[int]string)
+	outcome := func(side int) {
+		if _, ok := ledgers[side]; ok {
+			return
+		}
+		ledgers[side] = newCostLedger(CostBasisAverage)
+		reported[side] = new(big.Rat)
+		outcomeTokens[side] = tokenIDs[side]
+	}
+
+	for _, t := range sortTradesByTime(trades) {
+		outcome(t.OutcomeSide)
+		if err := ledgers[t.OutcomeSide].apply(t); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range positions {
+		shares, err := parseAPIAmount("sharesOwned", p.SharesOwned)
+		if err != nil {
+			return nil, err
+		}
+		outcome(p.OutcomeSide)
+		reported[p.OutcomeSide].Add(reported[p.OutcomeSide], shares)
+		if outcomeTokens[p.OutcomeSide] == "" {
+			outcomeTokens[p.OutcomeSide] = p.TokenID
+		}
+	}
+
+	summary := &PositionSummary{
+		MarketID:      marketID,
+		RealizedPnL:   new(big.Rat),
+		UnrealizedPnL: new(big.Rat),
+		Fees:          new(big.Rat),
+	}
+	for side, ledger := range ledgers {
+		tokenID := outcomeTokens[side]
+		latest := new(big.Rat)
+		if price, ok := prices[tokenID]; ok {
+			latest.Set(price)
+		}
+		avg := ledger.avgEntryPrice()
+		unrealized := new(big.Rat).Sub(latest, avg)
+		unrealized.Mul(unrealized, ledger.shares)
+
+		summary.RealizedPnL.Add(summary.RealizedPnL, ledger.realized)
+		summary.UnrealizedPnL.Add(summary.UnrealizedPnL, unrealized)
+		summary.Fees.Add(summary.Fees, ledger.fees)
+		summary.Outcomes = append(summary.Outcomes, OutcomePosition{
+			OutcomeSide:    side,
+			TokenID:        tokenID,
+			NetShares:      ledger.shares,
+			ReportedShares: reported[side],
+			AvgEntryPrice:  avg,
+			CostBasis:      ledger.cost,
+			LatestPrice:    latest,
+			RealizedPnL:    ledger.realized,
+			UnrealizedPnL:  unrealized,
+			Fees:           ledger.fees,
+		})
+	}
+
+	sort.Slice(summary.Outcomes, func(i, j int) bool {
+		return summary.Outcomes[i].OutcomeSide < summary.Outcomes[j].OutcomeSide
+	})
+
+	return summary, nil
+}
+
+// parseAPIAmount parses a decimal amount from the API exactly, treating an empty value as zero
+func parseAPIAmount(field, value string) (*big.Rat, error) {
+	if value == "" {
+		return new(big.Rat), nil
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, &OpenAPIError{Message: fmt.Sprintf("invalid %s: %s", field, value)}
+	}
+	return r, nil
+}
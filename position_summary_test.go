@@ -0,0 +1,94 @@
+package opinionclob
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"testing"
+)
+
+// serveTrades serves list, a JSON array of trades, as the user's whole trade history
+func serveTrades(f *fakeAPI, list string) {
+	f.handle("/trade", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"list":%s}}`, list)
+	})
+}
+
+// serveLatestPrices serves the latest price of each token in prices
+func serveLatestPrices(f *fakeAPI, prices map[string]string) {
+	f.handle("/token/latest-price", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"price":"%s"}}`, prices[r.URL.Query().Get("token_id")])
+	})
+}
+
+// ratIs reports whether r is exactly the decimal want
+func ratIs(r *big.Rat, want string) bool {
+	w, ok := new(big.Rat).SetString(want)
+	return ok && r != nil && r.Cmp(w) == 0
+}
+
+func TestGetPositionSummary(t *testing.T) {
+	f := newFakeAPI(t)
+	// Out of order on purpose: the summary replays trades by time
+	serveTrades(f, `[
+		{"tradeNo":"3","side":"Sell","outcomeSide":1,"price":"0.6","shares":"50","fee":"0.5","createdAt":3},
+		{"tradeNo":"1","side":"Buy","outcomeSide":1,"price":"0.4","shares":"100","fee":"1","createdAt":1},
+		{"tradeNo":"2","side":"Buy","outcomeSide":1,"price":"0.5","shares":"100","fee":"1","createdAt":2},
+		{"tradeNo":"4","side":1,"outcomeSide":2,"price":"0.3","shares":"10","fee":"0","createdAt":4}]`)
+	f.handle("/positions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"list":[{"tokenId":"111","outcomeSide":1,"sharesOwned":"150"}]}}`)
+	})
+	serveLatestPrices(f, map[string]string{"111": "0.7", "222": "0.3"})
+	c := newTestClient(t, f)
+
+	summary, err := c.GetPositionSummary(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Outcomes) != 2 {
+		t.Fatalf("got %d outcomes, want 2", len(summary.Outcomes))
+	}
+
+	// 200 shares at an average of 0.45; selling 50 at 0.6 realizes 7.5, less 2.5 in fees.
+	// The 150 left are worth 0.25 more each at the latest price.
+	yes := summary.Outcomes[0]
+	if !ratIs(yes.NetShares, "150") || !ratIs(yes.AvgEntryPrice, "0.45") || !ratIs(yes.RealizedPnL, "5") ||
+		!ratIs(yes.UnrealizedPnL, "37.5") || !ratIs(yes.Fees, "2.5") || !ratIs(yes.ReportedShares, "150") {
+		t.Fatalf("YES outcome = %+v", yes)
+	}
+
+	// Shares sold without a recorded buy carry no cost basis
+	no := summary.Outcomes[1]
+	if !ratIs(no.NetShares, "0") || !ratIs(no.RealizedPnL, "3") {
+		t.Fatalf("NO outcome = %+v", no)
+	}
+	if !ratIs(summary.RealizedPnL, "8") || !ratIs(summary.UnrealizedPnL, "37.5") || !ratIs(summary.Fees, "2.5") {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
+
+func TestGetAllMyPositions(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/positions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":2,"list":[
+			{"marketId":1,"tokenId":"111","outcomeSide":1,"outcome":"YES","sharesOwned":"10","sharesFrozen":"2","avgEntryPrice":"0.4","currentValueInQuoteToken":"5"},
+			{"marketId":1,"tokenId":"222","outcomeSide":2,"outcome":"NO","sharesOwned":"3","avgEntryPrice":"0.6"}]}}`)
+	})
+	c := newTestClient(t, f)
+
+	positions, err := c.GetAllMyPositions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("got %d positions, want 2", len(positions))
+	}
+	yes, no := positions[0], positions[1]
+	if !yes.IsYes() || yes.TokenID != "111" || yes.SharesOwned != "10" || yes.SharesFrozen != "2" || yes.AvgEntryPrice != "0.4" || yes.CurrentValueInQuoteToken != "5" {
+		t.Errorf("YES position %+v", yes)
+	}
+	if !no.IsNo() || no.TokenID != "222" || no.SharesOwned != "3" || no.AvgEntryPrice != "0.6" || no.CurrentValueInQuoteToken != "" {
+		t.Errorf("NO position %+v", no)
+	}
+}
@@ -0,0 +1,82 @@
+package opinionclob
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TickMode controls how limit prices that are not aligned to the market's tick size are handled
+type TickMode int
+
+const (
+	// TickModeReject rejects misaligned prices with an InvalidParamError
+	TickModeReject TickMode = iota
+	// TickModeSnap moves misaligned prices onto the tick in the trader's favor:
+	// BUY prices round down and SELL prices round up
+	TickModeSnap
+)
+
+// DefaultPriceTickSize is the tick used when neither the market nor the config specifies one
+const DefaultPriceTickSize = "0.001"
+
+// ParseTickSize parses a tick size, which must be a positive multiple of 0.001 below 1
+func ParseTickSize(tickSize string) (*big.Rat, error) {
+	tick, err := parseAmount("tick size", tickSize)
+	if err != nil {
+		return nil, err
+	}
+	if tick.Sign() <= 0 || tick.Cmp(big.NewRat(1, 1)) >= 0 || !new(big.Rat).Quo(tick, priceTick).IsInt() {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("tick size must be a multiple of %s below 1, got: %q", priceTick.FloatString(3), tickSize)}
+	}
+	return tick, nil
+}
+
+// SnapPriceToTick rounds price onto a multiple of tick: down for BUY, up for SELL
+func SnapPriceToTick(price, tick *big.Rat, side OrderSide) *big.Rat {
+	steps := new(big.Rat).Quo(price, tick)
+	if steps.IsInt() {
+		return new(big.Rat).Set(price)
+	}
+
+	n := new(big.Int).Quo(steps.Num(), steps.Denom())
+	if side == OrderSideSell {
+		n.Add(n, big.NewInt(1))
+	}
+
+	return new(big.Rat).Mul(new(big.Rat).SetInt(n), tick)
+}
+
+// marketTickSize returns the market's tick size, falling back to the client's default
+func (c *Client) marketTickSize(market *Market) (*big.Rat, error) {
+	if market.TickSize == "" {
+		return c.priceTick, nil
+	}
+	tick, err := ParseTickSize(market.TickSize)
+	if err != nil {
+		return nil, &OpenAPIError{Message: fmt.Sprintf("invalid tick size for market %d: %s", market.MarketID, market.TickSize)}
+	}
+	return tick, nil
+}
+
+// alignPriceToTick checks a limit price against the market's tick, snapping it in TickModeSnap
+func (c *Client) alignPriceToTick(market *Market, price *big.Rat, side OrderSide) (*big.Rat, error) {
+	tick, err := c.marketTickSize(market)
+	if err != nil {
+		return nil, err
+	}
+
+	if new(big.Rat).Quo(price, tick).IsInt() {
+		return price, nil
+	}
+
+	if c.tickMode != TickModeSnap {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("price %s is not a multiple of the market tick size %s", price.FloatString(3), tick.FloatString(3))}
+	}
+
+	snapped := SnapPriceToTick(price, tick, side)
+	if err := validatePrice(snapped); err != nil {
+		return nil, err
+	}
+
+	return snapped, nil
+}
@@ -0,0 +1,76 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// tickMarket serves market 1 trading on a 0.01 tick
+func tickMarket(f *fakeAPI) {
+	f.handleMarket(`{"marketId":1,"status":2,"chainId":"56","quoteToken":"` + testQuoteToken + `","yesTokenId":"111","noTokenId":"222","tickSize":"0.01"}`)
+}
+
+func TestPriceTickAligned(t *testing.T) {
+	f := newFakeAPI(t)
+	tickMarket(f)
+	c := newTestClient(t, f, WithPriceTick("", TickModeReject))
+
+	if _, _, err := c.BuildSignedOrder(context.Background(), limitBuy("0.55", "10")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPriceTickMisalignedRejected(t *testing.T) {
+	f := newFakeAPI(t)
+	tickMarket(f)
+	c := newTestClient(t, f, WithPriceTick("", TickModeReject))
+
+	_, _, err := c.BuildSignedOrder(context.Background(), limitBuy("0.555", "10"))
+	var invalid *InvalidParamError
+	if !errors.As(err, &invalid) || !strings.Contains(err.Error(), "tick") {
+		t.Fatalf("error = %v, want InvalidParamError about the tick", err)
+	}
+}
+
+func TestPriceTickMisalignedSnapped(t *testing.T) {
+	f := newFakeAPI(t)
+	tickMarket(f)
+	c := newTestClient(t, f, WithPriceTick("", TickModeSnap))
+
+	// Snapping never makes an order more aggressive: BUYs round down, SELLs up
+	buy := limitBuy("0.555", "10")
+	sell := PlaceOrderDataInput{
+		MarketID:               1,
+		TokenID:                "111",
+		Side:                   OrderSideSell,
+		OrderType:              OrderTypeLimit,
+		Price:                  "0.555",
+		MakerAmountInBaseToken: strPtr("10"),
+	}
+	for _, tt := range []struct {
+		order PlaceOrderDataInput
+		want  string
+	}{
+		{buy, "0.550"},
+		{sell, "0.560"},
+	} {
+		_, request, err := c.BuildSignedOrder(context.Background(), tt.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if request["price"] != tt.want {
+			t.Errorf("%v at 0.555 snapped to %v, want %s", tt.order.Side, request["price"], tt.want)
+		}
+	}
+}
+
+func TestPriceTickSizeValidated(t *testing.T) {
+	f := newFakeAPI(t)
+	config := testConfig(f)
+	config.PriceTickSize = "0.0005"
+	if _, err := NewClient(config); err == nil || !strings.Contains(err.Error(), "price_tick_size") {
+		t.Fatalf("NewClient with a tick finer than 0.001 error = %v", err)
+	}
+}
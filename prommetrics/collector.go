@@ -0,0 +1,261 @@
+// Package prommetrics collects SDK metrics in memory and serves them in the Prometheus
+// text exposition format, without depending on the Prometheus client library.
+//
+//	collector := prommetrics.New("opinion")
+//	client, err := opinionclob.NewClientWithOptions(..., opinionclob.WithMetrics(collector))
+//	http.Handle("/metrics", collector)
+package prommetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the request latency histogram buckets in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Collector implements opinionclob.MetricsCollector and http.Handler
+type Collector struct {
+	namespace string
+	buckets   []float64
+
+	mu           sync.Mutex
+	requests     map[requestKey]*histogram
+	orders       map[string]uint64
+	reconnects   [2]uint64 // indexed by result: failure, success
+	transactions [2]uint64
+}
+
+// requestKey identifies one request latency series
+type requestKey struct {
+	method string
+	route  string
+	status int
+}
+
+// histogram is a cumulative latency histogram
+type histogram struct {
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// New creates a collector whose metric names start with namespace (which may be empty)
+func New(namespace string) *Collector {
+	return NewWithBuckets(namespace, DefaultBuckets)
+}
+
+// NewWithBuckets creates a collector with custom latency buckets in seconds
+func NewWithBuckets(namespace string, buckets []float64) *Collector {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Collector{
+		namespace: namespace,
+		buckets:   sorted,
+		requests:  make(map[requestKey]*histogram),
+		orders:    make(map[string]uint64),
+	}
+}
+
+// ObserveRequest records an API request latency
+func (c *Collector) ObserveRequest(method, route string, status int, duration time.Duration) {
+	seconds := duration.Seconds()
+	key := requestKey{method: method, route: route, status: status}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, ok := c.requests[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(c.buckets))}
+		c.requests[key] = h
+	}
+	for i, upper := range c.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// IncOrder counts an order event
+func (c *Collector) IncOrder(event string) {
+	c.mu.Lock()
+	c.orders[event]++
+	c.mu.Unlock()
+}
+
+// IncReconnect counts a WebSocket reconnect attempt
+func (c *Collector) IncReconnect(success bool) {
+	c.mu.Lock()
+	c.reconnects[resultIndex(success)]++
+	c.mu.Unlock()
+}
+
+// IncTransaction counts a transaction broadcast
+func (c *Collector) IncTransaction(success bool) {
+	c.mu.Lock()
+	c.transactions[resultIndex(success)]++
+	c.mu.Unlock()
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	c.mu.Lock()
+	c.writeRequests(cw)
+	c.writeOrders(cw)
+	c.writeResults(cw, "ws_reconnects_total", "WebSocket reconnect attempts by result.", c.reconnects)
+	c.writeResults(cw, "transactions_total", "Transaction broadcasts by result.", c.transactions)
+	c.mu.Unlock()
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+// writeRequests writes the request latency histograms and the error counter
+func (c *Collector) writeRequests(w *countingWriter) {
+	keys := make([]requestKey, 0, len(c.requests))
+	for key := range c.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	name := c.name("api_request_duration_seconds")
+	w.printf("# HELP %s API request latency by method, route and HTTP status (0 = no response).\n", name)
+	w.printf("# TYPE %s histogram\n", name)
+	for _, key := range keys {
+		h := c.requests[key]
+		labels := fmt.Sprintf("method=%s,route=%s,status=\"%d\"", quote(key.method), quote(key.route), key.status)
+		var cumulative uint64
+		for i, upper := range c.buckets {
+			cumulative += h.counts[i]
+			w.printf("%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(upper), cumulative)
+		}
+		w.printf("%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		w.printf("%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+		w.printf("%s_count{%s} %d\n", name, labels, h.count)
+	}
+
+	// Failed requests summed over methods so alerts need no histogram arithmetic
+	errors := make(map[requestKey]uint64)
+	for _, key := range keys {
+		if key.status == 0 || key.status >= 400 {
+			errors[requestKey{route: key.route, status: key.status}] += c.requests[key].count
+		}
+	}
+	errorKeys := make([]requestKey, 0, len(errors))
+	for key := range errors {
+		errorKeys = append(errorKeys, key)
+	}
+	sort.Slice(errorKeys, func(i, j int) bool {
+		if errorKeys[i].route != errorKeys[j].route {
+			return errorKeys[i].route < errorKeys[j].route
+		}
+		return errorKeys[i].status < errorKeys[j].status
+	})
+
+	name = c.name("api_request_errors_total")
+	w.printf("# HELP %s API requests that failed or returned an HTTP error, by route and status.\n", name)
+	w.printf("# TYPE %s counter\n", name)
+	for _, key := range errorKeys {
+		w.printf("%s{route=%s,status=\"%d\"} %d\n", name, quote(key.route), key.status, errors[key])
+	}
+}
+
+// writeOrders writes the order event counter
+func (c *Collector) writeOrders(w *countingWriter) {
+	events := make([]string, 0, len(c.orders))
+	for event := range c.orders {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	name := c.name("orders_total")
+	w.printf("# HELP %s Orders by event (placed, rejected, cancelled).\n", name)
+	w.printf("# TYPE %s counter\n", name)
+	for _, event := range events {
+		w.printf("%s{event=%s} %d\n", name, quote(event), c.orders[event])
+	}
+}
+
+// writeResults writes a counter split into failure and success
+func (c *Collector) writeResults(w *countingWriter, metric, help string, counts [2]uint64) {
+	name := c.name(metric)
+	w.printf("# HELP %s %s\n", name, help)
+	w.printf("# TYPE %s counter\n", name)
+	w.printf("%s{result=\"failure\"} %d\n", name, counts[0])
+	w.printf("%s{result=\"success\"} %d\n", name, counts[1])
+}
+
+// name prefixes a metric with the namespace
+func (c *Collector) name(metric string) string {
+	if c.namespace == "" {
+		return metric
+	}
+	return c.namespace + "_" + metric
+}
+
+// resultIndex maps a result to its slot in a [failure, success] array
+func resultIndex(success bool) int {
+	if success {
+		return 1
+	}
+	return 0
+}
+
+// labelEscaper escapes label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote formats a label value, escaping backslashes, quotes and newlines
+func quote(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+// formatFloat formats a sample value the way Prometheus expects
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// countingWriter tracks bytes written and the first error
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+// printf writes formatted output unless an earlier write failed
+func (cw *countingWriter) printf(format string, args ...interface{}) {
+	if cw.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(cw.w, format, args...)
+	cw.n += int64(n)
+	cw.err = err
+}
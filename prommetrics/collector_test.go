@@ -0,0 +1,34 @@
+package prommetrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollectorExposition(t *testing.T) {
+	c := New("op")
+	c.ObserveRequest("GET", "/market/:id", 200, 30*time.Millisecond)
+	c.ObserveRequest("GET", "/market/:id", 500, 2*time.Second)
+	c.IncOrder("placed")
+	c.IncReconnect(true)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`op_api_request_duration_seconds_bucket{method="GET",route="/market/:id",status="200",le="0.05"} 1`,
+		`op_api_request_duration_seconds_bucket{method="GET",route="/market/:id",status="500",le="1"} 0`,
+		`op_api_request_duration_seconds_bucket{method="GET",route="/market/:id",status="500",le="2.5"} 1`,
+		`op_api_request_errors_total{route="/market/:id",status="500"} 1`,
+		`op_orders_total{event="placed"} 1`,
+		`op_ws_reconnects_total{result="success"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package opinionclob
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// QuoteTokenRegistry indexes the supported quote tokens by address so that the
+// CTF exchange and decimals of a quote token can be looked up in one place
+type QuoteTokenRegistry struct {
+	tokens map[string]QuoteToken // checksummed quote token address -> quote token
+}
+
+// NewQuoteTokenRegistry builds a registry from a list of quote tokens
+func NewQuoteTokenRegistry(tokens []QuoteToken) *QuoteTokenRegistry {
+	registry := &QuoteTokenRegistry{tokens: make(map[string]QuoteToken, len(tokens))}
+	for _, qt := range tokens {
+		registry.tokens[normalizeHexAddress(qt.QuoteTokenAddress)] = qt
+	}
+	return registry
+}
+
+// Get returns the quote token with the given address (case-insensitive)
+func (r *QuoteTokenRegistry) Get(quoteToken string) (*QuoteToken, bool) {
+	qt, ok := r.tokens[normalizeHexAddress(quoteToken)]
+	if !ok {
+		return nil, false
+	}
+	return &qt, true
+}
+
+// ExchangeFor returns the CTF exchange address used to trade against quoteToken
+func (r *QuoteTokenRegistry) ExchangeFor(quoteToken string) (string, error) {
+	qt, ok := r.Get(quoteToken)
+	if !ok {
+		return "", &OpenAPIError{Message: fmt.Sprintf("quote token not supported: %s", quoteToken)}
+	}
+	return normalizeHexAddress(qt.CTFExchangeAddress), nil
+}
+
+// DecimalsFor returns the number of decimals of quoteToken
+func (r *QuoteTokenRegistry) DecimalsFor(quoteToken string) (int, error) {
+	qt, ok := r.Get(quoteToken)
+	if !ok {
+		return 0, &OpenAPIError{Message: fmt.Sprintf("quote token not supported: %s", quoteToken)}
+	}
+	return qt.Decimal, nil
+}
+
+// ExchangeMapping returns the full quote token address -> CTF exchange address mapping.
+// The returned map is a copy and may be modified by the caller.
+func (r *QuoteTokenRegistry) ExchangeMapping() map[string]string {
+	mapping := make(map[string]string, len(r.tokens))
+	for addr, qt := range r.tokens {
+		mapping[addr] = normalizeHexAddress(qt.CTFExchangeAddress)
+	}
+	return mapping
+}
+
+// Len returns the number of supported quote tokens
+func (r *QuoteTokenRegistry) Len() int {
+	return len(r.tokens)
+}
+
+// normalizeHexAddress returns the checksummed form of a hex address
+func normalizeHexAddress(addr string) string {
+	return common.HexToAddress(addr).Hex()
+}
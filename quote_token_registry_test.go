@@ -0,0 +1,47 @@
+package opinionclob
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuoteTokenRegistry(t *testing.T) {
+	r := NewQuoteTokenRegistry([]QuoteToken{{
+		QuoteTokenAddress:  strings.ToLower(testQuoteToken),
+		CTFExchangeAddress: strings.ToLower(testExchange),
+		Decimal:            6,
+	}})
+
+	// Lookups ignore address case and return checksummed addresses
+	exchange, err := r.ExchangeFor(strings.ToUpper(testQuoteToken[2:]))
+	if err != nil || exchange != testExchange {
+		t.Fatalf("ExchangeFor = %q, %v; want %q", exchange, err, testExchange)
+	}
+	if decimals, err := r.DecimalsFor(testQuoteToken); err != nil || decimals != 6 {
+		t.Fatalf("DecimalsFor = %d, %v; want 6", decimals, err)
+	}
+	if _, err := r.DecimalsFor("0x0000000000000000000000000000000000000001"); err == nil {
+		t.Fatal("DecimalsFor an unknown token: want an error")
+	}
+	if m := r.ExchangeMapping(); len(m) != 1 || m[testQuoteToken] != testExchange {
+		t.Fatalf("ExchangeMapping = %v", m)
+	}
+}
+
+func TestGetQuoteTokenRegistryIsCached(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	for i := 0; i < 3; i++ {
+		r, err := c.GetQuoteTokenRegistry(true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Len() != 1 {
+			t.Fatalf("registry has %d tokens, want 1", r.Len())
+		}
+	}
+	if n := f.count("/quoteToken"); n != 1 {
+		t.Fatalf("fetched quote tokens %d times, want 1", n)
+	}
+}
@@ -0,0 +1,88 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// RedeemAll redeems every resolved market in which the user holds shares of the winning
+// outcome, one transaction per market in ascending market order. Markets whose
+// redemption fails are skipped and their errors joined into the returned error, along
+// with the successful results. It stops before the next redemption once ctx is done.
+func (c *Client) RedeemAll(ctx context.Context) ([]TransactionResult, error) {
+	if err := c.requireSigner(); err != nil {
+		return nil, err
+	}
+
+	marketIDs, err := c.redeemableMarketIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TransactionResult
+	var errs []error
+	for _, marketID := range marketIDs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		result, err := c.Redeem(ctx, marketID, false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("market %d: %w", marketID, err))
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// redeemableMarketIDs returns the resolved markets in which the user holds shares of
+// the winning outcome. If a resolved market does not report its winning token, any
+// holding makes it redeemable.
+func (c *Client) redeemableMarketIDs(ctx context.Context) ([]int, error) {
+	positions, err := c.allMyPositions(0)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[int][]string) // market ID -> token IDs with shares
+	for _, p := range positions {
+		shares, err := parseAPIAmount("sharesOwned", p.SharesOwned)
+		if err != nil {
+			return nil, err
+		}
+		if shares.Sign() > 0 {
+			held[p.MarketID] = append(held[p.MarketID], p.TokenID)
+		}
+	}
+
+	var marketIDs []int
+	for marketID, tokenIDs := range held {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Fetched fresh so a market that resolved within the cache TTL is not skipped
+		market, err := c.GetMarket(marketID, false)
+		if err != nil {
+			return nil, err
+		}
+		if TopicStatus(market.Status) != TopicStatusResolved {
+			continue
+		}
+
+		for _, tokenID := range tokenIDs {
+			if market.ResultTokenID == "" || tokenID == market.ResultTokenID {
+				marketIDs = append(marketIDs, marketID)
+				break
+			}
+		}
+	}
+
+	sort.Ints(marketIDs)
+	return marketIDs, nil
+}
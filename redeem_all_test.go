@@ -0,0 +1,89 @@
+package opinionclob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// serveRedeemFixture serves positions in markets 2 to 6: 2 and 6 are resolved with the
+// user holding the winning token, 3 is still active, 4 resolved to the other outcome
+// and 5 is resolved but the position is empty
+func serveRedeemFixture(f *fakeAPI) {
+	f.handle("/positions", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"total":5,"list":[
+			{"marketId":2,"tokenId":"21","sharesOwned":"5"},
+			{"marketId":3,"tokenId":"31","sharesOwned":"5"},
+			{"marketId":4,"tokenId":"42","sharesOwned":"5"},
+			{"marketId":5,"tokenId":"51","sharesOwned":"0"},
+			{"marketId":6,"tokenId":"61","sharesOwned":"1.5"}]}}`)
+	})
+	statuses := map[int]TopicStatus{2: TopicStatusResolved, 3: TopicStatusActivated, 4: TopicStatusResolved, 5: TopicStatusResolved, 6: TopicStatusResolved}
+	for id, status := range statuses {
+		f.handleMarket(fmt.Sprintf(`{"marketId":%d,"status":%d,"chainId":"56","quoteToken":"%s","yesTokenId":"%d1","noTokenId":"%d2","resultTokenId":"%d1","conditionId":"ab"}`,
+			id, status, testQuoteToken, id, id, id))
+	}
+}
+
+func TestRedeemableMarketIDs(t *testing.T) {
+	f := newFakeAPI(t)
+	serveRedeemFixture(f)
+	c := newTestClient(t, f)
+
+	ids, err := c.redeemableMarketIDs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 6 {
+		t.Fatalf("redeemable markets = %v, want [2 6]", ids)
+	}
+
+	// A market cached before it resolved is still found
+	if _, err := c.GetMarket(3, true); err != nil {
+		t.Fatal(err)
+	}
+	f.handleMarket(fmt.Sprintf(`{"marketId":3,"status":%d,"resultTokenId":"31"}`, TopicStatusResolved))
+	ids, err = c.redeemableMarketIDs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[1] != 3 {
+		t.Fatalf("redeemable markets after market 3 resolved = %v, want [2 3 6]", ids)
+	}
+}
+
+func TestRedeemAllOnlyRedeemsResolvedMarkets(t *testing.T) {
+	f := newFakeAPI(t)
+	serveRedeemFixture(f)
+	c := newTestClient(t, f)
+
+	// The fake chain holds no tokens, so each redemption fails; the joined errors show
+	// which markets were attempted and that one failure does not stop the rest
+	results, err := c.RedeemAll(context.Background())
+	if err == nil {
+		t.Fatal("expected the redemption errors")
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results, want none", len(results))
+	}
+	for _, id := range []int{2, 6} {
+		if !strings.Contains(err.Error(), fmt.Sprintf("market %d:", id)) {
+			t.Errorf("market %d was not redeemed: %v", id, err)
+		}
+	}
+	for _, id := range []int{3, 4, 5} {
+		if strings.Contains(err.Error(), fmt.Sprintf("market %d:", id)) {
+			t.Errorf("market %d was redeemed: %v", id, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.RedeemAll(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("RedeemAll with a cancelled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,84 @@
+package opinionclob
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// SubmittedOrder is the exact signed order and request body the client sent for an order
+type SubmittedOrder struct {
+	OrderID     string
+	SignedOrder SignedOrder
+	Request     map[string]interface{}
+	SubmittedAt time.Time
+}
+
+// GetSubmittedOrder returns the signed payload submitted for orderID, if it is still
+// retained. Only the most recently submitted orders are kept.
+func (c *Client) GetSubmittedOrder(orderID string) (*SubmittedOrder, bool) {
+	return c.submittedOrders.get(orderID)
+}
+
+// submittedOrderCache is a bounded LRU of submitted orders keyed by order ID
+type submittedOrderCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front = most recently used
+	items    map[string]*list.Element
+}
+
+func newSubmittedOrderCache(capacity int) *submittedOrderCache {
+	return &submittedOrderCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *submittedOrderCache) add(order *SubmittedOrder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[order.OrderID]; ok {
+		elem.Value = order
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[order.OrderID] = c.order.PushFront(order)
+
+	// Evict the least recently used entry
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*SubmittedOrder).OrderID)
+	}
+}
+
+func (c *submittedOrderCache) get(orderID string) (*SubmittedOrder, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[orderID]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+
+	return elem.Value.(*SubmittedOrder), true
+}
+
+// findByClientOrderID returns the retained order submitted with clientOrderID, if any
+func (c *submittedOrderCache) findByClientOrderID(clientOrderID string) (*SubmittedOrder, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		order := elem.Value.(*SubmittedOrder)
+		if id, _ := order.Request["client_order_id"].(string); id == clientOrderID {
+			return order, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,54 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetSubmittedOrder(t *testing.T) {
+	f := newFakeAPI(t)
+	var placed atomic.Int32
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":0,"msg":"ok","result":{"orderData":{"orderId":"ord-%d"}}}`, placed.Add(1))
+	})
+	config := testConfig(f)
+	config.SubmittedOrdersCacheSize = 2
+	c := newTestClient(t, f, WithConfig(config))
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := c.GetSubmittedOrder("ord-1"); ok {
+		t.Fatal("oldest order was not evicted")
+	}
+	submitted, ok := c.GetSubmittedOrder("ord-3")
+	if !ok {
+		t.Fatal("latest order not retained")
+	}
+	sent := f.lastBody("/order")
+	if submitted.OrderID != "ord-3" || submitted.SignedOrder.Signature != sent["signature"] || submitted.Request["salt"] != sent["salt"] {
+		t.Fatalf("GetSubmittedOrder = %+v, sent %v", submitted, sent)
+	}
+}
+
+func TestGetSubmittedOrderSkipsRejectedOrders(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/order", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":10001,"msg":"rejected"}`)
+	})
+	c := newTestClient(t, f)
+
+	if _, err := c.PlaceOrder(context.Background(), limitBuy("0.5", "10"), false); err == nil {
+		t.Fatal("rejected order reported success")
+	}
+	if _, ok := c.GetSubmittedOrder(""); ok {
+		t.Fatal("rejected order was retained")
+	}
+}
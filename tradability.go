@@ -0,0 +1,96 @@
+package opinionclob
+
+import (
+	"context"
+	"time"
+)
+
+// TradabilityReason explains why a market can or cannot be traded
+type TradabilityReason int
+
+const (
+	MarketTradable TradabilityReason = iota
+	MarketNotActivated
+	MarketPastCutoff
+	MarketResolving
+	MarketResolved
+	MarketNoLiquidity
+	// MarketTradabilityUnknown comes with an error when the market could not be fetched
+	MarketTradabilityUnknown
+)
+
+// String returns a short name for the reason
+func (r TradabilityReason) String() string {
+	switch r {
+	case MarketTradable:
+		return "tradable"
+	case MarketNotActivated:
+		return "not-activated"
+	case MarketPastCutoff:
+		return "past-cutoff"
+	case MarketResolving:
+		return "resolving"
+	case MarketResolved:
+		return "resolved"
+	case MarketNoLiquidity:
+		return "no-liquidity"
+	case MarketTradabilityUnknown:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// IsMarketTradable reports whether orders can be placed on a market right now, and why not
+// otherwise. The market is fetched fresh, bypassing the cache, so a recent change of status
+// is seen. With checkLiquidity, a market whose outcome orderbooks are all empty is reported
+// as MarketNoLiquidity.
+func (c *Client) IsMarketTradable(ctx context.Context, marketID int, checkLiquidity bool) (bool, TradabilityReason, error) {
+	market, err := c.GetMarket(marketID, false)
+	if err != nil {
+		return false, MarketTradabilityUnknown, err
+	}
+
+	reason := marketTradability(market, c.clock())
+	if reason != MarketTradable || !checkLiquidity {
+		return reason == MarketTradable, reason, nil
+	}
+
+	for _, tokenID := range []string{market.YesTokenID, market.NoTokenID} {
+		if tokenID == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return false, MarketTradable, err
+		}
+		book, err := c.GetOrderbook(tokenID)
+		if err != nil {
+			return false, MarketTradable, err
+		}
+		if len(book.Bids) > 0 || len(book.Asks) > 0 {
+			return true, MarketTradable, nil
+		}
+	}
+
+	return false, MarketNoLiquidity, nil
+}
+
+// marketTradability checks a market's status and cutoff time at now
+func marketTradability(market *Market, now time.Time) TradabilityReason {
+	switch TopicStatus(market.Status) {
+	case TopicStatusActivated:
+	case TopicStatusResolving:
+		return MarketResolving
+	case TopicStatusResolved:
+		return MarketResolved
+	default:
+		return MarketNotActivated
+	}
+
+	// CutoffAt is in Unix seconds; 0 means no cutoff
+	if market.CutoffAt > 0 && !now.Before(time.Unix(market.CutoffAt, 0)) {
+		return MarketPastCutoff
+	}
+
+	return MarketTradable
+}
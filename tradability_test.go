@@ -0,0 +1,84 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsMarketTradable(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	emptyBook := `{"bids":[],"asks":[]}`
+	tests := []struct {
+		name   string
+		status TopicStatus
+		cutoff int64
+		book   string
+		want   TradabilityReason
+	}{
+		{"created", TopicStatusCreated, 0, emptyBook, MarketNotActivated},
+		{"resolving", TopicStatusResolving, 0, emptyBook, MarketResolving},
+		{"resolved", TopicStatusResolved, 0, emptyBook, MarketResolved},
+		{"at cutoff", TopicStatusActivated, now.Unix(), emptyBook, MarketPastCutoff},
+		{"past cutoff", TopicStatusActivated, now.Unix() - 1, emptyBook, MarketPastCutoff},
+		{"empty books", TopicStatusActivated, now.Unix() + 100, emptyBook, MarketNoLiquidity},
+		{"liquid", TopicStatusActivated, 0, `{"bids":[{"price":"0.5","size":"1"}],"asks":[]}`, MarketTradable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeAPI(t)
+			f.handleMarket(fmt.Sprintf(`{"marketId":1,"status":%d,"cutoffAt":%d,"yesTokenId":"111","noTokenId":"222"}`, tt.status, tt.cutoff))
+			f.handle("/token/orderbook", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"code":0,"msg":"ok","result":%s}`, tt.book)
+			})
+			c := newTestClient(t, f, WithClock(func() time.Time { return now }))
+
+			ok, reason, err := c.IsMarketTradable(context.Background(), 1, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reason != tt.want || ok != (tt.want == MarketTradable) {
+				t.Fatalf("IsMarketTradable = %v, %s; want %s", ok, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMarketTradableWithoutLiquidityCheck(t *testing.T) {
+	f := newFakeAPI(t)
+	f.handle("/token/orderbook", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"code":0,"msg":"ok","result":{"bids":[],"asks":[]}}`)
+	})
+	c := newTestClient(t, f)
+
+	ok, reason, err := c.IsMarketTradable(context.Background(), 1, false)
+	if err != nil || !ok || reason != MarketTradable {
+		t.Fatalf("IsMarketTradable = %v, %s, %v; want tradable", ok, reason, err)
+	}
+	if n := f.count("/token/orderbook"); n != 0 {
+		t.Fatalf("fetched %d orderbooks without a liquidity check", n)
+	}
+}
+
+func TestIsMarketTradableSeesFreshStatus(t *testing.T) {
+	f := newFakeAPI(t)
+	c := newTestClient(t, f)
+
+	// Cache the market while it is still activated, then resolve it
+	if _, err := c.GetMarket(1, true); err != nil {
+		t.Fatal(err)
+	}
+	f.handleMarket(fmt.Sprintf(`{"marketId":1,"status":%d}`, TopicStatusResolved))
+	ok, reason, err := c.IsMarketTradable(context.Background(), 1, false)
+	if err != nil || ok || reason != MarketResolved {
+		t.Fatalf("IsMarketTradable = %v, %s, %v; want resolved", ok, reason, err)
+	}
+
+	ok, reason, err = c.IsMarketTradable(context.Background(), 9, false)
+	if err == nil || ok || reason != MarketTradabilityUnknown {
+		t.Fatalf("IsMarketTradable of a missing market = %v, %s, %v; want unknown with an error", ok, reason, err)
+	}
+}
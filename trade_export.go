@@ -0,0 +1,72 @@
+package opinionclob
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// tradesCSVHeader lists the columns written by ExportTradesCSV
+var tradesCSVHeader = []string{"time", "market", "side", "price", "shares", "amount", "fee", "usdAmount", "txHash"}
+
+// ExportTradesCSV writes the user's trades created in [from, to) across all markets to w
+// as CSV, oldest first. A zero from or to leaves that end of the range open.
+func (c *Client) ExportTradesCSV(ctx context.Context, w io.Writer, from, to time.Time) error {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return &InvalidParamError{Message: "from must be before to"}
+	}
+
+	trades, err := c.allMyTrades(ctx, nil, from, func(t *Trade) bool {
+		return to.IsZero() || time.Unix(t.CreatedAt, 0).Before(to)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeTradesCSV(w, trades)
+}
+
+// writeTradesCSV writes trades as CSV sorted by creation time
+func writeTradesCSV(w io.Writer, trades []Trade) error {
+	sorted := append([]Trade(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt < sorted[j].CreatedAt })
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(tradesCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, t := range sorted {
+		record := []string{
+			time.Unix(t.CreatedAt, 0).UTC().Format(time.RFC3339),
+			strconv.Itoa(t.MarketID),
+			tradeSideName(t.Side),
+			t.Price,
+			t.Shares,
+			t.Amount,
+			t.Fee,
+			t.UsdAmount,
+			t.TxHash,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write trade %s: %w", t.TradeNo, err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// tradeSideName returns "buy" or "sell" for a trade side
+func tradeSideName(side OrderSide) string {
+	if side == OrderSideSell {
+		return "sell"
+	}
+	return "buy"
+}
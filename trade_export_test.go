@@ -0,0 +1,51 @@
+package opinionclob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestExportTradesCSV(t *testing.T) {
+	f := newFakeAPI(t)
+	serveTrades(f, `[
+		{"tradeNo":"t3","marketId":1,"side":"Buy","price":"0.7","shares":"1","amount":"0.7","fee":"0","usdAmount":"0.7","txHash":"0xlate","createdAt":1700000300},
+		{"tradeNo":"t2","marketId":2,"side":"Sell","price":"0.6","shares":"5","amount":"3","fee":"0.01","usdAmount":"3","txHash":"0xb","createdAt":1700000200},
+		{"tradeNo":"t1","marketId":1,"side":"Buy","price":"0.5","shares":"10","amount":"5","fee":"0.02","usdAmount":"5","txHash":"0xa","createdAt":1700000100},
+		{"tradeNo":"t0","marketId":1,"side":"Buy","price":"0.5","shares":"10","amount":"5","fee":"0.02","usdAmount":"5","txHash":"0xold","createdAt":1600000000}
+	]`)
+	c := newTestClient(t, f)
+
+	var buf bytes.Buffer
+	if err := c.ExportTradesCSV(context.Background(), &buf, time.Unix(1700000000, 0), time.Unix(1700000300, 0)); err != nil {
+		t.Fatal(err)
+	}
+	want := "time,market,side,price,shares,amount,fee,usdAmount,txHash\n" +
+		"2023-11-14T22:15:00Z,1,buy,0.5,10,5,0.02,5,0xa\n" +
+		"2023-11-14T22:16:40Z,2,sell,0.6,5,3,0.01,3,0xb\n"
+	if buf.String() != want {
+		t.Fatalf("got CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestExportTradesCSVStopsAtFrom(t *testing.T) {
+	f := newFakeAPI(t)
+	// Newest first, one trade per second: only the first page is in range
+	f.servePages("/trade", 100, func(i int) string {
+		return fmt.Sprintf(`{"tradeNo":"t%d","marketId":1,"side":"Buy","price":"0.5","shares":"1","amount":"0.5","createdAt":%d}`, i, 1700000000-i)
+	})
+	c := newTestClient(t, f)
+
+	var buf bytes.Buffer
+	if err := c.ExportTradesCSV(context.Background(), &buf, time.Unix(1700000000-10, 0), time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if rows := bytes.Count(buf.Bytes(), []byte("\n")) - 1; rows != 11 {
+		t.Fatalf("wrote %d rows, want 11", rows)
+	}
+	if n := f.count("/trade"); n != 2 {
+		t.Fatalf("fetched %d pages, want 2", n)
+	}
+}
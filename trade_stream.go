@@ -0,0 +1,118 @@
+package opinionclob
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// StreamMyTrades returns a channel of the user's trades created after since, oldest first,
+// followed by live trades from live (typically WSClient.Messages() subscribed to
+// ChannelTradeRecord). Historical trades are fetched before it returns. marketID limits
+// both to one market (or root market for live trades); nil streams all markets. Trades are
+// deduplicated by trade number. The channel is closed when ctx is done or the client is
+// closed, once history is sent if live is nil, or when live is closed.
+func (c *Client) StreamMyTrades(ctx context.Context, marketID *int, since time.Time, live <-chan WSMessageEnvelope) (<-chan Trade, error) {
+	history, err := c.allMyTrades(ctx, marketID, since, func(t *Trade) bool {
+		return time.Unix(t.CreatedAt, 0).After(since)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].CreatedAt < history[j].CreatedAt })
+
+	trades := make(chan Trade)
+	err = c.goBackground(ctx, func(ctx context.Context) {
+		c.streamTrades(ctx, marketID, history, live, trades)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
+
+// streamTrades sends history and then live trades until ctx is done or live is closed.
+// Live trades with an unrecognized side are logged and skipped.
+func (c *Client) streamTrades(ctx context.Context, marketID *int, history []Trade, live <-chan WSMessageEnvelope, trades chan<- Trade) {
+	defer close(trades)
+
+	seen := make(map[string]bool, len(history))
+	send := func(t Trade) bool {
+		if t.TradeNo != "" {
+			if seen[t.TradeNo] {
+				return true
+			}
+			seen[t.TradeNo] = true
+		}
+		select {
+		case trades <- t:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for _, t := range history {
+		if !send(t) {
+			return
+		}
+	}
+
+	if live == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case env, ok := <-live:
+			if !ok {
+				return
+			}
+			record, isTrade := env.Data.(*TradeRecord)
+			if !isTrade {
+				continue
+			}
+			if marketID != nil && record.MarketID != *marketID && record.RootMarketID != *marketID {
+				continue
+			}
+			trade, err := record.Trade()
+			if err != nil {
+				c.logger.Warn("skipping live trade", "tradeNo", record.TradeNo, "error", err)
+				continue
+			}
+			if !send(trade) {
+				return
+			}
+		}
+	}
+}
+
+// Trade converts a WebSocket trade record to the trade history type, so live and
+// historical trades can be processed alike. It fails if the side is not recognized.
+func (r *TradeRecord) Trade() (Trade, error) {
+	side, err := r.OrderSide()
+	if err != nil {
+		return Trade{}, err
+	}
+
+	return Trade{
+		OrderID:      r.OrderID,
+		TradeNo:      r.TradeNo,
+		MarketID:     r.MarketID,
+		RootMarketID: r.RootMarketID,
+		TxHash:       r.TxHash,
+		Side:         side,
+		OutcomeSide:  r.OutcomeSide,
+		Price:        r.Price,
+		Shares:       r.Shares,
+		Amount:       r.Amount,
+		Fee:          r.Fee,
+		UsdAmount:    r.UsdAmount,
+		Profit:       r.Profit,
+		Status:       r.Status,
+		QuoteToken:   r.QuoteToken,
+		CreatedAt:    r.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package opinionclob
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStreamMyTrades(t *testing.T) {
+	f := newFakeAPI(t)
+	serveTrades(f, `[
+		{"tradeNo":"t2","marketId":1,"side":"Buy","createdAt":200},
+		{"tradeNo":"t1","marketId":1,"side":"Buy","createdAt":150},
+		{"tradeNo":"t0","marketId":1,"side":"Buy","createdAt":100}
+	]`)
+	c := newTestClient(t, f)
+
+	live := make(chan WSMessageEnvelope, 5)
+	live <- WSMessageEnvelope{Channel: ChannelTradeRecord, Data: &TradeRecord{TradeNo: "t2", MarketID: 1, Side: "Buy"}}
+	live <- WSMessageEnvelope{Channel: ChannelOrderUpdate, Data: &OrderUpdate{}}
+	live <- WSMessageEnvelope{Channel: ChannelTradeRecord, Data: &TradeRecord{TradeNo: "t9", MarketID: 2, Side: "Buy"}}
+	live <- WSMessageEnvelope{Channel: ChannelTradeRecord, Data: &TradeRecord{TradeNo: "t4", MarketID: 1, Side: "Hold"}}
+	live <- WSMessageEnvelope{Channel: ChannelTradeRecord, Data: &TradeRecord{TradeNo: "t3", MarketID: 1, Side: "Sell"}}
+	close(live)
+
+	marketID := 1
+	trades, err := c.StreamMyTrades(context.Background(), &marketID, time.Unix(100, 0), live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for trade := range trades {
+		got = append(got, fmt.Sprintf("%s:%d", trade.TradeNo, trade.Side))
+	}
+	// History after since, oldest first, then new live trades of the market with a known side
+	want := fmt.Sprintf("[t1:%d t2:%d t3:%d]", OrderSideBuy, OrderSideBuy, OrderSideSell)
+	if fmt.Sprint(got) != want {
+		t.Fatalf("streamed %v, want %s", got, want)
+	}
+}
+
+func TestStreamMyTradesStopsAtSince(t *testing.T) {
+	f := newFakeAPI(t)
+	// Newest first, one trade per second: only the first page is after since
+	f.servePages("/trade", 100, func(i int) string {
+		return fmt.Sprintf(`{"tradeNo":"t%d","marketId":1,"side":"Buy","createdAt":%d}`, i, 1000-i)
+	})
+	c := newTestClient(t, f)
+
+	trades, err := c.StreamMyTrades(context.Background(), nil, time.Unix(1000-5, 0), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for range trades {
+		n++
+	}
+	if n != 5 {
+		t.Fatalf("streamed %d trades, want 5", n)
+	}
+	if pages := f.count("/trade"); pages != 2 {
+		t.Fatalf("fetched %d pages, want 2", pages)
+	}
+}
+
+func TestStreamMyTradesStopsOnContext(t *testing.T) {
+	f := newFakeAPI(t)
+	serveTrades(f, `[{"tradeNo":"t1","marketId":1,"side":"Buy","createdAt":150}]`)
+	c := newTestClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	trades, err := c.StreamMyTrades(ctx, nil, time.Time{}, make(chan WSMessageEnvelope))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-trades
+	cancel()
+	for range trades {
+	}
+}
@@ -1,7 +1,9 @@
 package opinionclob
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -12,45 +14,143 @@ const (
 	ZeroAddress = "0x0000000000000000000000000000000000000000"
 )
 
+// RoundingMode selects how digits beyond a token's decimals are handled when converting to wei
+type RoundingMode int
+
+const (
+	// RoundDown truncates extra digits (the default)
+	RoundDown RoundingMode = iota
+	// RoundHalfUp rounds to the nearest wei, with halves rounded up
+	RoundHalfUp
+	// RoundUp rounds any remainder up to the next wei, so an amount is never under-funded
+	RoundUp
+)
+
+// AmountToWei converts a human-readable decimal amount string to wei units exactly.
+// Digits beyond decimals are truncated.
+func AmountToWei(amount string, decimals int) (*big.Int, error) {
+	return AmountToWeiRounded(amount, decimals, RoundDown)
+}
+
+// AmountToWeiRounded is like AmountToWei, but rounds digits beyond decimals using mode
+func AmountToWeiRounded(amount string, decimals int, mode RoundingMode) (*big.Int, error) {
+	r, err := parseAmount("amount", amount)
+	if err != nil {
+		return nil, err
+	}
+	return ratToWeiRounded(r, decimals, mode)
+}
+
+// WeiToAmount formats a wei value as a human-readable decimal string without trailing zeros
+func WeiToAmount(value *big.Int, decimals int) string {
+	if value == nil {
+		return "0"
+	}
+	if decimals <= 0 {
+		return value.String()
+	}
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(value).String()
+
+	// Left-pad so there is at least one integer digit
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integerPart := digits[:len(digits)-decimals]
+	decimalPart := strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if decimalPart == "" {
+		return sign + integerPart
+	}
+
+	return sign + integerPart + "." + decimalPart
+}
+
 // SafeAmountToWei safely converts human-readable amount to wei units
+//
+// Deprecated: float64 amounts can lose precision before conversion; use AmountToWei.
 func SafeAmountToWei(amount float64, decimals int) (*big.Int, error) {
+	if err := validateFloatAmount(amount); err != nil {
+		return nil, err
+	}
+
+	return AmountToWei(strconv.FormatFloat(amount, 'f', -1, 64), decimals)
+}
+
+// SafeAmountToWeiRounded is like SafeAmountToWei, but rounds digits beyond decimals using mode
+//
+// Deprecated: float64 amounts can lose precision before conversion; use AmountToWeiRounded.
+func SafeAmountToWeiRounded(amount float64, decimals int, mode RoundingMode) (*big.Int, error) {
+	if err := validateFloatAmount(amount); err != nil {
+		return nil, err
+	}
+
+	return AmountToWeiRounded(strconv.FormatFloat(amount, 'f', -1, 64), decimals, mode)
+}
+
+// validateFloatAmount checks that a float amount is finite and positive
+func validateFloatAmount(amount float64) error {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return &InvalidParamError{Message: fmt.Sprintf("amount must be a finite number, got: %v", amount)}
+	}
 	if amount <= 0 {
-		return nil, &InvalidParamError{Message: fmt.Sprintf("amount must be positive, got: %f", amount)}
+		return &InvalidParamError{Message: fmt.Sprintf("amount must be positive, got: %f", amount)}
 	}
+	return nil
+}
 
-	if decimals < 0 || decimals > MaxDecimals {
-		return nil, &InvalidParamError{Message: fmt.Sprintf("decimals must be between 0 and %d, got: %d", MaxDecimals, decimals)}
+// parseAmount parses a plain decimal amount string exactly
+func parseAmount(name, amount string) (*big.Rat, error) {
+	// big.Rat also accepts fractions and exponents; amounts must be plain decimals
+	if amount == "" || strings.ContainsAny(amount, "/eE") {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid %s: %q", name, amount)}
 	}
 
-	// Convert to string for precision
-	amountStr := strconv.FormatFloat(amount, 'f', -1, 64)
-	
-	// Split into integer and decimal parts
-	parts := strings.Split(amountStr, ".")
-	if len(parts) > 2 {
-		return nil, &InvalidParamError{Message: "invalid amount format"}
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid %s: %q", name, amount)}
 	}
 
-	integerPart := parts[0]
-	decimalPart := ""
-	if len(parts) == 2 {
-		decimalPart = parts[1]
+	return r, nil
+}
+
+// ratToWei scales amount by 10^decimals, truncating, and checks the result is a positive uint256
+func ratToWei(amount *big.Rat, decimals int) (*big.Int, error) {
+	return ratToWeiRounded(amount, decimals, RoundDown)
+}
+
+// ratToWeiRounded scales amount by 10^decimals, rounding with mode, and checks the result
+// is a positive uint256
+func ratToWeiRounded(amount *big.Rat, decimals int, mode RoundingMode) (*big.Int, error) {
+	if mode < RoundDown || mode > RoundUp {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid rounding mode: %d", mode)}
 	}
 
-	// Pad or truncate decimal part to match decimals
-	if len(decimalPart) > decimals {
-		decimalPart = decimalPart[:decimals]
-	} else {
-		decimalPart = decimalPart + strings.Repeat("0", decimals-len(decimalPart))
+	if amount.Sign() <= 0 {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("amount must be positive, got: %s", amount.FloatString(MaxDecimals))}
 	}
 
-	// Combine integer and decimal parts
-	combined := integerPart + decimalPart
+	if decimals < 0 || decimals > MaxDecimals {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("decimals must be between 0 and %d, got: %d", MaxDecimals, decimals)}
+	}
 
-	// Convert to big.Int
-	result, ok := new(big.Int).SetString(combined, 10)
-	if !ok {
-		return nil, &InvalidParamError{Message: "failed to convert amount to big.Int"}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	scaled := new(big.Rat).Mul(amount, new(big.Rat).SetInt(scale))
+	// amount is positive, so Quo truncation is the floor
+	result, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if remainder.Sign() != 0 {
+		switch mode {
+		case RoundUp:
+			result.Add(result, big.NewInt(1))
+		case RoundHalfUp:
+			if new(big.Int).Lsh(remainder, 1).Cmp(scaled.Denom()) >= 0 {
+				result.Add(result, big.NewInt(1))
+			}
+		}
 	}
 
 	// Validate result fits in uint256
@@ -67,70 +167,94 @@ func SafeAmountToWei(amount float64, decimals int) (*big.Int, error) {
 	return result, nil
 }
 
-// CalculateOrderAmounts calculates maker and taker amounts based on price and side
-func CalculateOrderAmounts(price float64, makerAmount *big.Int, side OrderSide, decimals int) (*big.Int, *big.Int, error) {
-	// Validate price
-	if price <= 0.001 || price >= 0.999 {
-		return nil, nil, &InvalidParamError{Message: fmt.Sprintf("price must be between 0.001 and 0.999, got: %f", price)}
-	}
+// Limit order prices must lie between MinPrice and MaxPrice, both included
+var (
+	MinPrice = big.NewRat(1, 1000)
+	MaxPrice = big.NewRat(999, 1000)
+)
 
-	// Convert price to fraction for exact representation
-	// Simplified version - in production, use proper fraction library
-	priceBig := new(big.Float).SetFloat64(price)
-	
-	var recalculatedMakerAmount, takerAmount *big.Int
+// ParsePrice parses a decimal price string exactly and validates its range
+func ParsePrice(price string) (*big.Rat, error) {
+	// big.Rat also accepts fractions and exponents; prices must be plain decimals
+	if price == "" || strings.ContainsAny(price, "/eE") {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid price: %q", price)}
+	}
 
-	if side == OrderSideBuy {
-		// For BUY: price = maker/taker, so taker = maker/price
-		// Round maker to 4 significant digits
-		maker4Digit := roundToSignificantDigits(makerAmount, 4)
-		
-		// Calculate taker amount
-		takerFloat := new(big.Float).Quo(new(big.Float).SetInt(maker4Digit), priceBig)
-		takerAmount, _ = takerFloat.Int(nil)
-		recalculatedMakerAmount = maker4Digit
-	} else {
-		// For SELL: price = taker/maker, so taker = maker*price
-		// Round maker to 4 significant digits
-		maker4Digit := roundToSignificantDigits(makerAmount, 4)
-		
-		// Calculate taker amount
-		takerFloat := new(big.Float).Mul(new(big.Float).SetInt(maker4Digit), priceBig)
-		takerAmount, _ = takerFloat.Int(nil)
-		recalculatedMakerAmount = maker4Digit
+	// Name NaN and infinities explicitly rather than reporting a generic parse failure
+	switch strings.ToLower(strings.TrimLeft(price, "+-")) {
+	case "nan", "inf", "infinity":
+		return nil, &InvalidParamError{Message: fmt.Sprintf("price must be a finite number, got: %q", price)}
 	}
 
-	// Ensure amounts are at least 1
-	if takerAmount.Sign() <= 0 {
-		takerAmount = big.NewInt(1)
+	r, ok := new(big.Rat).SetString(price)
+	if !ok {
+		return nil, &InvalidParamError{Message: fmt.Sprintf("invalid price: %q", price)}
 	}
-	if recalculatedMakerAmount.Sign() <= 0 {
-		recalculatedMakerAmount = big.NewInt(1)
+
+	if err := validatePrice(r); err != nil {
+		return nil, err
 	}
 
-	return recalculatedMakerAmount, takerAmount, nil
+	return r, nil
+}
+
+// priceTick is the price granularity: prices are whole multiples of 1/1000
+var priceTick = big.NewRat(1, 1000)
+
+// validatePrice checks that price lies within [MinPrice, MaxPrice] on a 0.001 tick
+func validatePrice(price *big.Rat) error {
+	if price.Cmp(MinPrice) < 0 || price.Cmp(MaxPrice) > 0 {
+		return &InvalidParamError{Message: fmt.Sprintf("price must be between %s and %s, got: %s", MinPrice.FloatString(3), MaxPrice.FloatString(3), price.FloatString(6))}
+	}
+	if !new(big.Rat).Quo(price, priceTick).IsInt() {
+		return &InvalidParamError{Message: fmt.Sprintf("price must be a multiple of %s, got: %s", priceTick.FloatString(3), price.FloatString(6))}
+	}
+	return nil
 }
 
-func roundToSignificantDigits(value *big.Int, n int) *big.Int {
-	if value.Sign() == 0 {
-		return big.NewInt(0)
+// CalculateOrderAmounts calculates maker and taker amounts based on price and side.
+// The amounts satisfy the price exactly: with price = num/den in lowest terms,
+// taker = maker*den/num for BUY and maker*num/den for SELL, so maker is rounded
+// down to a multiple of num (BUY) or den (SELL).
+func CalculateOrderAmounts(price *big.Rat, makerAmount *big.Int, side OrderSide, decimals int) (*big.Int, *big.Int, error) {
+	if price == nil {
+		return nil, nil, &InvalidParamError{Message: "price is required"}
+	}
+	if err := validatePrice(price); err != nil {
+		return nil, nil, err
 	}
 
-	// Get magnitude (number of digits)
-	magnitude := len(value.String())
+	// big.Rat keeps price in lowest terms
+	num, den := price.Num(), price.Denom()
 
-	// If already n digits or fewer, return as-is
-	if magnitude <= n {
-		return new(big.Int).Set(value)
+	var divisor, multiplier *big.Int
+	if side == OrderSideBuy {
+		// For BUY: price = maker/taker, so taker = maker*den/num
+		divisor, multiplier = num, den
+	} else {
+		// For SELL: price = taker/maker, so taker = maker*num/den
+		divisor, multiplier = den, num
 	}
 
-	// Calculate divisor to round to n significant digits
-	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(magnitude-n)), nil)
+	units := new(big.Int).Quo(makerAmount, divisor)
+	if units.Sign() <= 0 {
+		return nil, nil, &InvalidParamError{Message: fmt.Sprintf("maker amount %s is too small for price %s", makerAmount.String(), price.FloatString(3))}
+	}
 
-	// Round to n significant digits
-	rounded := new(big.Int).Div(value, divisor)
-	rounded.Mul(rounded, divisor)
+	recalculatedMakerAmount := new(big.Int).Mul(units, divisor)
+	takerAmount := new(big.Int).Mul(units, multiplier)
 
-	return rounded
+	return recalculatedMakerAmount, takerAmount, nil
 }
 
+// decodeHexBytes decodes a hex string with an optional 0x/0X prefix
+func decodeHexBytes(s string) ([]byte, error) {
+	digits := s
+	if len(digits) >= 2 && digits[0] == '0' && (digits[1] == 'x' || digits[1] == 'X') {
+		digits = digits[2:]
+	}
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("hex string %q has an odd number of digits", s)
+	}
+	return hex.DecodeString(digits)
+}
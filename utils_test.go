@@ -0,0 +1,321 @@
+package opinionclob
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		price string
+		want  string // exact value as a fraction; empty if the price is invalid
+	}{
+		{"0.001", "1/1000"},
+		{"0.999", "999/1000"},
+		{"0.5", "1/2"},
+		{"0.50", "1/2"},
+		{"0.123", "123/1000"},
+		{"0.0009", ""},
+		{"0", ""},
+		{"1", ""},
+		{"1.0", ""},
+		{"-0.5", ""},
+		{"0.0015", ""}, // between ticks
+		{"1/3", ""},
+		{"5e-1", ""},
+		{"NaN", ""},
+		{"abc", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := ParsePrice(tt.price)
+		if tt.want == "" {
+			var invalid *InvalidParamError
+			if !errors.As(err, &invalid) {
+				t.Errorf("ParsePrice(%q) error = %v, want InvalidParamError", tt.price, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePrice(%q) error: %v", tt.price, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParsePrice(%q) = %s, want %s", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestAmountToWeiIsExact(t *testing.T) {
+	tests := []struct {
+		amount string
+		want   string
+	}{
+		{"0.1", "100000000000000000"},
+		{"1.000000000000000001", "1000000000000000001"},
+		{"123456789.123456789123456789", "123456789123456789123456789"},
+		{"99999999999999999.99", "99999999999999999990000000000000000"},
+	}
+	for _, tt := range tests {
+		got, err := AmountToWei(tt.amount, 18)
+		if err != nil {
+			t.Errorf("AmountToWei(%q) error: %v", tt.amount, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("AmountToWei(%q) = %s, want %s", tt.amount, got, tt.want)
+		}
+	}
+
+	// The same amount as a float64 has already lost its last digits
+	viaFloat, err := SafeAmountToWei(99999999999999999.99, 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if viaFloat.String() == "99999999999999999990000000000000000" {
+		t.Error("float64 conversion unexpectedly exact; pick a value float64 cannot represent")
+	}
+}
+
+func TestAmountToWeiRejectsInvalidAmounts(t *testing.T) {
+	for _, amount := range []string{"", "0", "-1", "1e3", "1/2", "abc"} {
+		if _, err := AmountToWei(amount, 18); err == nil {
+			t.Errorf("AmountToWei(%q) succeeded", amount)
+		}
+	}
+	// Digits beyond the token's decimals are truncated
+	if got, err := AmountToWei("1.999", 2); err != nil || got.String() != "199" {
+		t.Errorf("AmountToWei(\"1.999\", 2) = %v, %v; want 199", got, err)
+	}
+}
+
+func TestWeiToAmountRoundTrips(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		decimals int
+		want     string
+	}{
+		{0.5, 6, "0.5"},
+		{1, 6, "1"},
+		{123.456, 6, "123.456"},
+		{0.000001, 6, "0.000001"},
+		{1000000, 6, "1000000"},
+		{0.5, 18, "0.5"},
+		{10, 18, "10"},
+		{123.456, 18, "123.456"},
+	}
+	for _, tt := range tests {
+		wei, err := SafeAmountToWei(tt.amount, tt.decimals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := WeiToAmount(wei, tt.decimals); got != tt.want {
+			t.Errorf("WeiToAmount(SafeAmountToWei(%g, %d)) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestWeiToAmountFormatting(t *testing.T) {
+	tests := []struct {
+		value    *big.Int
+		decimals int
+		want     string
+	}{
+		{big.NewInt(1500000), 6, "1.5"},
+		{big.NewInt(0), 6, "0"},
+		{nil, 6, "0"},
+		{big.NewInt(-1), 6, "-0.000001"},
+		{big.NewInt(12), 0, "12"},
+	}
+	for _, tt := range tests {
+		if got := WeiToAmount(tt.value, tt.decimals); got != tt.want {
+			t.Errorf("WeiToAmount(%v, %d) = %s, want %s", tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateOrderAmountsIsExact(t *testing.T) {
+	tests := []struct {
+		price     string
+		maker     string
+		side      OrderSide
+		wantMaker string
+		wantTaker string
+	}{
+		// BUY: maker is a multiple of the price numerator, taker = maker*den/num
+		{"0.333", "1000", OrderSideBuy, "999", "3000"},
+		{"0.667", "1000", OrderSideBuy, "667", "1000"},
+		{"0.333", "10000000000000000000", OrderSideBuy, "9999999999999999990", "30030030030030030000"},
+		// SELL: maker is a multiple of the price denominator, taker = maker*num/den
+		{"0.333", "1500", OrderSideSell, "1000", "333"},
+		{"0.667", "3000", OrderSideSell, "3000", "2001"},
+		// Amounts are not rounded to significant digits
+		{"0.5", "12345", OrderSideBuy, "12345", "24690"},
+		{"0.5", "12345", OrderSideSell, "12344", "6172"},
+	}
+	for _, tt := range tests {
+		price, err := ParsePrice(tt.price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		maker, _ := new(big.Int).SetString(tt.maker, 10)
+		gotMaker, gotTaker, err := CalculateOrderAmounts(price, maker, tt.side, 18)
+		if err != nil {
+			t.Errorf("CalculateOrderAmounts(%s, %s, %v) error: %v", tt.price, tt.maker, tt.side, err)
+			continue
+		}
+		if gotMaker.String() != tt.wantMaker || gotTaker.String() != tt.wantTaker {
+			t.Errorf("CalculateOrderAmounts(%s, %s, %v) = %s, %s; want %s, %s", tt.price, tt.maker, tt.side, gotMaker, gotTaker, tt.wantMaker, tt.wantTaker)
+		}
+
+		// The amounts imply exactly the requested price
+		implied := new(big.Rat).SetFrac(gotMaker, gotTaker)
+		if tt.side == OrderSideSell {
+			implied.Inv(implied)
+		}
+		if implied.Cmp(price) != 0 {
+			t.Errorf("CalculateOrderAmounts(%s, %s, %v) implies price %s", tt.price, tt.maker, tt.side, implied.FloatString(6))
+		}
+	}
+}
+
+func TestCalculateOrderAmountsRejectsTinyMaker(t *testing.T) {
+	price, err := ParsePrice("0.333")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid *InvalidParamError
+	if _, _, err := CalculateOrderAmounts(price, big.NewInt(332), OrderSideBuy, 18); !errors.As(err, &invalid) {
+		t.Fatalf("error = %v, want InvalidParamError", err)
+	}
+}
+
+func TestAmountToWeiRounded(t *testing.T) {
+	tests := []struct {
+		amount string
+		mode   RoundingMode
+		want   string
+	}{
+		{"1.2345", RoundDown, "123"},
+		{"1.2345", RoundHalfUp, "123"},
+		{"1.2345", RoundUp, "124"},
+		{"1.235", RoundDown, "123"},
+		{"1.235", RoundHalfUp, "124"},
+		{"1.23", RoundUp, "123"},
+		{"0.001", RoundUp, "1"},
+	}
+	for _, tt := range tests {
+		got, err := AmountToWeiRounded(tt.amount, 2, tt.mode)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("AmountToWeiRounded(%q, 2, %d) = %v, %v; want %s", tt.amount, tt.mode, got, err, tt.want)
+		}
+	}
+
+	// Truncation stays the default, so a value that rounds to zero is rejected
+	if _, err := AmountToWei("0.001", 2); err == nil {
+		t.Error("AmountToWei(0.001, 2) succeeded, want an error")
+	}
+	if got, err := SafeAmountToWeiRounded(1.239, 2, RoundHalfUp); err != nil || got.String() != "124" {
+		t.Errorf("SafeAmountToWeiRounded(1.239, 2, RoundHalfUp) = %v, %v; want 124", got, err)
+	}
+	if got, err := SafeAmountToWei(1.239, 2); err != nil || got.String() != "123" {
+		t.Errorf("SafeAmountToWei(1.239, 2) = %v, %v; want 123", got, err)
+	}
+	if _, err := AmountToWeiRounded("1.239", 2, RoundingMode(9)); err == nil {
+		t.Error("expected an error for an unknown rounding mode")
+	}
+}
+
+func TestDecodeHexBytes(t *testing.T) {
+	for _, in := range []string{"abcd", "0xabcd", "0XABCD", "0xAbCd"} {
+		got, err := decodeHexBytes(in)
+		if err != nil || len(got) != 2 || got[0] != 0xab || got[1] != 0xcd {
+			t.Errorf("decodeHexBytes(%q) = %x, %v; want abcd", in, got, err)
+		}
+	}
+	for _, in := range []string{"abc", "0xabc", "0xzz", "x0ab", "0x0xab"} {
+		if got, err := decodeHexBytes(in); err == nil {
+			t.Errorf("decodeHexBytes(%q) = %x, want an error", in, got)
+		}
+	}
+	if got, err := decodeHexBytes("0x"); err != nil || len(got) != 0 {
+		t.Errorf("decodeHexBytes(0x) = %x, %v; want empty", got, err)
+	}
+}
+
+func TestCalculateOrderAmountsAtPriceExtremes(t *testing.T) {
+	maker, err := AmountToWei("10", 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"0.001", "0.999"} {
+		price, err := ParsePrice(p)
+		if err != nil {
+			t.Fatalf("ParsePrice(%s): %v", p, err)
+		}
+		for _, side := range []OrderSide{OrderSideBuy, OrderSideSell} {
+			gotMaker, gotTaker, err := CalculateOrderAmounts(price, maker, side, 18)
+			if err != nil {
+				t.Errorf("CalculateOrderAmounts(%s, %v): %v", p, side, err)
+				continue
+			}
+			if gotMaker.Sign() <= 0 || gotTaker.Sign() <= 0 {
+				t.Errorf("CalculateOrderAmounts(%s, %v) = %s, %s; want positive amounts", p, side, gotMaker, gotTaker)
+			}
+			implied := new(big.Rat).SetFrac(gotMaker, gotTaker)
+			if side == OrderSideSell {
+				implied.Inv(implied)
+			}
+			if implied.Cmp(price) != 0 {
+				t.Errorf("CalculateOrderAmounts(%s, %v) implies price %s", p, side, implied.FloatString(6))
+			}
+		}
+	}
+
+	for _, p := range []string{"0", "0.0009", "0.0005", "0.9991", "1"} {
+		if _, err := ParsePrice(p); err == nil {
+			t.Errorf("ParsePrice(%s) succeeded, want an error", p)
+		}
+	}
+	if _, _, err := CalculateOrderAmounts(big.NewRat(1, 1001), maker, OrderSideBuy, 18); err == nil {
+		t.Error("CalculateOrderAmounts accepted a price below MinPrice")
+	}
+	if _, _, err := CalculateOrderAmounts(big.NewRat(1000, 1001), maker, OrderSideSell, 18); err == nil {
+		t.Error("CalculateOrderAmounts accepted a price above MaxPrice")
+	}
+}
+
+func TestSafeAmountToWeiEdgeCases(t *testing.T) {
+	var invalid *InvalidParamError
+	for _, amount := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := SafeAmountToWei(amount, 18); !errors.As(err, &invalid) || !strings.Contains(err.Error(), "finite") {
+			t.Errorf("SafeAmountToWei(%v) = %v, want a finite-number error", amount, err)
+		}
+		if _, err := SafeAmountToWeiRounded(amount, 18, RoundUp); !errors.As(err, &invalid) {
+			t.Errorf("SafeAmountToWeiRounded(%v) = %v, want InvalidParamError", amount, err)
+		}
+	}
+
+	tests := []struct {
+		amount   float64
+		decimals int
+		want     string
+	}{
+		{42, 0, "42"},     // 0-decimal tokens map 1:1
+		{42.9, 0, "42"},   // digits beyond decimals are truncated
+		{1.239, 2, "123"}, // truncated, not rounded
+		{0.000001, 6, "1"},
+	}
+	for _, tt := range tests {
+		got, err := SafeAmountToWei(tt.amount, tt.decimals)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("SafeAmountToWei(%v, %d) = %v, %v; want %s", tt.amount, tt.decimals, got, err, tt.want)
+		}
+	}
+	if _, err := SafeAmountToWei(0.4, 0); err == nil {
+		t.Error("SafeAmountToWei(0.4, 0) succeeded, want an error for an amount that truncates to zero")
+	}
+}
@@ -3,9 +3,13 @@ package opinionclob
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"net/url"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -18,9 +22,30 @@ const (
 	// Heartbeat interval
 	HeartbeatInterval = 30 * time.Second
 
+	// DefaultPongTimeout is how long the connection may stay silent (no pong or message)
+	// before it is considered dead
+	DefaultPongTimeout = pongTimeoutHeartbeats * HeartbeatInterval
+
+	// pongTimeoutHeartbeats is the default PongTimeout in heartbeat intervals
+	pongTimeoutHeartbeats = 2
+
+	// DefaultHandshakeTimeout bounds dialing, the TLS handshake and the WebSocket upgrade
+	DefaultHandshakeTimeout = 10 * time.Second
+
+	// controlWriteTimeout bounds writing a ping/pong control frame
+	controlWriteTimeout = 10 * time.Second
+
 	// Reconnect settings
 	DefaultReconnectInterval    = 5 * time.Second
+	DefaultMaxReconnectInterval = 2 * time.Minute
 	DefaultMaxReconnectAttempts = 10
+
+	// reconnectJitterFraction is the largest share of a backoff delay removed as jitter
+	reconnectJitterFraction = 0.2
+
+	// UnlimitedReconnectAttempts makes the client retry reconnection until it succeeds
+	// or Disconnect is called
+	UnlimitedReconnectAttempts = -1
 )
 
 // WebSocket action types
@@ -39,6 +64,28 @@ const (
 	ChannelMarketLastTrade = "market.last.trade"
 )
 
+// SupportedChannels returns every channel the WebSocket API accepts subscriptions to
+func SupportedChannels() []string {
+	return []string{
+		ChannelOrderUpdate,
+		ChannelTradeRecord,
+		ChannelMarketDepthDiff,
+		ChannelMarketLastPrice,
+		ChannelMarketLastTrade,
+	}
+}
+
+// validateChannel rejects channels the server does not know, which it would otherwise
+// accept as a subscription that never delivers messages
+func validateChannel(channel string) error {
+	for _, c := range SupportedChannels() {
+		if c == channel {
+			return nil
+		}
+	}
+	return &InvalidParamError{Message: fmt.Sprintf("unsupported channel: %q", channel)}
+}
+
 // WSMessage represents a generic WebSocket message
 type WSMessage struct {
 	Action string `json:"action"`
@@ -140,6 +187,163 @@ type MarketLastTrade struct {
 	MsgType     string `json:"msgType"`
 }
 
+// OrderSide parses the trade's side into the package's OrderSide enum
+func (r *TradeRecord) OrderSide() (OrderSide, error) {
+	return parseWSSide(r.Side)
+}
+
+// OrderSide parses the trade's side into the package's OrderSide enum
+func (t *MarketLastTrade) OrderSide() (OrderSide, error) {
+	return parseWSSide(t.Side)
+}
+
+// parseWSSide maps the "Buy"/"Sell" strings used in WebSocket messages, in any case, to an OrderSide
+func parseWSSide(side string) (OrderSide, error) {
+	switch strings.ToLower(side) {
+	case "buy":
+		return OrderSideBuy, nil
+	case "sell":
+		return OrderSideSell, nil
+	}
+	return 0, &OpenAPIError{Message: fmt.Sprintf("invalid side: %q", side)}
+}
+
+// WSMessageEnvelope carries a decoded WebSocket data message.
+// Data holds one of *OrderUpdate, *TradeRecord, *MarketDepthDiff, *MarketLastPrice
+// or *MarketLastTrade depending on Channel.
+type WSMessageEnvelope struct {
+	Channel string
+	Data    interface{}
+	Raw     []byte
+}
+
+// WSOverflowPolicy controls what happens when the Messages channel is full
+type WSOverflowPolicy int
+
+const (
+	// WSOverflowDrop drops the message and counts it in DroppedMessages
+	WSOverflowDrop WSOverflowPolicy = iota
+	// WSOverflowBlock blocks reading until the consumer makes room
+	WSOverflowBlock
+)
+
+// WSState is the connection state of a WSClient
+type WSState int
+
+const (
+	// WSStateDisconnected means there is no connection and none is being attempted
+	WSStateDisconnected WSState = iota
+	// WSStateConnecting means Connect is dialing the server
+	WSStateConnecting
+	// WSStateConnected means the connection is up
+	WSStateConnected
+	// WSStateReconnecting means the connection was lost and the client is retrying
+	WSStateReconnecting
+	// WSStateFailed means connecting failed, or reconnecting gave up, and no further
+	// attempt will be made until Connect is called
+	WSStateFailed
+)
+
+// String returns a short name for the state
+func (s WSState) String() string {
+	switch s {
+	case WSStateDisconnected:
+		return "disconnected"
+	case WSStateConnecting:
+		return "connecting"
+	case WSStateConnected:
+		return "connected"
+	case WSStateReconnecting:
+		return "reconnecting"
+	case WSStateFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// wsStateChange is a state transition waiting to be reported to OnStateChange
+type wsStateChange struct {
+	state WSState
+	err   error
+}
+
+// wsHeader holds the fields of a message that decide how it is handled
+type wsHeader struct {
+	MsgType string `json:"msgType"` // set on data messages
+	Action  string `json:"action"`  // set on subscription acks
+}
+
+// decodeWSHeader decodes the header of a message read from the server
+func decodeWSHeader(data []byte) (wsHeader, error) {
+	var header wsHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return wsHeader{}, fmt.Errorf("failed to decode message: %w", err)
+	}
+	return header, nil
+}
+
+// decodeWSMessage decodes a data message with the given header into a typed envelope
+// based on its msgType. It returns nil for messages that don't belong to a known channel.
+func decodeWSMessage(header wsHeader, data []byte) (*WSMessageEnvelope, error) {
+	var payload interface{}
+	switch header.MsgType {
+	case ChannelOrderUpdate:
+		payload = &OrderUpdate{}
+	case ChannelTradeRecord:
+		payload = &TradeRecord{}
+	case ChannelMarketDepthDiff:
+		payload = &MarketDepthDiff{}
+	case ChannelMarketLastPrice:
+		payload = &MarketLastPrice{}
+	case ChannelMarketLastTrade:
+		payload = &MarketLastTrade{}
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode %s message: %w", header.MsgType, err)
+	}
+
+	return &WSMessageEnvelope{
+		Channel: header.MsgType,
+		Data:    payload,
+		Raw:     data,
+	}, nil
+}
+
+// SubscriptionAck represents the server's response to a SUBSCRIBE message.
+// Code 0 means the subscription was accepted.
+type SubscriptionAck struct {
+	Action       string `json:"action"`
+	Channel      string `json:"channel"`
+	MarketID     int    `json:"marketId"`
+	RootMarketID int    `json:"rootMarketId"`
+	Code         int    `json:"code"`
+	Msg          string `json:"msg"`
+}
+
+// subscriptionState tracks whether the server has confirmed a subscription
+type subscriptionState struct {
+	err  error         // set if the server rejected the subscription
+	done chan struct{} // closed once the server acks or rejects
+}
+
+func newSubscriptionState() *subscriptionState {
+	return &subscriptionState{done: make(chan struct{})}
+}
+
+// resolved reports whether the server has answered (must be called with subMu held)
+func (s *subscriptionState) resolved() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
 // WSEventHandler is a callback function for handling WebSocket events
 type WSEventHandler func(messageType int, data []byte)
 
@@ -148,14 +352,45 @@ type WSErrorHandler func(err error)
 
 // WSConfig holds configuration for the WebSocket client
 type WSConfig struct {
-	Endpoint             string
-	APIKey               string
+	// Endpoint is the ws:// or wss:// URL to connect to; if empty it is looked up in
+	// WSEndpoints by ChainID (DefaultWSEndpoint when ChainID is unset)
+	Endpoint string
+	ChainID  ChainID
+	APIKey   string
+	// HandshakeTimeout bounds each dial, including TLS and the WebSocket upgrade
+	// (default: DefaultHandshakeTimeout); the context passed to Connect can end it sooner
+	HandshakeTimeout time.Duration
+	// ReconnectInterval is the base delay before the first reconnect attempt; it doubles
+	// on every consecutive failure up to MaxReconnectInterval
 	ReconnectInterval    time.Duration
+	MaxReconnectInterval time.Duration
+	// MaxReconnectAttempts limits consecutive failed reconnects (0 = default, -1 = unlimited)
 	MaxReconnectAttempts int
-	OnMessage            WSEventHandler
-	OnError              WSErrorHandler
-	OnConnect            func()
-	OnDisconnect         func()
+	// HeartbeatInterval is how often HEARTBEAT messages and ping frames are sent
+	HeartbeatInterval time.Duration
+	// PongTimeout is how long to wait for a pong (or any message) before treating the
+	// connection as dead and reconnecting (default: 2 * HeartbeatInterval)
+	PongTimeout time.Duration
+	// MessageBufferSize enables the Messages channel with the given buffer size (0 = disabled)
+	MessageBufferSize int
+	// MessageOverflow selects whether a full Messages channel drops or blocks (default: drop)
+	MessageOverflow WSOverflowPolicy
+	// EnableCompression offers permessage-deflate to the server; if the server declines,
+	// the connection silently falls back to uncompressed frames
+	EnableCompression bool
+	OnMessage         WSEventHandler
+	OnError           WSErrorHandler
+	OnConnect         func()
+	OnDisconnect      func()
+	// OnStateChange is called on every connection state change, in order, with the error
+	// that caused it if any: the dial error for Failed after Connect, the read error that
+	// dropped the connection for Reconnecting, and the attempt limit error when reconnecting
+	// gives up
+	OnStateChange func(state WSState, err error)
+	// Logger receives connection and reconnect events (default: no-op)
+	Logger Logger
+	// Metrics counts reconnect attempts (default: no-op)
+	Metrics MetricsCollector
 }
 
 // WSClient is the WebSocket client for Opinion Labs
@@ -165,44 +400,135 @@ type WSClient struct {
 	mu               sync.RWMutex
 	isConnected      bool
 	subscriptions    map[string]interface{} // Track active subscriptions for reconnection
+	subStates        map[string]*subscriptionState
+	compressed       bool // permessage-deflate negotiated on the current connection
 	subMu            sync.RWMutex
 	ctx              context.Context
 	cancel           context.CancelFunc
+	connectCtx       context.Context // passed to Connect; reconnects stay bound to it
 	heartbeatTicker  *time.Ticker
 	reconnectAttempt int
+	reconnecting     bool
 	done             chan struct{}
+	messages         chan WSMessageEnvelope
+	closeMessages    sync.Once // closes messages once the goroutines that publish to it are done
+	droppedMessages  atomic.Uint64
+	closed           bool           // set by Close; the client cannot connect again
+	goroutines       sync.WaitGroup // read, heartbeat, reconnect and callback goroutines
+	state            WSState
+	stateChanges     []wsStateChange // pending OnStateChange calls, oldest first
+	notifyingState   bool            // a goroutine is delivering stateChanges
+	stateListeners   []wsStateListener
+	nextListenerID   int
+}
+
+// wsStateListener is a state change callback added with AddStateListener
+type wsStateListener struct {
+	id int
+	fn func(state WSState, err error)
 }
 
 // NewWSClient creates a new WebSocket client
 func NewWSClient(config WSConfig) *WSClient {
 	if config.Endpoint == "" {
-		config.Endpoint = DefaultWSEndpoint
+		if config.ChainID == 0 {
+			config.Endpoint = DefaultWSEndpoint
+		} else {
+			// Left empty for chains without an endpoint; Connect reports it
+			config.Endpoint, _ = lookupWSEndpoint(config.ChainID)
+		}
+	}
+	if config.HandshakeTimeout == 0 {
+		config.HandshakeTimeout = DefaultHandshakeTimeout
 	}
 	if config.ReconnectInterval == 0 {
 		config.ReconnectInterval = DefaultReconnectInterval
 	}
+	if config.MaxReconnectInterval == 0 {
+		config.MaxReconnectInterval = DefaultMaxReconnectInterval
+	}
+	if config.MaxReconnectInterval < config.ReconnectInterval {
+		config.MaxReconnectInterval = config.ReconnectInterval
+	}
 	if config.MaxReconnectAttempts == 0 {
 		config.MaxReconnectAttempts = DefaultMaxReconnectAttempts
 	}
+	if config.HeartbeatInterval == 0 {
+		config.HeartbeatInterval = HeartbeatInterval
+	}
+	if config.PongTimeout == 0 {
+		config.PongTimeout = pongTimeoutHeartbeats * config.HeartbeatInterval
+	}
+	config.Logger = loggerOrNop(config.Logger)
+	config.Metrics = metricsOrNop(config.Metrics)
 
-	return &WSClient{
+	ws := &WSClient{
 		config:        config,
 		subscriptions: make(map[string]interface{}),
+		subStates:     make(map[string]*subscriptionState),
 		done:          make(chan struct{}),
 	}
+	if config.MessageBufferSize > 0 {
+		ws.messages = make(chan WSMessageEnvelope, config.MessageBufferSize)
+	}
+
+	return ws
+}
+
+// ValidateWSEndpoint checks that endpoint is a ws:// or wss:// URL with a host
+func ValidateWSEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "ws" && u.Scheme != "wss") || u.Host == "" {
+		return &InvalidParamError{Message: fmt.Sprintf("WebSocket endpoint must be a ws or wss URL with a host, got: %q", endpoint)}
+	}
+	return nil
 }
 
-// Connect establishes a WebSocket connection
+// Messages returns the channel of decoded data messages, or nil if
+// WSConfig.MessageBufferSize is not set. The channel stays open across reconnects.
+func (ws *WSClient) Messages() <-chan WSMessageEnvelope {
+	return ws.messages
+}
+
+// DroppedMessages returns how many messages were dropped because the Messages channel was full
+func (ws *WSClient) DroppedMessages() uint64 {
+	return ws.droppedMessages.Load()
+}
+
+// Connect establishes a WebSocket connection. The connection, and every reconnect
+// after it, ends when ctx is done.
 func (ws *WSClient) Connect(ctx context.Context) error {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
+	if !ws.closed && !ws.isConnected {
+		ws.connectCtx = ctx
+	}
+	return ws.connectLocked(ctx)
+}
+
+// connectLocked establishes a connection (must be called with lock held)
+func (ws *WSClient) connectLocked(ctx context.Context) error {
+	if ws.closed {
+		return ErrWSClosed
+	}
 	if ws.isConnected {
 		return nil
 	}
 
+	// Release goroutines still bound to a previous connection
+	if ws.cancel != nil {
+		ws.cancel()
+	}
 	ws.ctx, ws.cancel = context.WithCancel(ctx)
 
+	if ws.config.Endpoint == "" {
+		return &InvalidParamError{Message: fmt.Sprintf("no WebSocket endpoint for chain_id %d; set WSConfig.Endpoint", ws.config.ChainID)}
+	}
+	if err := ValidateWSEndpoint(ws.config.Endpoint); err != nil {
+		return err
+	}
+
 	// Build WebSocket URL with API key
 	u, err := url.Parse(ws.config.Endpoint)
 	if err != nil {
@@ -213,28 +539,176 @@ func (ws *WSClient) Connect(ctx context.Context) error {
 	u.RawQuery = q.Encode()
 
 	// Establish connection
-	conn, _, err := websocket.DefaultDialer.DialContext(ws.ctx, u.String(), nil)
+	// While reconnecting the state stays Reconnecting until an attempt succeeds
+	if !ws.reconnecting {
+		ws.setStateLocked(WSStateConnecting, nil)
+	}
+	dialer := *websocket.DefaultDialer
+	dialer.EnableCompression = ws.config.EnableCompression
+	dialer.HandshakeTimeout = ws.config.HandshakeTimeout
+	conn, resp, err := dialer.DialContext(ws.ctx, u.String(), nil)
 	if err != nil {
-		return fmt.Errorf("failed to connect to WebSocket: %w", err)
+		err = fmt.Errorf("failed to connect to WebSocket: %w", err)
+		if !ws.reconnecting {
+			ws.setStateLocked(WSStateFailed, err)
+		}
+		return err
 	}
+	ws.config.Logger.Info("websocket connected", "endpoint", ws.config.Endpoint)
 
 	ws.conn = conn
+	ws.compressed = ws.config.EnableCompression && strings.Contains(resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate")
 	ws.isConnected = true
 	ws.reconnectAttempt = 0
+	ws.reconnecting = false
+	ws.setStateLocked(WSStateConnected, nil)
+
+	// Detect silently dead connections: every pong, ping or message extends the deadline
+	ws.extendReadDeadline(conn)
+	conn.SetPongHandler(func(string) error {
+		ws.extendReadDeadline(conn)
+		return nil
+	})
+	conn.SetPingHandler(func(appData string) error {
+		ws.extendReadDeadline(conn)
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(controlWriteTimeout))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
 
 	// Start heartbeat
-	ws.startHeartbeat()
+	ws.startHeartbeat(ws.ctx, conn)
 
 	// Start message reader
-	go ws.readLoop()
+	readCtx := ws.ctx
+	ws.goLocked(func() { ws.readLoop(readCtx, conn) })
 
 	if ws.config.OnConnect != nil {
-		go ws.config.OnConnect()
+		ws.goLocked(ws.config.OnConnect)
 	}
 
 	return nil
 }
 
+// State returns the current connection state
+func (ws *WSClient) State() WSState {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	return ws.state
+}
+
+// setStateLocked records a state change and queues it for OnStateChange (must be called
+// with lock held). Changes are delivered one at a time, in order, by a single goroutine,
+// so the callback never runs under the lock and may call back into the client.
+func (ws *WSClient) setStateLocked(state WSState, err error) {
+	if state == ws.state {
+		return
+	}
+	// After Close the only change left to report is the final Disconnected
+	if ws.closed && state != WSStateDisconnected {
+		return
+	}
+	ws.state = state
+
+	if ws.config.OnStateChange == nil && len(ws.stateListeners) == 0 {
+		return
+	}
+	ws.stateChanges = append(ws.stateChanges, wsStateChange{state: state, err: err})
+	if !ws.notifyingState {
+		ws.notifyingState = true
+		ws.goLocked(ws.notifyStateChanges)
+	}
+}
+
+// notifyStateChanges delivers queued state changes until none are left
+func (ws *WSClient) notifyStateChanges() {
+	for {
+		ws.mu.Lock()
+		if len(ws.stateChanges) == 0 {
+			ws.notifyingState = false
+			ws.mu.Unlock()
+			return
+		}
+		change := ws.stateChanges[0]
+		ws.stateChanges = ws.stateChanges[1:]
+		listeners := ws.stateListeners
+		ws.mu.Unlock()
+
+		if ws.config.OnStateChange != nil {
+			ws.config.OnStateChange(change.state, change.err)
+		}
+		for _, l := range listeners {
+			l.fn(change.state, change.err)
+		}
+	}
+}
+
+// AddStateListener registers fn to be called on every later state change, after
+// WSConfig.OnStateChange and in the same order. It returns a function that removes it.
+func (ws *WSClient) AddStateListener(fn func(state WSState, err error)) (remove func()) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.nextListenerID++
+	id := ws.nextListenerID
+	// Copy on write: notifyStateChanges iterates a snapshot without the lock
+	listeners := make([]wsStateListener, len(ws.stateListeners), len(ws.stateListeners)+1)
+	copy(listeners, ws.stateListeners)
+	ws.stateListeners = append(listeners, wsStateListener{id: id, fn: fn})
+
+	return func() {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		listeners := make([]wsStateListener, 0, len(ws.stateListeners))
+		for _, l := range ws.stateListeners {
+			if l.id != id {
+				listeners = append(listeners, l)
+			}
+		}
+		ws.stateListeners = listeners
+	}
+}
+
+// goLocked runs fn in a goroutine that Close waits for (must be called with lock held,
+// and not after Close)
+func (ws *WSClient) goLocked(fn func()) {
+	ws.goroutines.Add(1)
+	go func() {
+		defer ws.goroutines.Done()
+		fn()
+	}()
+}
+
+// Close disconnects, stops any reconnect loop and waits until the client's goroutines,
+// and with them all callbacks, have finished, or ctx is done. Once they have finished
+// the Messages channel is closed, even if ctx ended first. A closed client cannot connect
+// again. Close must not be called from a WSConfig callback, which it would wait for.
+func (ws *WSClient) Close(ctx context.Context) error {
+	ws.mu.Lock()
+	ws.closed = true
+	err := ws.disconnect()
+	ws.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		ws.goroutines.Wait()
+		ws.closeMessages.Do(func() {
+			if ws.messages != nil {
+				close(ws.messages)
+			}
+		})
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Disconnect closes the WebSocket connection
 func (ws *WSClient) Disconnect() error {
 	ws.mu.Lock()
@@ -245,16 +719,20 @@ func (ws *WSClient) Disconnect() error {
 
 // disconnect is the internal disconnect method (must be called with lock held)
 func (ws *WSClient) disconnect() error {
+	// Always cancel so that a pending reconnect loop stops as well
+	if ws.cancel != nil {
+		ws.cancel()
+	}
+	if ws.state != WSStateFailed {
+		ws.setStateLocked(WSStateDisconnected, nil)
+	}
+
 	if !ws.isConnected {
 		return nil
 	}
 
 	ws.isConnected = false
 
-	if ws.cancel != nil {
-		ws.cancel()
-	}
-
 	if ws.heartbeatTicker != nil {
 		ws.heartbeatTicker.Stop()
 	}
@@ -264,14 +742,86 @@ func (ws *WSClient) disconnect() error {
 		err = ws.conn.Close()
 		ws.conn = nil
 	}
+	ws.compressed = false
 
 	if ws.config.OnDisconnect != nil {
-		go ws.config.OnDisconnect()
+		ws.goLocked(ws.config.OnDisconnect)
 	}
 
 	return err
 }
 
+// UpdateAPIKey replaces the API key used to connect; later connects and reconnects use
+// it. If the client is connected it drops the connection as Disconnect does and hands
+// over to the reconnect loop, which dials with the new key and resubscribes. It waits
+// until the client has reconnected or reconnecting has stopped; if ctx ends first its
+// error is returned and the reconnect loop carries on.
+func (ws *WSClient) UpdateAPIKey(ctx context.Context, key string) error {
+	// Changes queued before the rotation are skipped; the first outcome after it is reported
+	outcome := make(chan error, 1)
+	rotated := false
+	remove := ws.AddStateListener(func(state WSState, err error) {
+		if state == WSStateReconnecting && err == errAPIKeyRotated {
+			rotated = true
+			return
+		}
+		if !rotated {
+			return
+		}
+		var result error
+		switch state {
+		case WSStateConnected:
+		case WSStateFailed:
+			result = fmt.Errorf("reconnect with new API key: %w", err)
+		case WSStateDisconnected:
+			result = errors.New("reconnect with new API key: disconnected before reconnecting")
+		default:
+			return
+		}
+		select {
+		case outcome <- result:
+		default:
+		}
+	})
+	defer remove()
+
+	ws.mu.Lock()
+	ws.config.APIKey = key
+	if ws.closed || !ws.isConnected {
+		ws.mu.Unlock()
+		return nil
+	}
+
+	if err := ws.disconnect(); err != nil {
+		ws.config.Logger.Debug("websocket close failed", "error", err)
+	}
+	// disconnect cancelled the connection context, which would stop the loop at once
+	ws.ctx, ws.cancel = context.WithCancel(ws.connectCtx)
+	ws.reconnecting = true
+	ws.setStateLocked(WSStateReconnecting, errAPIKeyRotated)
+	ws.goLocked(ws.attemptReconnect)
+	ws.mu.Unlock()
+
+	ws.config.Logger.Info("websocket reconnecting with new API key", "endpoint", ws.config.Endpoint)
+
+	select {
+	case err := <-outcome:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// errAPIKeyRotated is the cause reported to OnStateChange when UpdateAPIKey reconnects
+var errAPIKeyRotated = errors.New("API key updated")
+
+// CompressionNegotiated reports whether the current connection uses permessage-deflate
+func (ws *WSClient) CompressionNegotiated() bool {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+	return ws.compressed
+}
+
 // IsConnected returns the current connection status
 func (ws *WSClient) IsConnected() bool {
 	ws.mu.RLock()
@@ -279,42 +829,54 @@ func (ws *WSClient) IsConnected() bool {
 	return ws.isConnected
 }
 
-// SubscribeBinary subscribes to a binary market channel
+// SubscribeBinary subscribes to a binary market channel; channel must be one of SupportedChannels.
+// The subscription is pending until the server acknowledges it; see WaitForSubscription.
 func (ws *WSClient) SubscribeBinary(channel string, marketID int) error {
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+
 	msg := SubscribeBinaryMessage{
 		Action:   ActionSubscribe,
 		Channel:  channel,
 		MarketID: marketID,
 	}
 
-	if err := ws.sendMessage(msg); err != nil {
-		return err
-	}
-
-	// Track subscription for reconnection
-	ws.subMu.Lock()
-	key := fmt.Sprintf("binary:%s:%d", channel, marketID)
-	ws.subscriptions[key] = msg
-	ws.subMu.Unlock()
-
-	return nil
+	return ws.subscribe(binarySubscriptionKey(channel, marketID), msg)
 }
 
-// SubscribeCategorical subscribes to a categorical market channel
+// SubscribeCategorical subscribes to a categorical market channel; channel must be one of SupportedChannels.
+// The subscription is pending until the server acknowledges it; see WaitForSubscription.
 func (ws *WSClient) SubscribeCategorical(channel string, rootMarketID int) error {
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+
 	msg := SubscribeCategoricalMessage{
 		Action:       ActionSubscribe,
 		Channel:      channel,
 		RootMarketID: rootMarketID,
 	}
 
+	return ws.subscribe(categoricalSubscriptionKey(channel, rootMarketID), msg)
+}
+
+// subscribe sends a SUBSCRIBE message and tracks it as pending under key
+func (ws *WSClient) subscribe(key string, msg interface{}) error {
+	// Register the pending state before sending so a fast ack is not missed
+	ws.subMu.Lock()
+	ws.subStates[key] = newSubscriptionState()
+	ws.subMu.Unlock()
+
 	if err := ws.sendMessage(msg); err != nil {
+		ws.subMu.Lock()
+		delete(ws.subStates, key)
+		ws.subMu.Unlock()
 		return err
 	}
 
 	// Track subscription for reconnection
 	ws.subMu.Lock()
-	key := fmt.Sprintf("categorical:%s:%d", channel, rootMarketID)
 	ws.subscriptions[key] = msg
 	ws.subMu.Unlock()
 
@@ -334,10 +896,7 @@ func (ws *WSClient) UnsubscribeBinary(channel string, marketID int) error {
 	}
 
 	// Remove from subscriptions
-	ws.subMu.Lock()
-	key := fmt.Sprintf("binary:%s:%d", channel, marketID)
-	delete(ws.subscriptions, key)
-	ws.subMu.Unlock()
+	ws.untrack(binarySubscriptionKey(channel, marketID))
 
 	return nil
 }
@@ -355,12 +914,82 @@ func (ws *WSClient) UnsubscribeCategorical(channel string, rootMarketID int) err
 	}
 
 	// Remove from subscriptions
+	ws.untrack(categoricalSubscriptionKey(channel, rootMarketID))
+
+	return nil
+}
+
+// untrack forgets a subscription and its confirmation state
+func (ws *WSClient) untrack(key string) {
 	ws.subMu.Lock()
-	key := fmt.Sprintf("categorical:%s:%d", channel, rootMarketID)
 	delete(ws.subscriptions, key)
+	delete(ws.subStates, key)
 	ws.subMu.Unlock()
+}
 
-	return nil
+func binarySubscriptionKey(channel string, marketID int) string {
+	return fmt.Sprintf("binary:%s:%d", channel, marketID)
+}
+
+func categoricalSubscriptionKey(channel string, rootMarketID int) string {
+	return fmt.Sprintf("categorical:%s:%d", channel, rootMarketID)
+}
+
+// WaitForSubscription blocks until the server acknowledges the subscription to channel
+// for marketID (binary market id or categorical root market id), the server rejects it,
+// or timeout elapses
+func (ws *WSClient) WaitForSubscription(channel string, marketID int, timeout time.Duration) error {
+	ws.subMu.RLock()
+	state, ok := ws.subStates[binarySubscriptionKey(channel, marketID)]
+	if !ok {
+		state, ok = ws.subStates[categoricalSubscriptionKey(channel, marketID)]
+	}
+	ws.subMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no subscription to %s for market %d", channel, marketID)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-state.done:
+		return state.err
+	case <-timer.C:
+		return fmt.Errorf("timed out waiting for subscription to %s for market %d", channel, marketID)
+	}
+}
+
+// handleSubscriptionAck resolves the pending subscription an ack message refers to.
+// It reports whether data was a subscription ack.
+func (ws *WSClient) handleSubscriptionAck(data []byte) bool {
+	var ack SubscriptionAck
+	if err := json.Unmarshal(data, &ack); err != nil || ack.Action != ActionSubscribe {
+		return false
+	}
+
+	key := binarySubscriptionKey(ack.Channel, ack.MarketID)
+	if ack.RootMarketID > 0 {
+		key = categoricalSubscriptionKey(ack.Channel, ack.RootMarketID)
+	}
+
+	ws.subMu.Lock()
+	defer ws.subMu.Unlock()
+
+	state, ok := ws.subStates[key]
+	if !ok || state.resolved() {
+		return true
+	}
+
+	if ack.Code != 0 {
+		state.err = fmt.Errorf("subscription to %s rejected: %s (code %d)", ack.Channel, ack.Msg, ack.Code)
+		// A rejected subscription is not active and must not be replayed on reconnect
+		delete(ws.subscriptions, key)
+	}
+	close(state.done)
+
+	return true
 }
 
 // SubscribeOrderUpdateBinary subscribes to order updates for a binary market
@@ -453,6 +1082,73 @@ func (ws *WSClient) UnsubscribeMarketLastTradeCategorical(rootMarketID int) erro
 	return ws.UnsubscribeCategorical(ChannelMarketLastTrade, rootMarketID)
 }
 
+// binaryChannels are the channels SubscribeAllBinary subscribes to
+var binaryChannels = []string{
+	ChannelOrderUpdate,
+	ChannelTradeRecord,
+	ChannelMarketDepthDiff,
+	ChannelMarketLastPrice,
+	ChannelMarketLastTrade,
+}
+
+// categoricalChannels are the channels SubscribeAllCategorical subscribes to; depth
+// diffs are only published per binary market
+var categoricalChannels = []string{
+	ChannelOrderUpdate,
+	ChannelTradeRecord,
+	ChannelMarketLastPrice,
+	ChannelMarketLastTrade,
+}
+
+// SubscribeAllBinary subscribes to order updates, trades, depth diffs, last price and last
+// trade for a binary market. If any subscription fails, those already made are undone.
+func (ws *WSClient) SubscribeAllBinary(marketID int) error {
+	return ws.subscribeAll(binaryChannels, marketID, ws.SubscribeBinary, ws.UnsubscribeBinary, binarySubscriptionKey)
+}
+
+// UnsubscribeAllBinary removes the subscriptions made by SubscribeAllBinary, returning the first error
+func (ws *WSClient) UnsubscribeAllBinary(marketID int) error {
+	return unsubscribeAll(binaryChannels, marketID, ws.UnsubscribeBinary)
+}
+
+// SubscribeAllCategorical subscribes to order updates, trades, last price and last trade
+// for a categorical market. If any subscription fails, those already made are undone.
+func (ws *WSClient) SubscribeAllCategorical(rootMarketID int) error {
+	return ws.subscribeAll(categoricalChannels, rootMarketID, ws.SubscribeCategorical, ws.UnsubscribeCategorical, categoricalSubscriptionKey)
+}
+
+// UnsubscribeAllCategorical removes the subscriptions made by SubscribeAllCategorical, returning the first error
+func (ws *WSClient) UnsubscribeAllCategorical(rootMarketID int) error {
+	return unsubscribeAll(categoricalChannels, rootMarketID, ws.UnsubscribeCategorical)
+}
+
+// subscribeAll subscribes to each channel in turn, unwinding on the first failure
+func (ws *WSClient) subscribeAll(channels []string, id int, subscribe, unsubscribe func(string, int) error, key func(string, int) string) error {
+	for i, channel := range channels {
+		if err := subscribe(channel, id); err != nil {
+			for _, done := range channels[:i] {
+				if uerr := unsubscribe(done, id); uerr != nil {
+					// Still forget the subscription so it is not restored on reconnect
+					ws.untrack(key(done, id))
+				}
+			}
+			return fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+		}
+	}
+	return nil
+}
+
+// unsubscribeAll unsubscribes from every channel, returning the first error
+func unsubscribeAll(channels []string, id int, unsubscribe func(string, int) error) error {
+	var firstErr error
+	for _, channel := range channels {
+		if err := unsubscribe(channel, id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // sendMessage sends a message over the WebSocket connection
 func (ws *WSClient) sendMessage(msg interface{}) error {
 	ws.mu.RLock()
@@ -474,24 +1170,31 @@ func (ws *WSClient) sendMessage(msg interface{}) error {
 	return nil
 }
 
-// startHeartbeat starts the heartbeat ticker
-func (ws *WSClient) startHeartbeat() {
-	ws.heartbeatTicker = time.NewTicker(HeartbeatInterval)
+// startHeartbeat starts the heartbeat ticker (must be called with lock held)
+func (ws *WSClient) startHeartbeat(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(ws.config.HeartbeatInterval)
+	ws.heartbeatTicker = ticker
 
-	go func() {
+	ws.goLocked(func() {
 		for {
 			select {
-			case <-ws.heartbeatTicker.C:
+			case <-ticker.C:
 				if err := ws.sendHeartbeat(); err != nil {
 					if ws.config.OnError != nil {
 						ws.config.OnError(fmt.Errorf("heartbeat failed: %w", err))
 					}
 				}
-			case <-ws.ctx.Done():
+				// A missing pong lets the read deadline expire and triggers reconnection
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(controlWriteTimeout)); err != nil {
+					if ws.config.OnError != nil {
+						ws.config.OnError(fmt.Errorf("ping failed: %w", err))
+					}
+				}
+			case <-ctx.Done():
 				return
 			}
 		}
-	}()
+	})
 }
 
 // sendHeartbeat sends a heartbeat message
@@ -499,95 +1202,236 @@ func (ws *WSClient) sendHeartbeat() error {
 	return ws.sendMessage(HeartbeatMessage{Action: ActionHeartbeat})
 }
 
+// extendReadDeadline pushes the read deadline of conn out by PongTimeout
+func (ws *WSClient) extendReadDeadline(conn *websocket.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(ws.config.PongTimeout))
+}
+
 // readLoop continuously reads messages from the WebSocket
-func (ws *WSClient) readLoop() {
+func (ws *WSClient) readLoop(ctx context.Context, conn *websocket.Conn) {
 	for {
 		select {
-		case <-ws.ctx.Done():
+		case <-ctx.Done():
 			return
 		default:
-			ws.mu.RLock()
-			conn := ws.conn
-			ws.mu.RUnlock()
-
-			if conn == nil {
-				return
-			}
-
 			messageType, data, err := conn.ReadMessage()
 			if err != nil {
+				if ctx.Err() != nil {
+					// Disconnect closed the connection; there is nothing to report
+					return
+				}
 				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-					ws.handleDisconnect()
+					ws.handleDisconnect(conn, err)
 					return
 				}
 				if ws.config.OnError != nil {
 					ws.config.OnError(fmt.Errorf("read error: %w", err))
 				}
-				ws.handleDisconnect()
+				ws.handleDisconnect(conn, err)
 				return
 			}
+			ws.extendReadDeadline(conn)
+
+			// The header is decoded once; only acks are decoded again in full
+			header, headerErr := decodeWSHeader(data)
+			if headerErr == nil && header.Action == ActionSubscribe {
+				ws.handleSubscriptionAck(data)
+			}
 
 			if ws.config.OnMessage != nil {
 				ws.config.OnMessage(messageType, data)
 			}
+
+			if ws.messages != nil {
+				if headerErr != nil {
+					if ws.config.OnError != nil {
+						ws.config.OnError(headerErr)
+					}
+				} else {
+					ws.publish(ctx, header, data)
+				}
+			}
+		}
+	}
+}
+
+// publish decodes a data message with the given header and delivers it to the Messages channel
+func (ws *WSClient) publish(ctx context.Context, header wsHeader, data []byte) {
+	envelope, err := decodeWSMessage(header, data)
+	if err != nil {
+		if ws.config.OnError != nil {
+			ws.config.OnError(err)
 		}
+		return
+	}
+	if envelope == nil {
+		return
+	}
+
+	if ws.config.MessageOverflow == WSOverflowBlock {
+		select {
+		case ws.messages <- *envelope:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	select {
+	case ws.messages <- *envelope:
+	default:
+		ws.droppedMessages.Add(1)
 	}
 }
 
-// handleDisconnect handles disconnection and attempts reconnection
-func (ws *WSClient) handleDisconnect() {
+// handleDisconnect handles disconnection of conn, caused by cause, and attempts reconnection
+func (ws *WSClient) handleDisconnect(conn *websocket.Conn, cause error) {
 	ws.mu.Lock()
+	if ws.conn != conn {
+		// A newer connection has already replaced this one
+		ws.mu.Unlock()
+		return
+	}
 	wasConnected := ws.isConnected
 	ws.isConnected = false
 	if ws.heartbeatTicker != nil {
 		ws.heartbeatTicker.Stop()
 	}
+	if ws.conn != nil {
+		ws.conn.Close()
+		ws.conn = nil
+	}
+	// Only one reconnect loop may run at a time, and none once closed
+	startReconnect := !ws.reconnecting && !ws.closed
+	if startReconnect {
+		ws.reconnecting = true
+		ws.setStateLocked(WSStateReconnecting, cause)
+		ws.goroutines.Add(1)
+	}
 	ws.mu.Unlock()
 
+	if wasConnected {
+		ws.config.Logger.Warn("websocket disconnected", "endpoint", ws.config.Endpoint)
+	}
 	if wasConnected && ws.config.OnDisconnect != nil {
 		ws.config.OnDisconnect()
 	}
 
+	if !startReconnect {
+		return
+	}
+
 	// Attempt reconnection
-	go ws.attemptReconnect()
+	go func() {
+		defer ws.goroutines.Done()
+		ws.attemptReconnect()
+	}()
 }
 
-// attemptReconnect attempts to reconnect to the WebSocket
+// attemptReconnect attempts to reconnect to the WebSocket until it succeeds,
+// the attempt limit is reached, or Disconnect is called
 func (ws *WSClient) attemptReconnect() {
-	for ws.reconnectAttempt < ws.config.MaxReconnectAttempts {
-		ws.reconnectAttempt++
-
+	for attempt := 1; ws.config.MaxReconnectAttempts < 0 || attempt <= ws.config.MaxReconnectAttempts; attempt++ {
+		// Disconnect cancels the current context, so re-read it on every attempt
+		ws.mu.Lock()
+		ws.reconnectAttempt = attempt
+		ctx := ws.ctx
+		ws.mu.Unlock()
+
+		delay := ws.reconnectDelay(attempt)
+		ws.config.Logger.Info("websocket reconnecting", "attempt", attempt, "delay", delay)
 		select {
-		case <-ws.ctx.Done():
+		case <-ctx.Done():
+			ws.finishReconnect()
 			return
-		case <-time.After(ws.config.ReconnectInterval):
+		case <-time.After(delay):
 		}
 
-		// Create a new context for reconnection
-		ctx := context.Background()
-		if err := ws.Connect(ctx); err != nil {
+		// Connecting resets the attempt counter and reconnecting flag on success
+		if err := ws.reconnect(ctx); err != nil {
+			if err == errReconnectCancelled {
+				// Disconnect or Close won the race with this attempt
+				ws.finishReconnect()
+				return
+			}
+			ws.config.Metrics.IncReconnect(false)
+			ws.config.Logger.Warn("websocket reconnect failed", "attempt", attempt, "error", err)
 			if ws.config.OnError != nil {
-				ws.config.OnError(fmt.Errorf("reconnect attempt %d failed: %w", ws.reconnectAttempt, err))
+				ws.config.OnError(fmt.Errorf("reconnect attempt %d failed: %w", attempt, err))
 			}
 			continue
 		}
 
+		ws.config.Metrics.IncReconnect(true)
+
 		// Resubscribe to all channels
 		ws.resubscribe()
 		return
 	}
 
+	abandoned := fmt.Errorf("max reconnect attempts (%d) reached", ws.config.MaxReconnectAttempts)
+	ws.mu.Lock()
+	ws.reconnecting = false
+	ws.reconnectAttempt = 0
+	ws.setStateLocked(WSStateFailed, abandoned)
+	ws.mu.Unlock()
+
+	ws.config.Logger.Error("websocket reconnect abandoned", "maxAttempts", ws.config.MaxReconnectAttempts)
 	if ws.config.OnError != nil {
-		ws.config.OnError(fmt.Errorf("max reconnect attempts (%d) reached", ws.config.MaxReconnectAttempts))
+		ws.config.OnError(abandoned)
 	}
 }
 
+// reconnect connects again unless Disconnect or Close has cancelled loopCtx, checked
+// under the lock so that a concurrent Disconnect cannot be undone
+func (ws *WSClient) reconnect(loopCtx context.Context) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if loopCtx.Err() != nil {
+		return errReconnectCancelled
+	}
+	return ws.connectLocked(ws.connectCtx)
+}
+
+// errReconnectCancelled reports that a reconnect attempt was skipped after Disconnect
+var errReconnectCancelled = errors.New("reconnect cancelled")
+
+// reconnectDelay returns the backoff before the given (1-based) reconnect attempt:
+// ReconnectInterval doubled per previous failure, capped at MaxReconnectInterval,
+// minus up to 20% random jitter so that many clients don't reconnect in lockstep
+func (ws *WSClient) reconnectDelay(attempt int) time.Duration {
+	delay := ws.config.ReconnectInterval
+	for i := 1; i < attempt && delay < ws.config.MaxReconnectInterval; i++ {
+		delay *= 2
+	}
+	if delay > ws.config.MaxReconnectInterval {
+		delay = ws.config.MaxReconnectInterval
+	}
+
+	jitter := time.Duration(rand.Float64() * reconnectJitterFraction * float64(delay))
+	delay -= jitter
+	if delay < ws.config.ReconnectInterval {
+		delay = ws.config.ReconnectInterval
+	}
+	return delay
+}
+
+// finishReconnect clears the reconnect state so a later disconnect starts a fresh cycle
+func (ws *WSClient) finishReconnect() {
+	ws.mu.Lock()
+	ws.reconnecting = false
+	ws.reconnectAttempt = 0
+	ws.mu.Unlock()
+}
+
 // resubscribe resubscribes to all tracked subscriptions
 func (ws *WSClient) resubscribe() {
-	ws.subMu.RLock()
-	defer ws.subMu.RUnlock()
+	ws.subMu.Lock()
+	defer ws.subMu.Unlock()
 
-	for _, msg := range ws.subscriptions {
+	for key, msg := range ws.subscriptions {
+		// The new connection has to confirm the subscription again
+		ws.subStates[key] = newSubscriptionState()
 		if err := ws.sendMessage(msg); err != nil {
 			if ws.config.OnError != nil {
 				ws.config.OnError(fmt.Errorf("resubscribe failed: %w", err))
@@ -596,7 +1440,8 @@ func (ws *WSClient) resubscribe() {
 	}
 }
 
-// GetSubscriptions returns a list of current subscriptions
+// GetSubscriptions returns a list of current subscriptions (pending or confirmed).
+// Subscriptions rejected by the server are not included.
 func (ws *WSClient) GetSubscriptions() []string {
 	ws.subMu.RLock()
 	defer ws.subMu.RUnlock()
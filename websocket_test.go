@@ -0,0 +1,759 @@
+package opinionclob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestReconnectResetsAttemptCounter(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url(), ReconnectInterval: 10 * time.Millisecond, MaxReconnectAttempts: 3})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+
+	// Each outage uses up two attempts; without the reset the third outage would exceed the limit
+	for i := 0; i < 3; i++ {
+		f.refuse.Store(true)
+		f.dropAll()
+		waitFor(t, func() bool { return reconnectAttempt(ws) >= 2 })
+		f.refuse.Store(false)
+		waitFor(t, ws.IsConnected)
+		if got := reconnectAttempt(ws); got != 0 {
+			t.Fatalf("outage %d: reconnect attempt = %d after reconnecting, want 0", i, got)
+		}
+	}
+}
+
+func TestReconnectStaysBoundToConnectContext(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url(), ReconnectInterval: 10 * time.Millisecond, MaxReconnectAttempts: UnlimitedReconnectAttempts})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := ws.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+
+	f.dropAll()
+	waitFor(t, func() bool { return f.accepts.Load() == 2 && ws.IsConnected() })
+
+	cancel()
+	waitFor(t, func() bool { return reconnectAttempt(ws) == 0 })
+	// The reconnected connection ends with ctx, so the server's copy of it closes
+	f.dropAll()
+	time.Sleep(100 * time.Millisecond)
+	if got := f.accepts.Load(); got != 2 {
+		t.Fatalf("accepted %d connections after the Connect context was cancelled, want 2", got)
+	}
+}
+
+func TestReconnectDelayBacksOffExponentially(t *testing.T) {
+	ws := NewWSClient(WSConfig{ReconnectInterval: time.Second, MaxReconnectInterval: 30 * time.Second})
+
+	// Up to 20% jitter is removed from 1s, 2s, 4s, 8s, 16s and then the 30s cap
+	bounds := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, max := range bounds {
+		attempt := i + 1
+		for n := 0; n < 50; n++ {
+			delay := ws.reconnectDelay(attempt)
+			min := max * 8 / 10
+			if min < time.Second {
+				min = time.Second
+			}
+			if delay < min || delay > max {
+				t.Fatalf("attempt %d: delay %v outside [%v, %v]", attempt, delay, min, max)
+			}
+		}
+	}
+}
+
+func TestDeadConnectionIsReplaced(t *testing.T) {
+	f := newFakeWSServer(t)
+	// A silent server never answers pings, so the read deadline expires
+	f.silent.Store(true)
+	ws := NewWSClient(WSConfig{Endpoint: f.url(), ReconnectInterval: 10 * time.Millisecond, HeartbeatInterval: 50 * time.Millisecond, PongTimeout: 150 * time.Millisecond})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+	waitFor(t, func() bool { return f.accepts.Load() >= 2 })
+
+	// Once the server answers pings again the connection stays up
+	f.silent.Store(false)
+	silentConns := f.accepts.Load()
+	f.dropAll()
+	waitFor(t, func() bool { return f.accepts.Load() > silentConns && ws.IsConnected() })
+	accepted := f.accepts.Load()
+	time.Sleep(400 * time.Millisecond)
+	if got := f.accepts.Load(); got != accepted {
+		t.Fatalf("healthy connection was replaced: %d connections, want %d", got, accepted)
+	}
+}
+
+func TestServerPingIsAnswered(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+	waitFor(t, func() bool { return f.accepts.Load() == 1 })
+
+	f.mu.Lock()
+	conn := f.conns[0]
+	f.mu.Unlock()
+	pong := make(chan string, 1)
+	conn.SetPongHandler(func(data string) error {
+		pong <- data
+		return nil
+	})
+	if err := conn.WriteControl(websocket.PingMessage, []byte("hello"), time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case data := <-pong:
+		if data != "hello" {
+			t.Fatalf("pong payload = %q, want %q", data, "hello")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no pong for the server's ping")
+	}
+}
+
+func TestPongTimeoutDefaultsToTwoHeartbeats(t *testing.T) {
+	if got := NewWSClient(WSConfig{}).config.PongTimeout; got != DefaultPongTimeout {
+		t.Fatalf("default PongTimeout = %v, want %v", got, DefaultPongTimeout)
+	}
+	if got := NewWSClient(WSConfig{HeartbeatInterval: time.Second}).config.PongTimeout; got != 2*time.Second {
+		t.Fatalf("PongTimeout with a 1s heartbeat = %v, want 2s", got)
+	}
+}
+
+func TestMessagesDeliversTypedEnvelopes(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url(), MessageBufferSize: 8})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+	waitFor(t, func() bool { return f.accepts.Load() == 1 })
+
+	f.broadcast(t, `{"msgType":"trade.order.update","orderId":"o1","side":1}`)
+	f.broadcast(t, `{"msgType":"trade.record.new","tradeNo":"t1","side":"Sell"}`)
+	f.broadcast(t, `{"msgType":"market.depth.diff","tokenId":"111","side":"bids","price":"0.5","size":"10"}`)
+	f.broadcast(t, `{"msgType":"market.last.price","tokenId":"111","price":"0.51"}`)
+	f.broadcast(t, `{"msgType":"market.last.trade","tokenId":"111","side":"Buy","price":"0.52"}`)
+	f.broadcast(t, `{"action":"HEARTBEAT"}`) // not a data message
+
+	check := []func(interface{}) bool{
+		func(d interface{}) bool { u, ok := d.(*OrderUpdate); return ok && u.OrderID == "o1" && u.Side == 1 },
+		func(d interface{}) bool {
+			r, ok := d.(*TradeRecord)
+			return ok && r.TradeNo == "t1" && r.Side == "Sell"
+		},
+		func(d interface{}) bool {
+			m, ok := d.(*MarketDepthDiff)
+			return ok && m.Side == "bids" && m.Size == "10"
+		},
+		func(d interface{}) bool { m, ok := d.(*MarketLastPrice); return ok && m.Price == "0.51" },
+		func(d interface{}) bool {
+			m, ok := d.(*MarketLastTrade)
+			return ok && m.Side == "Buy" && m.Price == "0.52"
+		},
+	}
+	channels := []string{ChannelOrderUpdate, ChannelTradeRecord, ChannelMarketDepthDiff, ChannelMarketLastPrice, ChannelMarketLastTrade}
+	for i, want := range channels {
+		select {
+		case env := <-ws.Messages():
+			if env.Channel != want || !check[i](env.Data) {
+				t.Fatalf("message %d = %s %#v; want a decoded %s message", i, env.Channel, env.Data, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+	select {
+	case env := <-ws.Messages():
+		t.Fatalf("unexpected envelope for a non-data message: %+v", env)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMessagesDropsWhenFull(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url(), MessageBufferSize: 1})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+	waitFor(t, func() bool { return f.accepts.Load() == 1 })
+
+	for i := 0; i < 3; i++ {
+		f.broadcast(t, `{"msgType":"market.last.price","price":"0.5"}`)
+	}
+	waitFor(t, func() bool { return ws.DroppedMessages() == 2 })
+	if n := len(ws.Messages()); n != 1 {
+		t.Fatalf("buffered messages = %d, want 1", n)
+	}
+}
+
+func TestMessagesBlocksWhenFull(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url(), MessageBufferSize: 1, MessageOverflow: WSOverflowBlock})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+	waitFor(t, func() bool { return f.accepts.Load() == 1 })
+
+	for i := 0; i < 3; i++ {
+		f.broadcast(t, `{"msgType":"market.last.price","price":"0.5"}`)
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ws.Messages():
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %d of 3 messages", i)
+		}
+	}
+	if n := ws.DroppedMessages(); n != 0 {
+		t.Fatalf("dropped %d messages with WSOverflowBlock", n)
+	}
+}
+
+func TestCloseClosesMessagesAfterTimeout(t *testing.T) {
+	f := newFakeWSServer(t)
+	release := make(chan struct{})
+	ws := NewWSClient(WSConfig{
+		Endpoint:          f.url(),
+		MessageBufferSize: 1,
+		OnMessage:         func(int, []byte) { <-release },
+	})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return f.accepts.Load() == 1 })
+	f.broadcast(t, `{"msgType":"market.last.price","price":"0.5"}`)
+	time.Sleep(20 * time.Millisecond) // let the read loop enter the callback
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ws.Close(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Close with a blocked callback = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	done := make(chan struct{})
+	go func() {
+		for range ws.Messages() {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Messages channel not closed after the callback returned")
+	}
+	if err := ws.Close(context.Background()); err != nil {
+		t.Fatalf("second Close = %v", err)
+	}
+}
+
+func TestWaitForSubscription(t *testing.T) {
+	f := newFakeWSServer(t)
+	f.ackSubscriptions(func(msg map[string]interface{}) bool { return msg["marketId"] == float64(2) })
+	ws := NewWSClient(WSConfig{Endpoint: f.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+
+	for _, err := range []error{
+		ws.SubscribeBinary(ChannelMarketDepthDiff, 1),
+		ws.SubscribeBinary(ChannelMarketDepthDiff, 2),
+		ws.SubscribeCategorical(ChannelMarketLastPrice, 9),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ws.WaitForSubscription(ChannelMarketDepthDiff, 1, time.Second); err != nil {
+		t.Fatalf("accepted binary subscription: %v", err)
+	}
+	if err := ws.WaitForSubscription(ChannelMarketLastPrice, 9, time.Second); err != nil {
+		t.Fatalf("accepted categorical subscription: %v", err)
+	}
+	if err := ws.WaitForSubscription(ChannelMarketDepthDiff, 2, time.Second); err == nil {
+		t.Fatal("rejected subscription: want an error")
+	}
+	// The rejected subscription is not kept for replay on reconnect
+	if subs := ws.GetSubscriptions(); len(subs) != 2 {
+		t.Fatalf("subscriptions = %v, want the 2 accepted ones", subs)
+	}
+	if err := ws.WaitForSubscription(ChannelMarketDepthDiff, 3, 10*time.Millisecond); err == nil {
+		t.Fatal("unknown subscription: want an error")
+	}
+}
+
+func TestWaitForSubscriptionTimesOutWithoutAck(t *testing.T) {
+	f := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: f.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close(context.Background())
+
+	if err := ws.SubscribeBinary(ChannelMarketDepthDiff, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.WaitForSubscription(ChannelMarketDepthDiff, 1, 50*time.Millisecond); err == nil {
+		t.Fatal("subscription without an ack: want a timeout error")
+	}
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	payload := `{"msgType":"market.depth.diff","tokenId":"111","side":"bids","price":"0.5","size":"` + strings.Repeat("9", 2000) + `"}`
+	for _, serverSupports := range []bool{true, false} {
+		f := newFakeWSServer(t)
+		f.compress.Store(serverSupports)
+		ws := NewWSClient(WSConfig{Endpoint: f.url(), MessageBufferSize: 4, EnableCompression: true})
+		if err := ws.Connect(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if got := ws.CompressionNegotiated(); got != serverSupports {
+			t.Fatalf("server supports compression: %v, negotiated: %v", serverSupports, got)
+		}
+		waitFor(t, func() bool { return f.accepts.Load() == 1 })
+
+		f.broadcast(t, payload)
+		select {
+		case env := <-ws.Messages():
+			if string(env.Raw) != payload {
+				t.Fatalf("server supports compression: %v, frame decoded to %q", serverSupports, env.Raw)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("server supports compression: %v, no message received", serverSupports)
+		}
+		ws.Close(context.Background())
+	}
+}
+
+func TestWSTradeSides(t *testing.T) {
+	tests := []struct {
+		side    string
+		want    OrderSide
+		wantErr bool
+	}{
+		{"buy", OrderSideBuy, false},
+		{"Buy", OrderSideBuy, false},
+		{"SELL", OrderSideSell, false},
+		{"hold", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		for name, parse := range map[string]func() (OrderSide, error){
+			"TradeRecord":     (&TradeRecord{Side: tt.side}).OrderSide,
+			"MarketLastTrade": (&MarketLastTrade{Side: tt.side}).OrderSide,
+		} {
+			got, err := parse()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("%s side %q: expected an error", name, tt.side)
+				}
+				continue
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("%s side %q = %v, %v; want %v", name, tt.side, got, err, tt.want)
+			}
+		}
+	}
+}
+
+func TestSubscribeValidatesChannel(t *testing.T) {
+	server := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: server.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+
+	for _, channel := range SupportedChannels() {
+		if err := ws.SubscribeBinary(channel, 1); err != nil {
+			t.Errorf("SubscribeBinary(%q): %v", channel, err)
+		}
+	}
+	if n := len(ws.GetSubscriptions()); n != len(SupportedChannels()) {
+		t.Fatalf("got %d subscriptions, want %d", n, len(SupportedChannels()))
+	}
+
+	var invalid *InvalidParamError
+	if err := ws.SubscribeBinary("market.depth.dif", 1); !errors.As(err, &invalid) {
+		t.Errorf("SubscribeBinary with a typo: expected InvalidParamError, got %v", err)
+	}
+	if err := ws.SubscribeCategorical("trade.order", 1); !errors.As(err, &invalid) {
+		t.Errorf("SubscribeCategorical with a typo: expected InvalidParamError, got %v", err)
+	}
+	if n := len(ws.GetSubscriptions()); n != len(SupportedChannels()) {
+		t.Fatalf("invalid channels were subscribed: %v", ws.GetSubscriptions())
+	}
+}
+
+// recordActions records the action and channel of every non-heartbeat frame the server reads
+func recordActions(server *fakeWSServer) func() []string {
+	var mu sync.Mutex
+	var frames []string
+	server.handleMessages(func(conn *websocket.Conn, data []byte) {
+		var msg struct {
+			Action  string `json:"action"`
+			Channel string `json:"channel"`
+		}
+		if json.Unmarshal(data, &msg) != nil || msg.Action == ActionHeartbeat {
+			return
+		}
+		mu.Lock()
+		frames = append(frames, msg.Action+" "+msg.Channel)
+		mu.Unlock()
+	})
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), frames...)
+	}
+}
+
+func TestSubscribeAllBinary(t *testing.T) {
+	server := newFakeWSServer(t)
+	frames := recordActions(server)
+	ws := NewWSClient(WSConfig{Endpoint: server.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+
+	if err := ws.SubscribeAllBinary(4); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(frames()) == len(binaryChannels) })
+	for i, channel := range binaryChannels {
+		if got := frames()[i]; got != ActionSubscribe+" "+channel {
+			t.Errorf("frame %d = %q, want SUBSCRIBE %s", i, got, channel)
+		}
+	}
+	if n := len(ws.GetSubscriptions()); n != 5 {
+		t.Fatalf("got %d subscriptions, want 5", n)
+	}
+
+	if err := ws.UnsubscribeAllBinary(4); err != nil {
+		t.Fatal(err)
+	}
+	if subs := ws.GetSubscriptions(); len(subs) != 0 {
+		t.Fatalf("subscriptions left after UnsubscribeAllBinary: %v", subs)
+	}
+
+	if err := ws.SubscribeAllCategorical(7); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(ws.GetSubscriptions()); n != len(categoricalChannels) {
+		t.Fatalf("got %d categorical subscriptions, want %d", n, len(categoricalChannels))
+	}
+}
+
+func TestSubscribeAllUnwindsOnFailure(t *testing.T) {
+	server := newFakeWSServer(t)
+	frames := recordActions(server)
+	ws := NewWSClient(WSConfig{Endpoint: server.url()})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+
+	failing := errors.New("boom")
+	subscribe := func(channel string, id int) error {
+		if channel == ChannelMarketDepthDiff {
+			return failing
+		}
+		return ws.SubscribeBinary(channel, id)
+	}
+	err := ws.subscribeAll(binaryChannels, 4, subscribe, ws.UnsubscribeBinary, binarySubscriptionKey)
+	if !errors.Is(err, failing) {
+		t.Fatalf("expected the subscribe error, got %v", err)
+	}
+	if subs := ws.GetSubscriptions(); len(subs) != 0 {
+		t.Fatalf("partial subscriptions were kept: %v", subs)
+	}
+	want := []string{
+		ActionSubscribe + " " + ChannelOrderUpdate,
+		ActionSubscribe + " " + ChannelTradeRecord,
+		ActionUnsubscribe + " " + ChannelOrderUpdate,
+		ActionUnsubscribe + " " + ChannelTradeRecord,
+	}
+	waitFor(t, func() bool { return len(frames()) == len(want) })
+	for i, got := range frames() {
+		if got != want[i] {
+			t.Errorf("frame %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	// A disconnected client fails on the first channel and tracks nothing
+	offline := NewWSClient(WSConfig{Endpoint: server.url()})
+	if err := offline.SubscribeAllBinary(4); err == nil {
+		t.Fatal("expected an error subscribing while disconnected")
+	}
+	if subs := offline.GetSubscriptions(); len(subs) != 0 {
+		t.Fatalf("subscriptions tracked after failure: %v", subs)
+	}
+}
+
+// stateRecorder records the states and causes passed to OnStateChange
+type stateRecorder struct {
+	mu     sync.Mutex
+	states []WSState
+	errs   []error
+}
+
+func (r *stateRecorder) record(state WSState, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states = append(r.states, state)
+	r.errs = append(r.errs, err)
+}
+
+func (r *stateRecorder) get() ([]WSState, []error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]WSState(nil), r.states...), append([]error(nil), r.errs...)
+}
+
+func TestStateChangesAcrossReconnect(t *testing.T) {
+	server := newFakeWSServer(t)
+	rec := &stateRecorder{}
+	var connects, disconnects atomic.Int32
+	ws := NewWSClient(WSConfig{
+		Endpoint:          server.url(),
+		ReconnectInterval: 10 * time.Millisecond,
+		OnStateChange:     rec.record,
+		OnConnect:         func() { connects.Add(1) },
+		OnDisconnect:      func() { disconnects.Add(1) },
+	})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server.dropAll()
+	waitFor(t, func() bool { states, _ := rec.get(); return len(states) >= 4 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := ws.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	states, errs := rec.get()
+	want := []WSState{WSStateConnecting, WSStateConnected, WSStateReconnecting, WSStateConnected, WSStateDisconnected}
+	if len(states) != len(want) {
+		t.Fatalf("states = %v, want %v", states, want)
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Fatalf("states = %v, want %v", states, want)
+		}
+	}
+	if errs[2] == nil {
+		t.Error("Reconnecting was reported without a cause")
+	}
+	if connects.Load() != 2 || disconnects.Load() != 2 {
+		t.Errorf("OnConnect called %d times and OnDisconnect %d times, want 2 each", connects.Load(), disconnects.Load())
+	}
+}
+
+func TestStateFailedAfterReconnectsRunOut(t *testing.T) {
+	server := newFakeWSServer(t)
+	rec := &stateRecorder{}
+	ws := NewWSClient(WSConfig{
+		Endpoint:             server.url(),
+		ReconnectInterval:    5 * time.Millisecond,
+		MaxReconnectAttempts: 2,
+		OnStateChange:        rec.record,
+	})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	server.refuse.Store(true)
+	server.dropAll()
+	waitFor(t, func() bool { return ws.State() == WSStateFailed })
+
+	states, errs := rec.get()
+	if n := len(states); n == 0 || states[n-1] != WSStateFailed || errs[n-1] == nil {
+		t.Fatalf("states = %v, errors = %v; want a final Failed with its cause", states, errs)
+	}
+
+	unreachable := NewWSClient(WSConfig{Endpoint: "ws://127.0.0.1:1"})
+	if err := unreachable.Connect(context.Background()); err == nil {
+		t.Fatal("Connect to an unreachable endpoint succeeded")
+	}
+	if state := unreachable.State(); state != WSStateFailed {
+		t.Errorf("state after a failed Connect = %v, want Failed", state)
+	}
+}
+
+// stalledListener accepts TCP connections but never answers, so handshakes hang
+func stalledListener(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var conns []net.Conn
+	var mu sync.Mutex
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+	t.Cleanup(func() {
+		l.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+	return "ws://" + l.Addr().String()
+}
+
+func TestHandshakeTimeout(t *testing.T) {
+	endpoint := stalledListener(t)
+
+	ws := NewWSClient(WSConfig{Endpoint: endpoint, HandshakeTimeout: 100 * time.Millisecond})
+	start := time.Now()
+	if err := ws.Connect(context.Background()); err == nil {
+		t.Fatal("Connect to a stalled listener succeeded")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Connect took %v, want about the 100ms handshake timeout", d)
+	}
+
+	// The context can end the dial before a longer handshake timeout
+	ws = NewWSClient(WSConfig{Endpoint: endpoint, HandshakeTimeout: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := ws.Connect(ctx); err == nil {
+		t.Fatal("Connect to a stalled listener succeeded")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Connect took %v, want about the 100ms context deadline", d)
+	}
+}
+
+func TestUpdateAPIKeyReconnectsWithNewKey(t *testing.T) {
+	server := newFakeWSServer(t)
+	frames := recordActions(server)
+	rec := &stateRecorder{}
+	var readErrors atomic.Int32
+	ws := NewWSClient(WSConfig{
+		Endpoint:          server.url(),
+		APIKey:            "old-key",
+		ReconnectInterval: 10 * time.Millisecond,
+		OnStateChange:     rec.record,
+		OnError:           func(error) { readErrors.Add(1) },
+	})
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+	if err := ws.SubscribeOrderUpdateBinary(1); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ws.UpdateAPIKey(ctx, "new-key"); err != nil {
+		t.Fatal(err)
+	}
+	if !ws.IsConnected() {
+		t.Fatal("not connected after UpdateAPIKey returned")
+	}
+	if q := server.lastQuery(); !strings.Contains(q, "apikey=new-key") {
+		t.Fatalf("reconnect query = %q, want the new key", q)
+	}
+	waitFor(t, func() bool {
+		return len(frames()) == 2 && frames()[1] == ActionSubscribe+" "+ChannelOrderUpdate
+	})
+	if n := readErrors.Load(); n != 0 {
+		t.Errorf("OnError called %d times for a key rotation", n)
+	}
+
+	// Later reconnects keep using the new key
+	accepts := server.accepts.Load()
+	server.dropAll()
+	waitFor(t, func() bool { return server.accepts.Load() > accepts && ws.IsConnected() })
+	if q := server.lastQuery(); !strings.Contains(q, "apikey=new-key") {
+		t.Fatalf("query after a dropped connection = %q, want the new key", q)
+	}
+
+	waitFor(t, func() bool { states, _ := rec.get(); return len(states) >= 7 })
+	states, errs := rec.get()
+	if states[2] != WSStateDisconnected || states[3] != WSStateReconnecting || !errors.Is(errs[3], errAPIKeyRotated) || states[4] != WSStateConnected {
+		t.Errorf("states = %v, want the rotation reported as Disconnected, Reconnecting, Connected", states)
+	}
+}
+
+func TestUpdateAPIKeyWhileDisconnected(t *testing.T) {
+	server := newFakeWSServer(t)
+	ws := NewWSClient(WSConfig{Endpoint: server.url(), APIKey: "old-key"})
+	if err := ws.UpdateAPIKey(context.Background(), "new-key"); err != nil {
+		t.Fatal(err)
+	}
+	if n := server.accepts.Load(); n != 0 {
+		t.Fatalf("UpdateAPIKey connected %d times on a disconnected client", n)
+	}
+	if err := ws.Connect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+	if q := server.lastQuery(); !strings.Contains(q, "apikey=new-key") {
+		t.Fatalf("query = %q, want the new key", q)
+	}
+}
+
+func TestDecodeWSHeaderRoutesMessages(t *testing.T) {
+	ack, err := decodeWSHeader([]byte(`{"action":"SUBSCRIBE","channel":"market.depth.diff","marketId":1,"code":0}`))
+	if err != nil || ack.Action != ActionSubscribe || ack.MsgType != "" {
+		t.Fatalf("ack header = %+v, %v; want a SUBSCRIBE action", ack, err)
+	}
+
+	data := []byte(`{"msgType":"market.last.price","marketId":1,"price":"0.5"}`)
+	header, err := decodeWSHeader(data)
+	if err != nil || header.Action != "" || header.MsgType != ChannelMarketLastPrice {
+		t.Fatalf("data header = %+v, %v; want msgType %s", header, err, ChannelMarketLastPrice)
+	}
+	envelope, err := decodeWSMessage(header, data)
+	if err != nil || envelope == nil || envelope.Channel != ChannelMarketLastPrice {
+		t.Fatalf("decodeWSMessage = %+v, %v", envelope, err)
+	}
+	if _, ok := envelope.Data.(*MarketLastPrice); !ok {
+		t.Fatalf("payload = %T, want *MarketLastPrice", envelope.Data)
+	}
+
+	if _, err := decodeWSHeader([]byte(`not json`)); err == nil {
+		t.Fatal("decodeWSHeader accepted invalid JSON")
+	}
+}